@@ -8,7 +8,10 @@ import (
 	"github.com/jackvonhouse/product-catalog/app/transport"
 	"github.com/jackvonhouse/product-catalog/app/usecase"
 	"github.com/jackvonhouse/product-catalog/config"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
 	"github.com/jackvonhouse/product-catalog/internal/infrastructure/server/http"
+	"github.com/jackvonhouse/product-catalog/internal/worker/job"
+	"github.com/jackvonhouse/product-catalog/internal/worker/purge"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 )
 
@@ -22,6 +25,8 @@ type App struct {
 	config config.Config
 	logger log.Logger
 	server http.Server
+	purge  purge.Worker
+	jobs   job.Worker
 }
 
 func New(
@@ -36,11 +41,16 @@ func New(
 	}
 
 	r := repository.New(i, logger)
-	s := service.New(r, config.JWT, logger)
-	u := usecase.New(s, logger)
-	t := transport.New(u, logger)
+	s := service.New(r, i.Cache, config, logger)
+	u := usecase.New(s, config, logger)
+	t := transport.New(u, config, logger)
 
 	httpServer := http.New(t.Router(), config.Server)
+	purgeWorker := purge.New(u.Product, u.Category, u.Idempotency, config.Purge, logger)
+	jobWorker := job.New(u.Job, map[string]job.Handler{
+		dto.JobProductImport: u.Product.RunImport,
+		dto.JobProductExport: u.Product.RunExport,
+	}, config.Jobs, logger)
 
 	return App{
 		infrastructure: i,
@@ -51,10 +61,15 @@ func New(
 		config:         config,
 		logger:         logger,
 		server:         httpServer,
+		purge:          purgeWorker,
+		jobs:           jobWorker,
 	}, nil
 }
 
 func (a App) Run() error {
+	go a.purge.Run()
+	go a.jobs.Run()
+
 	a.logger.Infof("running http server on %d port", a.config.Server.Port)
 
 	return a.server.Run()
@@ -70,6 +85,29 @@ func (a App) Shutdown(
 		return err
 	}
 
+	a.logger.Info("purge worker shutdown")
+
+	if err := a.purge.Shutdown(ctx); err != nil {
+		return err
+	}
+
+	a.logger.Info("job workers shutdown")
+
+	if err := a.jobs.Shutdown(ctx); err != nil {
+		return err
+	}
+
+	a.logger.WithFields(map[string]any{
+		"product":  a.service.Product.Stats(),
+		"category": a.service.Category.Stats(),
+	}).Info("cache stats")
+
+	a.logger.Info("cache shutdown")
+
+	if err := a.infrastructure.Cache.Close(); err != nil {
+		return err
+	}
+
 	a.logger.Info("repository shutdown")
 
 	if err := a.repository.Shutdown(ctx); err != nil {
@@ -3,12 +3,14 @@ package infrastructure
 import (
 	"context"
 	"github.com/jackvonhouse/product-catalog/config"
+	"github.com/jackvonhouse/product-catalog/internal/infrastructure/cache"
 	"github.com/jackvonhouse/product-catalog/internal/infrastructure/postgres"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 )
 
 type Infrastructure struct {
 	Postgres postgres.Database
+	Cache    cache.Database
 }
 
 func New(
@@ -26,7 +28,15 @@ func New(
 		return Infrastructure{}, err
 	}
 
+	c, err := cache.New(ctx, config.Cache, infrastructureLog)
+	if err != nil {
+		infrastructureLog.Warn(err)
+
+		return Infrastructure{}, err
+	}
+
 	return Infrastructure{
 		Postgres: pg,
+		Cache:    c,
 	}, nil
 }
@@ -4,10 +4,14 @@ import (
 	"context"
 	"github.com/jackvonhouse/product-catalog/app/infrastructure"
 	"github.com/jackvonhouse/product-catalog/internal/infrastructure/postgres"
+	"github.com/jackvonhouse/product-catalog/internal/repository/audit"
 	"github.com/jackvonhouse/product-catalog/internal/repository/category"
+	"github.com/jackvonhouse/product-catalog/internal/repository/idempotency"
+	"github.com/jackvonhouse/product-catalog/internal/repository/job"
 	"github.com/jackvonhouse/product-catalog/internal/repository/jwt/refresh"
 	"github.com/jackvonhouse/product-catalog/internal/repository/product"
 	"github.com/jackvonhouse/product-catalog/internal/repository/user"
+	"github.com/jackvonhouse/product-catalog/internal/transaction"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 )
 
@@ -16,6 +20,10 @@ type Repository struct {
 	Category     category.Repository
 	RefreshToken refresh.Repository
 	User         user.Repository
+	Audit        audit.Repository
+	Job          job.Repository
+	Idempotency  idempotency.Repository
+	Transaction  transaction.Manager
 
 	storage postgres.Database
 }
@@ -27,15 +35,23 @@ func New(
 
 	repositoryLogger := logger.WithField("layer", "repository")
 
+	auditRepository := audit.New(
+		infrastructure.Postgres.Database(),
+		repositoryLogger,
+	)
+
 	return Repository{
 		Product: product.New(
 			infrastructure.Postgres.Database(),
+			auditRepository,
 			repositoryLogger,
 		),
 		Category: category.New(
 			infrastructure.Postgres.Database(),
+			auditRepository,
 			repositoryLogger,
 		),
+		Audit: auditRepository,
 		RefreshToken: refresh.New(
 			infrastructure.Postgres.Database(),
 			repositoryLogger,
@@ -44,6 +60,18 @@ func New(
 			infrastructure.Postgres.Database(),
 			repositoryLogger,
 		),
+		Job: job.New(
+			infrastructure.Postgres.Database(),
+			repositoryLogger,
+		),
+		Idempotency: idempotency.New(
+			infrastructure.Postgres.Database(),
+			repositoryLogger,
+		),
+		Transaction: transaction.New(
+			infrastructure.Postgres.Database(),
+			repositoryLogger,
+		),
 
 		storage: infrastructure.Postgres,
 	}
@@ -3,35 +3,56 @@ package service
 import (
 	"github.com/jackvonhouse/product-catalog/app/repository"
 	"github.com/jackvonhouse/product-catalog/config"
+	"github.com/jackvonhouse/product-catalog/internal/infrastructure/cache"
+	"github.com/jackvonhouse/product-catalog/internal/service/audit"
+	"github.com/jackvonhouse/product-catalog/internal/service/cached"
 	"github.com/jackvonhouse/product-catalog/internal/service/category"
+	"github.com/jackvonhouse/product-catalog/internal/service/idempotency"
+	"github.com/jackvonhouse/product-catalog/internal/service/job"
 	"github.com/jackvonhouse/product-catalog/internal/service/jwt/access"
 	"github.com/jackvonhouse/product-catalog/internal/service/jwt/refresh"
 	"github.com/jackvonhouse/product-catalog/internal/service/product"
 	"github.com/jackvonhouse/product-catalog/internal/service/user"
+	"github.com/jackvonhouse/product-catalog/internal/transaction"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 )
 
 type Service struct {
-	Product      product.Service
-	Category     category.Service
+	Product      cached.Product
+	Category     cached.Category
 	AccessToken  access.Service
 	RefreshToken refresh.Service
 	User         user.Service
+	Audit        audit.Service
+	Job          job.Service
+	Idempotency  idempotency.Service
+	Transaction  transaction.Manager
 }
 
 func New(
 	repository repository.Repository,
-	config config.JWT,
+	cache cache.Database,
+	config config.Config,
 	logger log.Logger,
 ) Service {
 
 	serviceLogger := logger.WithField("layer", "service")
 
 	return Service{
-		Product:      product.New(repository.Product, serviceLogger),
-		Category:     category.New(repository.Category, serviceLogger),
-		AccessToken:  access.New(config, serviceLogger),
-		RefreshToken: refresh.New(repository.RefreshToken, config, serviceLogger),
+		Product: cached.NewProduct(
+			product.New(repository.Product, serviceLogger),
+			cache, config.Cache, serviceLogger,
+		),
+		Category: cached.NewCategory(
+			category.New(repository.Category, serviceLogger),
+			cache, config.Cache, serviceLogger,
+		),
+		AccessToken:  access.New(config.JWT, serviceLogger),
+		RefreshToken: refresh.New(repository.RefreshToken, config.JWT, serviceLogger),
 		User:         user.New(repository.User, serviceLogger),
+		Audit:        audit.New(repository.Audit, serviceLogger),
+		Job:          job.New(repository.Job, serviceLogger),
+		Idempotency:  idempotency.New(repository.Idempotency, config.Idempotency, serviceLogger),
+		Transaction:  repository.Transaction,
 	}
 }
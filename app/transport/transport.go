@@ -3,9 +3,15 @@ package transport
 import (
 	"github.com/gorilla/mux"
 	"github.com/jackvonhouse/product-catalog/app/usecase"
+	"github.com/jackvonhouse/product-catalog/config"
 	_ "github.com/jackvonhouse/product-catalog/docs"
+	"github.com/jackvonhouse/product-catalog/internal/transport"
+	"github.com/jackvonhouse/product-catalog/internal/transport/audit"
 	"github.com/jackvonhouse/product-catalog/internal/transport/auth"
 	"github.com/jackvonhouse/product-catalog/internal/transport/category"
+	"github.com/jackvonhouse/product-catalog/internal/transport/cursor"
+	"github.com/jackvonhouse/product-catalog/internal/transport/job"
+	"github.com/jackvonhouse/product-catalog/internal/transport/middleware"
 	"github.com/jackvonhouse/product-catalog/internal/transport/product"
 	"github.com/jackvonhouse/product-catalog/internal/transport/router"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
@@ -18,17 +24,25 @@ type Transport struct {
 
 func New(
 	useCase usecase.UseCase,
+	config config.Config,
 	logger log.Logger,
 ) Transport {
 
 	transportLogger := logger.WithField("layer", "transport")
 
-	r := router.New("/api/v1")
+	c := cursor.New(config.Cursor)
+	cache := transport.NewHTTPCache(config.Server.CacheControl)
+	idempotency := middleware.NewIdempotency(useCase.Idempotency, transportLogger)
+
+	r := router.New(transport.BasePath)
+	r.Router().Use(middleware.RequestId)
 
 	r.Handle(map[string]router.Handlify{
-		"/product":  product.New(useCase.Product, useCase.AccessToken, transportLogger),
-		"/category": category.New(useCase.Category, useCase.AccessToken, transportLogger),
-		"/user":     auth.New(useCase.Auth, transportLogger),
+		"/product":  product.New(useCase.Product, useCase.AccessToken, idempotency, c, cache, transportLogger),
+		"/category": category.New(useCase.Category, useCase.AccessToken, idempotency, c, cache, transportLogger),
+		"/user":     auth.New(useCase.Auth, useCase.AccessToken, transportLogger),
+		"/audit":    audit.New(useCase.Audit, useCase.AccessToken, transportLogger),
+		"/jobs":     job.New(useCase.Job, useCase.AccessToken, transportLogger),
 	})
 
 	r.Router().
@@ -2,8 +2,13 @@ package usecase
 
 import (
 	"github.com/jackvonhouse/product-catalog/app/service"
+	"github.com/jackvonhouse/product-catalog/config"
+	auditrecorder "github.com/jackvonhouse/product-catalog/internal/audit"
+	"github.com/jackvonhouse/product-catalog/internal/usecase/audit"
 	"github.com/jackvonhouse/product-catalog/internal/usecase/auth"
 	"github.com/jackvonhouse/product-catalog/internal/usecase/category"
+	"github.com/jackvonhouse/product-catalog/internal/usecase/idempotency"
+	"github.com/jackvonhouse/product-catalog/internal/usecase/job"
 	"github.com/jackvonhouse/product-catalog/internal/usecase/jwt/access"
 	"github.com/jackvonhouse/product-catalog/internal/usecase/product"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
@@ -14,19 +19,28 @@ type UseCase struct {
 	Category    category.UseCase
 	AccessToken access.UseCase
 	Auth        auth.UseCase
+	Audit       audit.UseCase
+	Job         job.UseCase
+	Idempotency idempotency.UseCase
 }
 
 func New(
 	service service.Service,
+	config config.Config,
 	logger log.Logger,
 ) UseCase {
 
 	useCaseLogger := logger.WithField("layer", "usecase")
 
+	recorder := auditrecorder.New(useCaseLogger)
+
 	return UseCase{
-		Product:     product.New(service.Product, service.Category, useCaseLogger),
-		Category:    category.New(service.Category, useCaseLogger),
-		AccessToken: access.New(service.AccessToken, useCaseLogger),
-		Auth:        auth.New(service.AccessToken, service.RefreshToken, service.User, useCaseLogger),
+		Product:     product.New(service.Product, service.Category, service.Job, service.Transaction, recorder, useCaseLogger),
+		Category:    category.New(service.Category, service.Transaction, recorder, useCaseLogger),
+		AccessToken: access.New(service.AccessToken, service.RefreshToken, config.JWT, useCaseLogger),
+		Auth:        auth.New(service.AccessToken, service.RefreshToken, service.User, service.Transaction, useCaseLogger),
+		Audit:       audit.New(service.Audit, useCaseLogger),
+		Job:         job.New(service.Job, useCaseLogger),
+		Idempotency: idempotency.New(service.Idempotency, useCaseLogger),
 	}
 }
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"time"
+
+	"github.com/jackvonhouse/product-catalog/app/infrastructure"
+	"github.com/jackvonhouse/product-catalog/app/repository"
+	"github.com/jackvonhouse/product-catalog/app/service"
+	"github.com/jackvonhouse/product-catalog/app/usecase"
+	"github.com/jackvonhouse/product-catalog/config"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/transport/validator"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+)
+
+const (
+	passwordEnv = "ADMIN_PASSWORD"
+)
+
+// Создание первого администратора. Пароль передаётся через переменную
+// окружения ADMIN_PASSWORD, чтобы не попадать в историю команд.
+func main() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	logger := log.NewLogrusLogger()
+
+	var configPath, username string
+
+	flag.StringVar(
+		&configPath,
+		"config",
+		"config/config.toml",
+		"The path to the configuration file",
+	)
+
+	flag.StringVar(
+		&username,
+		"username",
+		"admin",
+		"The username of the first admin",
+	)
+
+	flag.Parse()
+
+	password := os.Getenv(passwordEnv)
+	if password == "" {
+		logger.Errorf("%s environment variable is empty", passwordEnv)
+
+		os.Exit(1)
+	}
+
+	if err := validator.IsValidCredentials(username, password); err != nil {
+		logger.Error(err)
+
+		os.Exit(1)
+	}
+
+	cfg, err := config.New(configPath, logger)
+	if err != nil {
+		logger.Error(err)
+
+		os.Exit(1)
+	}
+
+	i, err := infrastructure.New(ctx, cfg, logger)
+	if err != nil {
+		logger.Error(err)
+
+		os.Exit(1)
+	}
+
+	r := repository.New(i, logger)
+	defer r.Shutdown(ctx)
+
+	s := service.New(r, i.Cache, cfg, logger)
+	u := usecase.New(s, cfg, logger)
+
+	credentials := dto.Credentials{
+		Username: username,
+		Password: password,
+	}
+
+	id, err := u.Auth.CreateAdmin(ctx, credentials)
+	if err != nil {
+		logger.Error(err)
+
+		r.Shutdown(ctx)
+		os.Exit(1)
+	}
+
+	logger.Infof("admin %q created with id %d", username, id)
+}
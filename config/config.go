@@ -16,11 +16,46 @@ type JWT struct {
 	AccessToken  Token
 	RefreshToken Token
 	SecretKey    string
+
+	// Strict включает проверку того, что refresh-токен из access-токена
+	// не отозван. Результат проверки кэшируется на RevocationTTL секунд,
+	// 0 отключает кэш
+	Strict        bool
+	RevocationTTL int
+}
+
+type Cursor struct {
+	SecretKey string
 }
 
 type Cache struct {
+	Driver          string
+	Address         string
+	Password        string
+	DB              int
+	PoolSize        int
 	ExpireDuration  int
 	CleanupInterval int
+	TTL             int
+	NotFoundTTL     int
+}
+
+type Purge struct {
+	Retention int
+	Interval  int
+}
+
+type Jobs struct {
+	Workers      int
+	PollInterval int
+	StaleAfter   int
+	MaxAttempts  int
+	Retention    int
+	DrainTimeout int
+}
+
+type Idempotency struct {
+	TTL int
 }
 
 type Database struct {
@@ -43,14 +78,19 @@ func (d Database) String() string {
 }
 
 type ServerHTTP struct {
-	Port int
+	Port         int
+	CacheControl string
 }
 
 type Config struct {
-	Database Database
-	Cache    Cache
-	JWT      JWT
-	Server   ServerHTTP
+	Database    Database
+	Cache       Cache
+	JWT         JWT
+	Cursor      Cursor
+	Purge       Purge
+	Jobs        Jobs
+	Idempotency Idempotency
+	Server      ServerHTTP
 }
 
 func New(
@@ -104,21 +144,68 @@ func New(
 		},
 
 		Cache: Cache{
+			Driver: viper.GetString(
+				fmt.Sprintf("%s.driver", cachePrefix),
+			),
+			Address: viper.GetString(
+				fmt.Sprintf("%s.address", cachePrefix),
+			),
+			Password: viper.GetString(
+				fmt.Sprintf("%s.password", cachePrefix),
+			),
+			DB: viper.GetInt(
+				fmt.Sprintf("%s.db", cachePrefix),
+			),
+			PoolSize: viper.GetInt(
+				fmt.Sprintf("%s.pool_size", cachePrefix),
+			),
 			ExpireDuration: viper.GetInt(
 				fmt.Sprintf("%s.token_expire_duration", cachePrefix),
 			),
 			CleanupInterval: viper.GetInt(
 				fmt.Sprintf("%s.cleanup_interval", cachePrefix),
 			),
+			TTL: viper.GetInt(
+				fmt.Sprintf("%s.ttl", cachePrefix),
+			),
+			NotFoundTTL: viper.GetInt(
+				fmt.Sprintf("%s.not_found_ttl", cachePrefix),
+			),
 		},
 
 		Server: ServerHTTP{
-			Port: viper.GetInt("server.http.port"),
+			Port:         viper.GetInt("server.http.port"),
+			CacheControl: viper.GetString("server.http.cache_control"),
+		},
+
+		Cursor: Cursor{
+			SecretKey: viper.GetString("cursor.secret"),
+		},
+
+		Purge: Purge{
+			Retention: viper.GetInt("purge.retention"),
+			Interval:  viper.GetInt("purge.interval"),
+		},
+
+		Jobs: Jobs{
+			Workers:      viper.GetInt("jobs.workers"),
+			PollInterval: viper.GetInt("jobs.poll_interval"),
+			StaleAfter:   viper.GetInt("jobs.stale_after"),
+			MaxAttempts:  viper.GetInt("jobs.max_attempts"),
+			Retention:    viper.GetInt("jobs.retention"),
+			DrainTimeout: viper.GetInt("jobs.drain_timeout"),
+		},
+
+		Idempotency: Idempotency{
+			TTL: viper.GetInt("idempotency.ttl"),
 		},
 
 		JWT: JWT{
 			SecretKey: viper.GetString(fmt.Sprintf("%s.secret", tokenPrefix)),
 
+			Strict:        viper.GetBool(fmt.Sprintf("%s.strict", tokenPrefix)),
+			RevocationTTL: viper.GetInt(fmt.Sprintf("%s.revocation_ttl", tokenPrefix)),
+
 			AccessToken: Token{
 				Exp: viper.GetInt(fmt.Sprintf("%s.access.exp", tokenPrefix)),
 			},
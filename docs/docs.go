@@ -15,9 +15,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/category": {
+        "/audit": {
             "get": {
-                "description": "Получение категорий",
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Получение страницы журнала изменений товаров и категорий: кто, когда и что изменил, состояние сущности до и после изменения. Записи упорядочены от новых к старым. Доступно только администраторам",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,35 +30,88 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Категория"
+                    "Журнал изменений"
                 ],
-                "summary": "Получить категории",
+                "summary": "Получить журнал изменений",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Тип сущности: product или category",
+                        "name": "entity",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Идентификатор сущности",
+                        "name": "id",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "description": "Лимит",
                         "name": "limit",
-                        "in": "path"
+                        "in": "query"
                     },
                     {
                         "type": "integer",
                         "description": "Смещение",
                         "name": "offset",
-                        "in": "path"
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.Category"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_transport.Page"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.AuditRecord"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "int",
+                                "description": "Общее количество записей"
                             }
                         }
                     },
-                    "404": {
-                        "description": "Категории отсутствуют",
+                    "400": {
+                        "description": "Некорректный тип или идентификатор сущности",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -75,14 +133,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Создание категории",
+            }
+        },
+        "/category": {
+            "get": {
+                "description": "Получение страницы категорий с общим количеством (также в заголовке X-Total-Count) и ссылками на соседние страницы. При передаче параметра cursor используется курсорная пагинация, next_cursor отсутствует на последней странице",
                 "consumes": [
                     "application/json"
                 ],
@@ -92,32 +147,91 @@ const docTemplate = `{
                 "tags": [
                     "Категория"
                 ],
-                "summary": "Создать категорию",
+                "summary": "Получить категории",
                 "parameters": [
                     {
-                        "description": "Данные о категории",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.CreateCategory"
-                        }
+                        "type": "integer",
+                        "description": "Лимит",
+                        "name": "limit",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы. Пустое значение включает курсорную пагинацию с первой страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Включить удалённые категории. Доступно только администраторам",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag ранее полученного ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_transport.Page"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.Category"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "Политика кэширования"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Хэш содержимого ответа"
+                            },
+                            "X-Total-Count": {
+                                "type": "int",
+                                "description": "Общее количество категорий"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Содержимое не изменилось"
+                    },
+                    "400": {
+                        "description": "Некорректный курсор",
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "id": {
-                                    "type": "integer"
+                                "error": {
+                                    "type": "string"
                                 }
                             }
                         }
                     },
                     "401": {
-                        "description": "Пользователь не авторизован",
+                        "description": "Пользователь не авторизован (только с include_deleted)",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -127,8 +241,19 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "409": {
-                        "description": "Категория уже существует",
+                    "403": {
+                        "description": "Недостаточно прав (только с include_deleted)",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Категории отсутствуют",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -150,16 +275,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/category/{id}": {
-            "put": {
+            },
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Обновление категории",
+                "description": "Создание категории",
                 "consumes": [
                     "application/json"
                 ],
@@ -169,7 +292,7 @@ const docTemplate = `{
                 "tags": [
                     "Категория"
                 ],
-                "summary": "Обновить категорию",
+                "summary": "Создать категорию",
                 "parameters": [
                     {
                         "description": "Данные о категории",
@@ -177,15 +300,14 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.UpdateCategory"
+                            "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.CreateCategory"
                         }
                     },
                     {
-                        "type": "integer",
-                        "description": "Идентификатор категории",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повторный запрос с тем же ключом возвращает сохранённый ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -200,6 +322,17 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "400": {
+                        "description": "Некорректные данные категории",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
                     "401": {
                         "description": "Пользователь не авторизован",
                         "schema": {
@@ -211,8 +344,19 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
                     "404": {
-                        "description": "Категория не найдена",
+                        "description": "Родительская категория не найдена",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -223,7 +367,18 @@ const docTemplate = `{
                         }
                     },
                     "409": {
-                        "description": "Категория уже существует",
+                        "description": "Категория уже существует или запрос с тем же ключом идемпотентности ещё выполняется",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности использован для другого запроса",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -245,14 +400,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Удаление категории",
+            }
+        },
+        "/category/tree": {
+            "get": {
+                "description": "Получение всех категорий в виде дерева",
                 "consumes": [
                     "application/json"
                 ],
@@ -262,49 +414,37 @@ const docTemplate = `{
                 "tags": [
                     "Категория"
                 ],
-                "summary": "Удалить категорию",
+                "summary": "Получить дерево категорий",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Идентификатор категории",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "ETag ранее полученного ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "id": {
-                                    "type": "integer"
-                                }
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.CategoryTree"
                             }
-                        }
-                    },
-                    "401": {
-                        "description": "Пользователь не авторизован",
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "error": {
-                                    "type": "string"
-                                }
+                        },
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "Политика кэширования"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Хэш содержимого ответа"
                             }
                         }
                     },
-                    "404": {
-                        "description": "Категория не найдена",
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "error": {
-                                    "type": "string"
-                                }
-                            }
-                        }
+                    "304": {
+                        "description": "Содержимое не изменилось"
                     },
                     "500": {
                         "description": "Неизвестная ошибка",
@@ -320,9 +460,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/product": {
+        "/category/{id}": {
             "get": {
-                "description": "Получение товаров",
+                "description": "Получение категории по идентификатору",
                 "consumes": [
                     "application/json"
                 ],
@@ -330,41 +470,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Товар"
+                    "Категория"
                 ],
-                "summary": "Получить товары",
+                "summary": "Получить категорию",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Лимит",
-                        "name": "limit",
-                        "in": "path"
+                        "description": "Идентификатор категории",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Смещение",
-                        "name": "offset",
-                        "in": "path"
+                        "type": "string",
+                        "description": "ETag ранее полученного ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
                     },
                     {
-                        "type": "integer",
-                        "description": "Идентификатор категории",
-                        "name": "category_id",
-                        "in": "path"
+                        "type": "string",
+                        "description": "Значение Last-Modified ранее полученного ответа",
+                        "name": "If-Modified-Since",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.Product"
+                            "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.Category"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия категории"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Время последнего изменения категории"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Категория не изменилась"
+                    },
+                    "400": {
+                        "description": "Некорректный идентификатор категории",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
                             }
                         }
                     },
                     "404": {
-                        "description": "Товары отсутствуют или категория не найдена",
+                        "description": "Категория не найдена",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -387,13 +549,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Создание товара с определённой категорией",
+                "description": "Обновление категории",
                 "consumes": [
                     "application/json"
                 ],
@@ -401,18 +563,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Товар"
+                    "Категория"
                 ],
-                "summary": "Создать товар",
+                "summary": "Обновить категорию",
                 "parameters": [
                     {
-                        "description": "Данные о товаре",
+                        "description": "Данные о категории",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.CreateProduct"
+                            "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.UpdateCategory"
                         }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Идентификатор категории",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ожидаемая версия категории из заголовка ETag",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -427,8 +602,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Пользователь не авторизован",
+                    "400": {
+                        "description": "Категория не может быть потомком самой себя",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -438,8 +613,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "409": {
-                        "description": "Товар уже существует",
+                    "401": {
+                        "description": "Пользователь не авторизован",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -449,7 +624,51 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Категория не найдена",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Категория уже существует",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Категория была изменена",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
                         "description": "Неизвестная ошибка",
                         "schema": {
                             "type": "object",
@@ -461,16 +680,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/product/{id}": {
-            "put": {
+            },
+            "delete": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Обновление товара",
+                "description": "Удаление категории. Категорию с неудалёнными дочерними категориями удалить нельзя",
                 "consumes": [
                     "application/json"
                 ],
@@ -478,25 +695,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Товар"
+                    "Категория"
                 ],
-                "summary": "Обновить товар",
+                "summary": "Удалить категорию",
                 "parameters": [
-                    {
-                        "description": "Данные о товаре",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.UpdateProduct"
-                        }
-                    },
                     {
                         "type": "integer",
-                        "description": "Идентификатор товара",
+                        "description": "Идентификатор категории",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ожидаемая версия категории из заголовка ETag",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -511,6 +725,17 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "400": {
+                        "description": "Некорректный заголовок If-Match",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
                     "401": {
                         "description": "Пользователь не авторизован",
                         "schema": {
@@ -522,8 +747,19 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
                     "404": {
-                        "description": "Товар или категория не найдены",
+                        "description": "Категория не найдена",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -534,7 +770,18 @@ const docTemplate = `{
                         }
                     },
                     "409": {
-                        "description": "Товар уже существует",
+                        "description": "У категории есть дочерние категории",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Категория была изменена",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -556,14 +803,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Удаление товара",
+            }
+        },
+        "/category/{id}/ancestors": {
+            "get": {
+                "description": "Получение цепочки родительских категорий, начиная с корневой",
                 "consumes": [
                     "application/json"
                 ],
@@ -571,13 +815,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Товар"
+                    "Категория"
                 ],
-                "summary": "Удалить товар",
+                "summary": "Получить предков категории",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Идентификатор товара",
+                        "description": "Идентификатор категории",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -587,16 +831,14 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "id": {
-                                    "type": "integer"
-                                }
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.Category"
                             }
                         }
                     },
-                    "401": {
-                        "description": "Пользователь не авторизован",
+                    "400": {
+                        "description": "Некорректный идентификатор категории",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -607,7 +849,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Товар не найден",
+                        "description": "Категория не найдена",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -631,9 +873,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/refresh": {
-            "post": {
-                "description": "Обновление токенов",
+        "/category/{id}/children": {
+            "get": {
+                "description": "Получение непосредственных дочерних категорий",
                 "consumes": [
                     "application/json"
                 ],
@@ -641,33 +883,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Авторизация"
+                    "Категория"
                 ],
-                "summary": "Обновление токенов",
+                "summary": "Получить дочерние категории",
                 "parameters": [
                     {
-                        "description": "Пара токенов",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                        "type": "integer",
+                        "description": "Идентификатор категории",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.Category"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный идентификатор категории",
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "access_token": {
-                                    "type": "string"
-                                },
-                                "refresh_token": {
+                                "error": {
                                     "type": "string"
                                 }
                             }
                         }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Категория не найдена",
                         "schema": {
-                            "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.TokenPair"
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
                         }
                     },
                     "500": {
@@ -684,9 +941,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/sign-in": {
+        "/category/{id}/restore": {
             "post": {
-                "description": "Авторизация пользователя",
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Восстановление удалённого категории вместе с его привязками к товарам",
                 "consumes": [
                     "application/json"
                 ],
@@ -694,37 +956,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Авторизация"
+                    "Категория"
                 ],
-                "summary": "Авторизация",
+                "summary": "Восстановить категорию",
                 "parameters": [
                     {
-                        "description": "Данные пользователя",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "password": {
-                                    "type": "string"
-                                },
-                                "username": {
-                                    "type": "string"
-                                }
-                            }
-                        }
+                        "type": "integer",
+                        "description": "Идентификатор категории",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.TokenPair"
+                            "type": "object",
+                            "properties": {
+                                "id": {
+                                    "type": "integer"
+                                }
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Неизвестная ошибка",
+                    "400": {
+                        "description": "Родительская категория удалена",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -733,51 +990,31 @@ const docTemplate = `{
                                 }
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/user/sign-up": {
-            "post": {
-                "description": "Регистрация нового пользователя",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Авторизация"
-                ],
-                "summary": "Регистрация",
-                "parameters": [
-                    {
-                        "description": "Данные пользователя",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "password": {
-                                    "type": "string"
-                                },
-                                "username": {
+                                "error": {
                                     "type": "string"
                                 }
                             }
                         }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
                         "schema": {
-                            "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.TokenPair"
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
                         }
                     },
-                    "409": {
-                        "description": "Пользователь уже существует",
+                    "404": {
+                        "description": "Удалённая категория не найдена",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -787,8 +1024,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Неизвестная ошибка",
+                    "409": {
+                        "description": "Категория с таким названием уже существует",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -797,15 +1034,2573 @@ const docTemplate = `{
                                 }
                             }
                         }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Получение состояния фоновой задачи: статус (pending, running, done, failed, cancelled), число обработанных строк и результат. Задача доступна своему автору и администраторам",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Задача"
+                ],
+                "summary": "Получить задачу",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Идентификатор задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный идентификатор задачи",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Задача не найдена",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Задача из очереди отменяется сразу, выполняющаяся — при следующей отметке обработчика. Изменения прерванного импорта не сохраняются",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Задача"
+                ],
+                "summary": "Отменить задачу",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Идентификатор задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный идентификатор задачи",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Задача не найдена",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Задача уже завершена",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/file": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Скачивание файла, подготовленного завершённой задачей выгрузки",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Задача"
+                ],
+                "summary": "Скачать файл задачи",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Идентификатор задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        },
+                        "headers": {
+                            "Content-Disposition": {
+                                "type": "string",
+                                "description": "Имя файла"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный идентификатор задачи",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Задача или файл не найдены",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/product": {
+            "get": {
+                "description": "Получение страницы товаров с общим количеством (также в заголовке X-Total-Count) и ссылками на соседние страницы. При передаче параметра cursor используется курсорная пагинация, next_cursor отсутствует на последней странице",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Товар"
+                ],
+                "summary": "Получить товары",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Лимит",
+                        "name": "limit",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы. Пустое значение включает курсорную пагинацию с первой страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сортировка через запятую: id, name, price, stock, sku, created_at. Префикс - задаёт убывающий порядок, например name,-id",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Префикс названия товара",
+                        "name": "name_prefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификаторы категорий через запятую",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Включить товары всех дочерних категорий",
+                        "name": "include_descendants",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Товары, созданные после указанного времени (RFC 3339)",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Включить удалённые товары. Доступно только администраторам",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag ранее полученного ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_transport.Page"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.Product"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "Политика кэширования"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Хэш содержимого ответа"
+                            },
+                            "X-Total-Count": {
+                                "type": "int",
+                                "description": "Общее количество товаров"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Содержимое не изменилось"
+                    },
+                    "400": {
+                        "description": "Некорректный курсор, сортировка или фильтр",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован (только с include_deleted)",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав (только с include_deleted)",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Товары отсутствуют или категория не найдена",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Создание товара с указанными категориями. Цена указывается в минимальных единицах валюты (копейках, центах)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Товар"
+                ],
+                "summary": "Создать товар",
+                "parameters": [
+                    {
+                        "description": "Данные о товаре",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.CreateProduct"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повторный запрос с тем же ключом возвращает сохранённый ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "id": {
+                                    "type": "integer"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные данные о товаре",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Категория не найдена",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Товар уже существует или запрос с тем же ключом идемпотентности ещё выполняется",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности использован для другого запроса",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/product/batch": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Создание, изменение, удаление товаров и их привязка к категориям одним запросом. Операции выполняются по порядку: create принимает данные в поле create, update — идентификатор, версию и данные в поле update, delete — идентификатор и версию, attach и detach — идентификатор товара и category_id. По умолчанию все операции выполняются в одной транзакции и отменяются при первой ошибке: упавшая операция получает статус failed, предыдущие — rolled_back, последующие — skipped. С параметром atomic=false каждая операция фиксируется отдельно. В пакете не больше 100 операций",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Товар"
+                ],
+                "summary": "Выполнить пакет операций над товарами",
+                "parameters": [
+                    {
+                        "description": "Операции",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "operations": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.BatchOperation"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Отменить все операции при ошибке одной из них, по умолчанию true",
+                        "name": "atomic",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.BatchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректная операция",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/product/export": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Потоковая выгрузка всех товаров с названиями категорий в CSV или JSON Lines. Поддерживает те же фильтры и сортировку, что и получение товаров. Колонки CSV совместимы с импортом",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Товар"
+                ],
+                "summary": "Выгрузить товары",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Формат файла, по умолчанию csv",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сортировка через запятую: id, name, price, stock, sku, created_at. Префикс - задаёт убывающий порядок, например name,-id",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Префикс названия товара",
+                        "name": "name_prefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификаторы категорий через запятую",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Включить товары всех дочерних категорий",
+                        "name": "include_descendants",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Товары, созданные после указанного времени (RFC 3339)",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Включить удалённые товары. Доступно только администраторам",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        },
+                        "headers": {
+                            "Content-Disposition": {
+                                "type": "string",
+                                "description": "Имя файла выгрузки"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный формат, сортировка или фильтр",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Постановка выгрузки в очередь задач. Параметры совпадают с потоковой выгрузкой. Готовый файл скачивается по адресу /jobs/{id}/file",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Товар"
+                ],
+                "summary": "Выгрузить товары фоновой задачей",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Формат файла, по умолчанию csv",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сортировка через запятую: id, name, price, stock, sku, created_at. Префикс - задаёт убывающий порядок, например name,-id",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Префикс названия товара",
+                        "name": "name_prefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификаторы категорий через запятую",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Включить товары всех дочерних категорий",
+                        "name": "include_descendants",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Товары, созданные после указанного времени (RFC 3339)",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Включить удалённые товары. Доступно только администраторам",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Задача поставлена в очередь",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "job_id": {
+                                    "type": "integer"
+                                }
+                            }
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "Адрес задачи"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный формат, сортировка или фильтр",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/product/import": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Массовое создание товаров из CSV или JSON Lines. Формат задаётся параметром format или заголовком Content-Type (text/csv, application/x-ndjson). В CSV обязателен заголовок с колонками name, price, sku, categories (опционально description, currency, stock), категории перечисляются через \"|\". Отсутствующие категории создаются по имени. Некорректные строки не прерывают импорт и попадают в отчёт с номером строки файла. С параметром async=true файл проверяется сразу, а сохранение выполняется фоновой задачей: в ответ возвращается её идентификатор и адрес в заголовке Location",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Товар"
+                ],
+                "summary": "Импортировать товары",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Формат файла",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Проверить файл без сохранения",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Выполнить импорт фоновой задачей",
+                        "name": "async",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.ImportResult"
+                        }
+                    },
+                    "202": {
+                        "description": "Задача поставлена в очередь",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "job_id": {
+                                    "type": "integer"
+                                }
+                            }
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "Адрес задачи"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный файл",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Товар уже существует",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "413": {
+                        "description": "Файл слишком большой",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/product/search": {
+            "get": {
+                "description": "Полнотекстовый поиск товаров по названию и описанию. Результаты упорядочены по релевантности, сниппет экранирован как HTML, совпадения в нём выделены тегом \u003cb\u003e",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Товар"
+                ],
+                "summary": "Найти товары",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Поисковый запрос",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Лимит",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.FoundProduct"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Пустой поисковый запрос",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}": {
+            "get": {
+                "description": "Получение товара вместе со всеми категориями, к которым он относится",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Товар"
+                ],
+                "summary": "Получить товар",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Идентификатор товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag ранее полученного ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Значение Last-Modified ранее полученного ответа",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.ProductWithCategories"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия товара"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Время последнего изменения товара и его категорий"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Товар не изменился"
+                    },
+                    "400": {
+                        "description": "Некорректный идентификатор товара",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Обновление товара",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Товар"
+                ],
+                "summary": "Обновить товар",
+                "parameters": [
+                    {
+                        "description": "Данные о товаре",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.UpdateProduct"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Идентификатор товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ожидаемая версия товара из заголовка ETag",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "id": {
+                                    "type": "integer"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные данные о товаре",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Товар или категория не найдены",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Товар уже существует",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Товар был изменён",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Удаление товара",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Товар"
+                ],
+                "summary": "Удалить товар",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Идентификатор товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ожидаемая версия товара из заголовка ETag",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "id": {
+                                    "type": "integer"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный заголовок If-Match",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Товар был изменён",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}/category": {
+            "get": {
+                "description": "Получение всех категорий, к которым относится товар",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Товар"
+                ],
+                "summary": "Получить категории товара",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Идентификатор товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.Category"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный идентификатор товара",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}/category/{category_id}": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Привязка товара к категории",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Товар"
+                ],
+                "summary": "Добавить товар в категорию",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Идентификатор товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Идентификатор категории",
+                        "name": "category_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "category_id": {
+                                    "type": "integer"
+                                },
+                                "product_id": {
+                                    "type": "integer"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный идентификатор товара или категории",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Товар или категория не найдены",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Товар уже находится в категории",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Отвязка товара от категории",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Товар"
+                ],
+                "summary": "Убрать товар из категории",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Идентификатор товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Идентификатор категории",
+                        "name": "category_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "category_id": {
+                                    "type": "integer"
+                                },
+                                "product_id": {
+                                    "type": "integer"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный идентификатор товара или категории",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Товар, категория или товар в категории не найдены",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Восстановление удалённого товара вместе с его привязками к категориям",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Товар"
+                ],
+                "summary": "Восстановить товар",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Идентификатор товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "id": {
+                                    "type": "integer"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Удалённый товар не найден",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Товар с таким названием или артикулом уже существует",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/refresh": {
+            "post": {
+                "description": "Обновление токенов. Refresh-токен одноразовый: повторное использование отзывает все токены, выпущенные вместе с ним",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Авторизация"
+                ],
+                "summary": "Обновление токенов",
+                "parameters": [
+                    {
+                        "description": "Пара токенов",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "access_token": {
+                                    "type": "string"
+                                },
+                                "refresh_token": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.TokenPair"
+                        }
+                    },
+                    "401": {
+                        "description": "Токен истёк или отозван",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Активные сессии текущего пользователя: устройство, user agent и IP входа, время последнего обновления токенов. Сессия, в которой выполнен запрос, отмечена полем current",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Авторизация"
+                ],
+                "summary": "Список сессий",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.Session"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Завершение сессии текущего пользователя на другом устройстве: её refresh-токены удаляются",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Авторизация"
+                ],
+                "summary": "Завершить сессию",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Идентификатор сессии",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "id": {
+                                    "type": "integer"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный идентификатор",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Сессия не найдена",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/sign-in": {
+            "post": {
+                "description": "Авторизация пользователя. Каждый вход открывает отдельную сессию, сессии на других устройствах сохраняются",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Авторизация"
+                ],
+                "summary": "Авторизация",
+                "parameters": [
+                    {
+                        "description": "Данные пользователя и необязательное имя устройства",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "device_name": {
+                                    "type": "string"
+                                },
+                                "password": {
+                                    "type": "string"
+                                },
+                                "username": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.TokenPair"
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/sign-out": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Завершение текущей сессии. Access-токен остаётся действительным до истечения срока, а в строгом режиме — не дольше revocation_ttl секунд",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Авторизация"
+                ],
+                "summary": "Выход",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/sign-out-all": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Завершение всех сессий текущего пользователя, включая текущую",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Авторизация"
+                ],
+                "summary": "Выход на всех устройствах",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/sign-up": {
+            "post": {
+                "description": "Регистрация нового пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Авторизация"
+                ],
+                "summary": "Регистрация",
+                "parameters": [
+                    {
+                        "description": "Данные пользователя и необязательное имя устройства",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "device_name": {
+                                    "type": "string"
+                                },
+                                "password": {
+                                    "type": "string"
+                                },
+                                "username": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.TokenPair"
+                        }
+                    },
+                    "409": {
+                        "description": "Пользователь уже существует",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Изменение роли пользователя (admin, editor, viewer). Доступно только администраторам. Новая роль применяется после обновления токенов",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Авторизация"
+                ],
+                "summary": "Изменить роль пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Идентификатор пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новая роль",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "role": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "id": {
+                                    "type": "integer"
+                                },
+                                "role": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректная роль",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Неизвестная ошибка",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_jackvonhouse_product-catalog_internal_dto.AuditRecord": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "actor_id": {
+                    "type": "integer"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_jackvonhouse_product-catalog_internal_dto.BatchOperation": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "create": {
+                    "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.CreateProduct"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update",
+                        "delete",
+                        "attach",
+                        "detach"
+                    ]
+                },
+                "update": {
+                    "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.UpdateProduct"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_jackvonhouse_product-catalog_internal_dto.BatchOperationResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "done",
+                        "failed",
+                        "rolled_back",
+                        "skipped"
+                    ]
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_jackvonhouse_product-catalog_internal_dto.BatchResult": {
+            "type": "object",
+            "properties": {
+                "atomic": {
+                    "type": "boolean"
+                },
+                "committed": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.BatchOperationResult"
                     }
+                },
+                "succeeded": {
+                    "type": "integer"
                 }
             }
-        }
-    },
-    "definitions": {
+        },
         "github_com_jackvonhouse_product-catalog_internal_dto.Category": {
             "type": "object",
             "properties": {
+                "deleted_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "default": 1
+                },
+                "name": {
+                    "type": "string",
+                    "default": "Категория"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer",
+                    "default": 1
+                }
+            }
+        },
+        "github_com_jackvonhouse_product-catalog_internal_dto.CategoryTree": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.CategoryTree"
+                    }
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer",
                     "default": 1
@@ -813,6 +3608,16 @@ const docTemplate = `{
                 "name": {
                     "type": "string",
                     "default": "Категория"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer",
+                    "default": 1
                 }
             }
         },
@@ -821,25 +3626,258 @@ const docTemplate = `{
             "properties": {
                 "name": {
                     "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
                 }
             }
         },
         "github_com_jackvonhouse_product-catalog_internal_dto.CreateProduct": {
             "type": "object",
             "properties": {
-                "category_id": {
+                "category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "currency": {
+                    "type": "string",
+                    "default": "RUB"
+                },
+                "description": {
+                    "type": "string",
+                    "default": "Описание товара"
+                },
+                "name": {
+                    "type": "string",
+                    "default": "Товар"
+                },
+                "price": {
+                    "type": "integer",
+                    "default": 10000
+                },
+                "sku": {
+                    "type": "string",
+                    "default": "SKU-1"
+                },
+                "stock": {
+                    "type": "integer",
+                    "default": 10
+                }
+            }
+        },
+        "github_com_jackvonhouse_product-catalog_internal_dto.FoundProduct": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "default": "RUB"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "default": "Описание товара"
+                },
+                "id": {
                     "type": "integer",
                     "default": 1
                 },
                 "name": {
                     "type": "string",
                     "default": "Товар"
+                },
+                "price": {
+                    "type": "integer",
+                    "default": 10000
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "sku": {
+                    "type": "string",
+                    "default": "SKU-1"
+                },
+                "snippet": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer",
+                    "default": 10
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer",
+                    "default": 1
+                }
+            }
+        },
+        "github_com_jackvonhouse_product-catalog_internal_dto.ImportError": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_jackvonhouse_product-catalog_internal_dto.ImportResult": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "created_categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.ImportError"
+                    }
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_jackvonhouse_product-catalog_internal_dto.Job": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "actor_id": {
+                    "type": "integer"
+                },
+                "attempt": {
+                    "type": "integer"
+                },
+                "cancel_requested": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "progress": {
+                    "type": "integer"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "object"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
         "github_com_jackvonhouse_product-catalog_internal_dto.Product": {
             "type": "object",
             "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "default": "RUB"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "default": "Описание товара"
+                },
+                "id": {
+                    "type": "integer",
+                    "default": 1
+                },
+                "name": {
+                    "type": "string",
+                    "default": "Товар"
+                },
+                "price": {
+                    "type": "integer",
+                    "default": 10000
+                },
+                "sku": {
+                    "type": "string",
+                    "default": "SKU-1"
+                },
+                "stock": {
+                    "type": "integer",
+                    "default": 10
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer",
+                    "default": 1
+                }
+            }
+        },
+        "github_com_jackvonhouse_product-catalog_internal_dto.ProductWithCategories": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_jackvonhouse_product-catalog_internal_dto.Category"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "default": "RUB"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "default": "Описание товара"
+                },
                 "id": {
                     "type": "integer",
                     "default": 1
@@ -847,6 +3885,48 @@ const docTemplate = `{
                 "name": {
                     "type": "string",
                     "default": "Товар"
+                },
+                "price": {
+                    "type": "integer",
+                    "default": 10000
+                },
+                "sku": {
+                    "type": "string",
+                    "default": "SKU-1"
+                },
+                "stock": {
+                    "type": "integer",
+                    "default": 10
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer",
+                    "default": 1
+                }
+            }
+        },
+        "github_com_jackvonhouse_product-catalog_internal_dto.Session": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "boolean"
+                },
+                "device_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
                 }
             }
         },
@@ -869,12 +3949,21 @@ const docTemplate = `{
                 },
                 "name": {
                     "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
                 }
             }
         },
         "github_com_jackvonhouse_product-catalog_internal_dto.UpdateProduct": {
             "type": "object",
             "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -886,6 +3975,39 @@ const docTemplate = `{
                 },
                 "old_category_id": {
                     "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_jackvonhouse_product-catalog_internal_transport.Page": {
+            "type": "object",
+            "properties": {
+                "items": {},
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "prev": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         }
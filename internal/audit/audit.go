@@ -0,0 +1,120 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/errors"
+	"github.com/jackvonhouse/product-catalog/internal/principal"
+	"github.com/jackvonhouse/product-catalog/internal/requestid"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+)
+
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionRestore = "restore"
+
+	EntityProduct  = "product"
+	EntityCategory = "category"
+)
+
+type contextKey struct{}
+
+type Entry struct {
+	ActorId   *int
+	Actor     string
+	Action    string
+	Entity    string
+	RequestId string
+}
+
+type Recorder struct {
+	logger log.Logger
+}
+
+func New(
+	logger log.Logger,
+) Recorder {
+
+	return Recorder{
+		logger: logger.WithField("unit", "audit"),
+	}
+}
+
+func (r Recorder) Record(
+	ctx context.Context,
+	action, entity string,
+) context.Context {
+
+	entry := Entry{
+		Action:    action,
+		Entity:    entity,
+		RequestId: requestid.FromContext(ctx),
+	}
+
+	if p, ok := principal.FromContext(ctx); ok {
+		entry.ActorId = &p.UserId
+		entry.Actor = p.Username
+	} else {
+		r.logger.Warnf("%s of %s without authenticated actor", action, entity)
+	}
+
+	return context.WithValue(ctx, contextKey{}, entry)
+}
+
+func FromContext(
+	ctx context.Context,
+) (Entry, bool) {
+
+	entry, ok := ctx.Value(contextKey{}).(Entry)
+
+	return entry, ok
+}
+
+func (e Entry) Record(
+	entityId int,
+	before, after any,
+) (dto.AuditRecord, error) {
+
+	record := dto.AuditRecord{
+		ActorId:   e.ActorId,
+		Actor:     e.Actor,
+		Action:    e.Action,
+		Entity:    e.Entity,
+		EntityId:  entityId,
+		RequestId: e.RequestId,
+	}
+
+	var err error
+
+	if record.Before, err = snapshot(before); err != nil {
+		return dto.AuditRecord{}, err
+	}
+
+	if record.After, err = snapshot(after); err != nil {
+		return dto.AuditRecord{}, err
+	}
+
+	return record, nil
+}
+
+func snapshot(
+	value any,
+) (json.RawMessage, error) {
+
+	if value == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.
+			ErrInternal.
+			New("can't encode audit snapshot").
+			Wrap(err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditRecord struct {
+	ID        int             `json:"id" db:"id"`
+	ActorId   *int            `json:"actor_id" db:"actor_id"`
+	Actor     string          `json:"actor" db:"actor"`
+	Action    string          `json:"action" db:"action"`
+	Entity    string          `json:"entity" db:"entity"`
+	EntityId  int             `json:"entity_id" db:"entity_id"`
+	Before    json.RawMessage `json:"before" db:"before" swaggertype:"object"`
+	After     json.RawMessage `json:"after" db:"after" swaggertype:"object"`
+	RequestId string          `json:"request_id" db:"request_id"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
+
+type AuditPage struct {
+	Items []AuditRecord `json:"items"`
+	Total int           `json:"total"`
+}
+
+type GetAudit struct {
+	Entity   string
+	EntityId int
+	Limit    int
+	Offset   int
+}
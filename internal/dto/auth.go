@@ -1,8 +1,11 @@
 package dto
 
+import "time"
+
 type Credentials struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
+	Role     string `json:"-"`
 }
 
 type Registration struct {
@@ -10,16 +13,38 @@ type Registration struct {
 }
 
 type RefreshToken struct {
-	ID             string `json:"id" db:"id"`
+	ID             int    `json:"id" db:"id"`
 	Token          string `json:"jwt" db:"token"`
 	UserId         int    `json:"user_id" db:"user_id"`
+	FamilyId       string `json:"family_id" db:"family_id"`
+	ParentId       *int   `json:"parent_id" db:"parent_id"`
+	UsedAt         *int64 `json:"-" db:"used_at"`
 	ExpireAt       int64  `json:"-" db:"expire_at"`
 	ExpireDuration int    `json:"expire_duration"`
+
+	Device
+	LastUsedAt time.Time `json:"last_used_at" db:"last_used_at"`
+}
+
+type Device struct {
+	Name      string `json:"device_name" db:"device_name"`
+	UserAgent string `json:"user_agent" db:"user_agent"`
+	IP        string `json:"ip" db:"ip"`
+}
+
+type Session struct {
+	ID int `json:"id" db:"id"`
+
+	Device
+	LastUsedAt time.Time `json:"last_used_at" db:"last_used_at"`
+	Current    bool      `json:"current" db:"-"`
 }
 
 type AccessToken struct {
+	UserId         int    `json:"user_id"`
 	Username       string `json:"username"`
 	RefreshTokenId int    `json:"refresh_token_id"`
+	Role           string `json:"role"`
 }
 
 type TokenPair struct {
@@ -0,0 +1,46 @@
+package dto
+
+const (
+	BatchCreate = "create"
+	BatchUpdate = "update"
+	BatchDelete = "delete"
+	BatchAttach = "attach"
+	BatchDetach = "detach"
+)
+
+const (
+	BatchDone       = "done"
+	BatchFailed     = "failed"
+	BatchRolledBack = "rolled_back"
+	BatchSkipped    = "skipped"
+)
+
+type BatchOperation struct {
+	Type       string         `json:"type" enums:"create,update,delete,attach,detach"`
+	ID         int            `json:"id,omitempty"`
+	Version    int            `json:"version,omitempty"`
+	CategoryId int            `json:"category_id,omitempty"`
+	Create     *CreateProduct `json:"create,omitempty"`
+	Update     *UpdateProduct `json:"update,omitempty"`
+}
+
+type BatchProducts struct {
+	Operations []BatchOperation `json:"operations"`
+	Atomic     bool             `json:"-"`
+}
+
+type BatchOperationResult struct {
+	Index  int    `json:"index"`
+	Type   string `json:"type"`
+	Status string `json:"status" enums:"done,failed,rolled_back,skipped"`
+	ID     int    `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type BatchResult struct {
+	Atomic    bool                   `json:"atomic"`
+	Committed bool                   `json:"committed"`
+	Succeeded int                    `json:"succeeded"`
+	Failed    int                    `json:"failed"`
+	Results   []BatchOperationResult `json:"results"`
+}
@@ -1,20 +1,42 @@
 package dto
 
+import "time"
+
 type Category struct {
-	ID   int    `json:"id" default:"1"`
-	Name string `json:"name" default:"Категория"`
+	ID        int        `json:"id" db:"id" default:"1"`
+	Name      string     `json:"name" db:"name" default:"Категория"`
+	ParentId  *int       `json:"parent_id" db:"parent_id"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	Version   int        `json:"version,omitempty" db:"version" default:"1"`
+}
+
+type CategoryPage struct {
+	Items []Category `json:"items"`
+	Total int        `json:"total"`
+}
+
+type CategoryTree struct {
+	Category
+
+	Children []CategoryTree `json:"children"`
 }
 
 type CreateCategory struct {
-	Name string
+	Name     string `json:"name"`
+	ParentId *int   `json:"parent_id"`
 }
 
 type GetCategory struct {
-	Limit  int
-	Offset int
+	Limit          int
+	Offset         int
+	AfterId        int
+	IncludeDeleted bool
 }
 
 type UpdateCategory struct {
-	ID   int
-	Name string
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	ParentId *int   `json:"parent_id"`
+	Version  int    `json:"-"`
 }
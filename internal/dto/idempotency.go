@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+// IdempotencyKey хранит отпечаток запроса и ответ на него.
+// Нулевой StatusCode означает, что запрос ещё выполняется
+type IdempotencyKey struct {
+	Key         string    `db:"key"`
+	UserId      int       `db:"user_id"`
+	Fingerprint string    `db:"fingerprint"`
+	StatusCode  int       `db:"status_code"`
+	ContentType string    `db:"content_type"`
+	Body        []byte    `db:"body"`
+	ExpiresAt   time.Time `db:"expires_at"`
+}
@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	JobProductImport = "product_import"
+	JobProductExport = "product_export"
+
+	JobPending   = "pending"
+	JobRunning   = "running"
+	JobDone      = "done"
+	JobFailed    = "failed"
+	JobCancelled = "cancelled"
+)
+
+type Job struct {
+	ID              int              `json:"id" db:"id"`
+	Type            string           `json:"type" db:"type"`
+	Status          string           `json:"status" db:"status"`
+	Payload         json.RawMessage  `json:"-" db:"payload"`
+	Result          *json.RawMessage `json:"result,omitempty" db:"result" swaggertype:"object"`
+	Error           string           `json:"error,omitempty" db:"error"`
+	Progress        int              `json:"progress" db:"progress"`
+	Attempt         int              `json:"attempt" db:"attempt"`
+	FileName        string           `json:"file_name,omitempty" db:"file_name"`
+	CancelRequested bool             `json:"cancel_requested" db:"cancel_requested"`
+	ActorId         *int             `json:"actor_id" db:"actor_id"`
+	Actor           string           `json:"actor" db:"actor"`
+	ActorRole       string           `json:"-" db:"actor_role"`
+	RequestId       string           `json:"request_id" db:"request_id"`
+	CreatedAt       time.Time        `json:"created_at" db:"created_at"`
+	StartedAt       *time.Time       `json:"started_at,omitempty" db:"started_at"`
+	FinishedAt      *time.Time       `json:"finished_at,omitempty" db:"finished_at"`
+}
+
+type JobFile struct {
+	Name        string `db:"file_name"`
+	ContentType string `db:"content_type"`
+	Data        []byte `db:"file"`
+}
+
+type CreateJob struct {
+	Type      string
+	Payload   json.RawMessage
+	ActorId   *int
+	Actor     string
+	ActorRole string
+	RequestId string
+}
+
+type FinishJob struct {
+	ID       int
+	Attempt  int
+	Status   string
+	Result   json.RawMessage
+	Error    string
+	Progress int
+	File     *JobFile
+}
+
+type ExportProducts struct {
+	Format string     `json:"format"`
+	Data   GetProduct `json:"data"`
+}
+
+type JobOutput struct {
+	Result json.RawMessage
+	File   *JobFile
+}
@@ -1,23 +1,124 @@
 package dto
 
+import "time"
+
 type Product struct {
-	ID   int    `json:"id" db:"id" default:"1"`
-	Name string `json:"name" db:"name" default:"Товар"`
+	ID          int        `json:"id" db:"id" default:"1"`
+	Name        string     `json:"name" db:"name" default:"Товар"`
+	Description string     `json:"description" db:"description" default:"Описание товара"`
+	Price       int64      `json:"price" db:"price" default:"10000"`
+	Currency    string     `json:"currency" db:"currency" default:"RUB"`
+	SKU         string     `json:"sku" db:"sku" default:"SKU-1"`
+	Stock       int        `json:"stock" db:"stock" default:"10"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	Version     int        `json:"version,omitempty" db:"version" default:"1"`
+}
+
+type ProductPage struct {
+	Items []Product `json:"items"`
+	Total int       `json:"total"`
+	Last  []any     `json:"-"`
+}
+
+type ProductWithCategories struct {
+	Product
+
+	Categories []Category `json:"categories"`
 }
 
 type CreateProduct struct {
-	Name       string `json:"name" default:"Товар"`
-	CategoryId int    `json:"category_id" default:"1"`
+	Name        string `json:"name" default:"Товар"`
+	Description string `json:"description" default:"Описание товара"`
+	Price       int64  `json:"price" default:"10000"`
+	Currency    string `json:"currency" default:"RUB"`
+	SKU         string `json:"sku" default:"SKU-1"`
+	Stock       int    `json:"stock" default:"10"`
+	CategoryIds []int  `json:"category_ids"`
+}
+
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+type ProductFilter struct {
+	NamePrefix         string
+	CategoryIds        []int
+	IncludeDescendants bool
+	CreatedAfter       time.Time
 }
 
 type GetProduct struct {
+	Limit          int
+	Offset         int
+	AfterId        int
+	After          []any
+	Sort           []SortField
+	Filter         ProductFilter
+	IncludeDeleted bool
+}
+
+type SearchProduct struct {
+	Query  string
 	Limit  int
 	Offset int
 }
 
+type FoundProduct struct {
+	Product
+
+	Rank    float64 `json:"rank" db:"rank"`
+	Snippet string  `json:"snippet" db:"snippet"`
+}
+
 type UpdateProduct struct {
 	ID            int    `json:"id"`
 	Name          string `json:"name"`
+	Description   string `json:"description"`
+	Price         int64  `json:"price"`
+	Currency      string `json:"currency"`
+	SKU           string `json:"sku"`
+	Stock         int    `json:"stock"`
 	OldCategoryId int    `json:"old_category_id"`
 	NewCategoryId int    `json:"new_category_id"`
+	Version       int    `json:"-"`
+}
+
+type ImportProduct struct {
+	Row         int      `json:"row,omitempty"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Price       int64    `json:"price"`
+	Currency    string   `json:"currency"`
+	SKU         string   `json:"sku"`
+	Stock       int      `json:"stock"`
+	Categories  []string `json:"categories"`
+}
+
+type ImportProducts struct {
+	Rows   []ImportProduct `json:"rows"`
+	Errors []ImportError   `json:"errors"`
+	DryRun bool            `json:"dry_run"`
+}
+
+type ImportError struct {
+	Row    int    `json:"row"`
+	Reason string `json:"reason"`
+}
+
+type ImportResult struct {
+	Total             int           `json:"total"`
+	Created           int           `json:"created"`
+	Failed            int           `json:"failed"`
+	CreatedCategories []string      `json:"created_categories"`
+	DryRun            bool          `json:"dry_run"`
+	Errors            []ImportError `json:"errors"`
+}
+
+type ExportProduct struct {
+	Product
+
+	Categories []string `json:"categories"`
 }
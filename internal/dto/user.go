@@ -1,7 +1,19 @@
 package dto
 
+const (
+	RoleAdmin  = "admin"
+	RoleEditor = "editor"
+	RoleViewer = "viewer"
+)
+
 type User struct {
 	ID       int    `json:"id"`
 	Username string `json:"username"`
 	Password string `json:"password"`
+	Role     string `json:"role"`
+}
+
+type UpdateRole struct {
+	UserId int    `json:"user_id"`
+	Role   string `json:"role"`
 }
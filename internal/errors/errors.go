@@ -9,4 +9,6 @@ var (
 	ErrInvalid       = errors.NewType("invalid data")
 	ErrExpired       = errors.NewType("expired")
 	ErrInvalidToken  = errors.NewType("invalid token")
+	ErrConflict      = errors.NewType("conflict")
+	ErrRevoked       = errors.NewType("revoked")
 )
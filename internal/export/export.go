@@ -0,0 +1,127 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+
+	CategoriesSeparator = "|"
+)
+
+var (
+	columns = []string{
+		"id", "name", "description", "price", "currency", "sku", "stock",
+		"categories", "created_at", "updated_at",
+	}
+
+	contentTypes = map[string]string{
+		FormatCSV:    "text/csv; charset=utf-8",
+		FormatNDJSON: "application/x-ndjson",
+	}
+)
+
+// Writer кодирует товары в CSV (с заголовком) или JSON Lines
+type Writer struct {
+	csv     *csv.Writer
+	encoder *json.Encoder
+	started bool
+}
+
+func NewWriter(
+	w io.Writer,
+	format string,
+) (*Writer, error) {
+
+	switch format {
+
+	case FormatCSV:
+		return &Writer{csv: csv.NewWriter(w)}, nil
+
+	case FormatNDJSON:
+		return &Writer{encoder: json.NewEncoder(w)}, nil
+
+	default:
+		return nil, errors.ErrInvalid.New("unsupported export format")
+	}
+}
+
+func ContentType(
+	format string,
+) string {
+
+	return contentTypes[format]
+}
+
+func FileName(
+	format string,
+	now time.Time,
+) string {
+
+	return fmt.Sprintf("products-%s.%s", now.Format("20060102-150405"), format)
+}
+
+func (w *Writer) Write(
+	product dto.ExportProduct,
+) error {
+
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	if w.encoder != nil {
+		return w.encoder.Encode(product)
+	}
+
+	return w.csv.Write([]string{
+		strconv.Itoa(product.ID),
+		product.Name,
+		product.Description,
+		strconv.FormatInt(product.Price, 10),
+		product.Currency,
+		product.SKU,
+		strconv.Itoa(product.Stock),
+		strings.Join(product.Categories, CategoriesSeparator),
+		product.CreatedAt.Format(time.RFC3339),
+		product.UpdatedAt.Format(time.RFC3339),
+	})
+}
+
+// Flush дописывает буфер; у пустой выгрузки в CSV остаётся только заголовок
+func (w *Writer) Flush() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	if w.csv == nil {
+		return nil
+	}
+
+	w.csv.Flush()
+
+	return w.csv.Error()
+}
+
+func (w *Writer) start() error {
+	if w.started {
+		return nil
+	}
+
+	w.started = true
+
+	if w.csv == nil {
+		return nil
+	}
+
+	return w.csv.Write(columns)
+}
@@ -2,37 +2,42 @@ package cache
 
 import (
 	"context"
+	"fmt"
 	"github.com/jackvonhouse/product-catalog/config"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
-	"github.com/patrickmn/go-cache"
 	"time"
 )
 
-type Database struct {
-	db *cache.Cache
+const (
+	DriverMemory = "memory"
+	DriverRedis  = "redis"
+)
+
+type Database interface {
+	Get(context.Context, string) ([]byte, bool, error)
+	Set(context.Context, string, []byte, time.Duration) error
+	Delete(context.Context, ...string) error
+	DeleteByPrefix(context.Context, string) error
+	Close() error
 }
 
 func New(
-	_ context.Context,
+	ctx context.Context,
 	config config.Cache,
 	logger log.Logger,
 ) (Database, error) {
 
-	expireDuration := time.Duration(config.ExpireDuration) * time.Minute
-	cleanupDuration := time.Duration(config.CleanupInterval) * time.Minute
+	switch config.Driver {
 
-	c := cache.New(expireDuration, cleanupDuration)
+	case "", DriverMemory:
+		return NewMemory(config, logger), nil
 
-	logger.WithFields(map[string]any{
-		"duration": map[string]any{
-			"expire":  expireDuration,
-			"cleanup": cleanupDuration,
-		},
-	}).Info("cache initialized")
+	case DriverRedis:
+		return NewRedis(ctx, config, logger)
 
-	return Database{
-		db: c,
-	}, nil
-}
+	default:
+		logger.Warnf("unknown cache driver: %s", config.Driver)
 
-func (d Database) Database() *cache.Cache { return d.db }
+		return nil, fmt.Errorf("unknown cache driver: %s", config.Driver)
+	}
+}
@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"context"
+	"github.com/jackvonhouse/product-catalog/config"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/patrickmn/go-cache"
+	"strings"
+	"time"
+)
+
+type Memory struct {
+	db *cache.Cache
+}
+
+func NewMemory(
+	config config.Cache,
+	logger log.Logger,
+) Memory {
+
+	expireDuration := time.Duration(config.ExpireDuration) * time.Minute
+	cleanupDuration := time.Duration(config.CleanupInterval) * time.Minute
+
+	c := cache.New(expireDuration, cleanupDuration)
+
+	logger.WithFields(map[string]any{
+		"driver": DriverMemory,
+		"duration": map[string]any{
+			"expire":  expireDuration,
+			"cleanup": cleanupDuration,
+		},
+	}).Info("cache initialized")
+
+	return Memory{
+		db: c,
+	}
+}
+
+func (m Memory) Get(
+	_ context.Context,
+	key string,
+) ([]byte, bool, error) {
+
+	value, ok := m.db.Get(key)
+	if !ok {
+		return nil, false, nil
+	}
+
+	data, ok := value.([]byte)
+
+	return data, ok, nil
+}
+
+func (m Memory) Set(
+	_ context.Context,
+	key string,
+	value []byte,
+	ttl time.Duration,
+) error {
+
+	m.db.Set(key, value, ttl)
+
+	return nil
+}
+
+func (m Memory) Delete(
+	_ context.Context,
+	keys ...string,
+) error {
+
+	for _, key := range keys {
+		m.db.Delete(key)
+	}
+
+	return nil
+}
+
+func (m Memory) DeleteByPrefix(
+	_ context.Context,
+	prefix string,
+) error {
+
+	for key := range m.db.Items() {
+		if strings.HasPrefix(key, prefix) {
+			m.db.Delete(key)
+		}
+	}
+
+	return nil
+}
+
+func (m Memory) Close() error { return nil }
@@ -0,0 +1,313 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/jackvonhouse/product-catalog/config"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultPoolSize = 10
+	defaultTimeout  = 5 * time.Second
+	scanCount       = "100"
+)
+
+type conn struct {
+	net.Conn
+
+	reader *bufio.Reader
+	writer *bufio.Writer
+}
+
+// Redis — клиент к серверу с протоколом RESP (Redis, KeyDB, Valkey),
+// соединения переиспользуются через пул фиксированного размера
+type Redis struct {
+	address  string
+	password string
+	db       int
+	pool     chan *conn
+}
+
+func NewRedis(
+	ctx context.Context,
+	config config.Cache,
+	logger log.Logger,
+) (Redis, error) {
+
+	poolSize := config.PoolSize
+	if poolSize <= 0 {
+		poolSize = defaultPoolSize
+	}
+
+	r := Redis{
+		address:  config.Address,
+		password: config.Password,
+		db:       config.DB,
+		pool:     make(chan *conn, poolSize),
+	}
+
+	if _, err := r.do(ctx, "PING"); err != nil {
+		logger.Warnf("can't connect to redis: %s", err)
+
+		return Redis{}, fmt.Errorf("can't connect to redis: %s", err)
+	}
+
+	logger.WithFields(map[string]any{
+		"driver":  DriverRedis,
+		"address": config.Address,
+		"db":      config.DB,
+		"pool":    poolSize,
+	}).Info("cache initialized")
+
+	return r, nil
+}
+
+func (r Redis) Get(
+	ctx context.Context,
+	key string,
+) ([]byte, bool, error) {
+
+	reply, err := r.do(ctx, "GET", key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if reply == nil {
+		return nil, false, nil
+	}
+
+	data, ok := reply.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("unexpected reply to GET: %v", reply)
+	}
+
+	return data, true, nil
+}
+
+func (r Redis) Set(
+	ctx context.Context,
+	key string,
+	value []byte,
+	ttl time.Duration,
+) error {
+
+	args := []string{"SET", key, string(value)}
+
+	if ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	}
+
+	_, err := r.do(ctx, args...)
+
+	return err
+}
+
+func (r Redis) Delete(
+	ctx context.Context,
+	keys ...string,
+) error {
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	_, err := r.do(ctx, append([]string{"DEL"}, keys...)...)
+
+	return err
+}
+
+func (r Redis) DeleteByPrefix(
+	ctx context.Context,
+	prefix string,
+) error {
+
+	pattern := escapePattern(prefix) + "*"
+	cursor := "0"
+
+	for {
+		reply, err := r.do(ctx, "SCAN", cursor, "MATCH", pattern, "COUNT", scanCount)
+		if err != nil {
+			return err
+		}
+
+		items, ok := reply.([]any)
+		if !ok || len(items) != 2 {
+			return fmt.Errorf("unexpected reply to SCAN: %v", reply)
+		}
+
+		next, _ := items[0].([]byte)
+		found, _ := items[1].([]any)
+
+		keys := make([]string, 0, len(found))
+
+		for _, key := range found {
+			if k, ok := key.([]byte); ok {
+				keys = append(keys, string(k))
+			}
+		}
+
+		if err := r.Delete(ctx, keys...); err != nil {
+			return err
+		}
+
+		cursor = string(next)
+		if cursor == "0" || cursor == "" {
+			return nil
+		}
+	}
+}
+
+func (r Redis) Close() error {
+	for {
+		select {
+
+		case c := <-r.pool:
+			c.Close()
+
+		default:
+			return nil
+		}
+	}
+}
+
+func (r Redis) do(
+	ctx context.Context,
+	args ...string,
+) (any, error) {
+
+	c, err := r.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := c.command(ctx, args...)
+	if err != nil {
+		c.Close()
+
+		return nil, err
+	}
+
+	r.release(c)
+
+	if e, ok := reply.(respError); ok {
+		return nil, e
+	}
+
+	return reply, nil
+}
+
+func (r Redis) acquire(
+	ctx context.Context,
+) (*conn, error) {
+
+	select {
+
+	case c := <-r.pool:
+		return c, nil
+
+	default:
+	}
+
+	dialer := net.Dialer{Timeout: defaultTimeout}
+
+	netConn, err := dialer.DialContext(ctx, "tcp", r.address)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &conn{
+		Conn:   netConn,
+		reader: bufio.NewReader(netConn),
+		writer: bufio.NewWriter(netConn),
+	}
+
+	if r.password != "" {
+		if err := c.expectOK(ctx, "AUTH", r.password); err != nil {
+			c.Close()
+
+			return nil, err
+		}
+	}
+
+	if r.db != 0 {
+		if err := c.expectOK(ctx, "SELECT", strconv.Itoa(r.db)); err != nil {
+			c.Close()
+
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+func (r Redis) release(
+	c *conn,
+) {
+
+	select {
+
+	case r.pool <- c:
+
+	default:
+		c.Close()
+	}
+}
+
+func (c *conn) command(
+	ctx context.Context,
+	args ...string,
+) (any, error) {
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultTimeout)
+	}
+
+	if err := c.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	if err := writeCommand(c.writer, args...); err != nil {
+		return nil, err
+	}
+
+	return readReply(c.reader)
+}
+
+func (c *conn) expectOK(
+	ctx context.Context,
+	args ...string,
+) error {
+
+	reply, err := c.command(ctx, args...)
+	if err != nil {
+		return err
+	}
+
+	if e, ok := reply.(respError); ok {
+		return fmt.Errorf("%s: %s", args[0], e)
+	}
+
+	return nil
+}
+
+func escapePattern(
+	value string,
+) string {
+
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		`*`, `\*`,
+		`?`, `\?`,
+		`[`, `\[`,
+		`]`, `\]`,
+	)
+
+	return replacer.Replace(value)
+}
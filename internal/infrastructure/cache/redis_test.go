@@ -0,0 +1,377 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/jackvonhouse/product-catalog/config"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/stretchr/testify/suite"
+	"net"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// respServer — минимальный сервер RESP в памяти для тестов клиента
+type respServer struct {
+	listener net.Listener
+	password string
+
+	mu      sync.Mutex
+	values  map[string][]byte
+	expires map[string]time.Time
+}
+
+func newRespServer(
+	password string,
+) (*respServer, error) {
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &respServer{
+		listener: listener,
+		password: password,
+		values:   make(map[string][]byte),
+		expires:  make(map[string]time.Time),
+	}
+
+	go s.serve()
+
+	return s, nil
+}
+
+func (s *respServer) Address() string { return s.listener.Addr().String() }
+
+func (s *respServer) Close() error { return s.listener.Close() }
+
+func (s *respServer) serve() {
+	for {
+		c, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go s.handle(c)
+	}
+}
+
+func (s *respServer) handle(
+	c net.Conn,
+) {
+
+	defer c.Close()
+
+	reader := bufio.NewReader(c)
+	writer := bufio.NewWriter(c)
+	authorized := s.password == ""
+
+	for {
+		reply, err := readReply(reader)
+		if err != nil {
+			return
+		}
+
+		items, _ := reply.([]any)
+
+		args := make([]string, len(items))
+		for i, item := range items {
+			data, _ := item.([]byte)
+			args[i] = string(data)
+		}
+
+		if len(args) == 0 {
+			return
+		}
+
+		command := strings.ToUpper(args[0])
+
+		switch {
+
+		case command == "AUTH":
+			authorized = len(args) == 2 && args[1] == s.password
+
+			if !authorized {
+				writer.WriteString("-WRONGPASS invalid password\r\n")
+			} else {
+				writer.WriteString("+OK\r\n")
+			}
+
+		case !authorized:
+			writer.WriteString("-NOAUTH Authentication required.\r\n")
+
+		default:
+			writer.WriteString(s.execute(command, args[1:]))
+		}
+
+		if err := writer.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func (s *respServer) execute(
+	command string,
+	args []string,
+) string {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch command {
+
+	case "PING":
+		return "+PONG\r\n"
+
+	case "SELECT":
+		return "+OK\r\n"
+
+	case "GET":
+		value, ok := s.get(args[0])
+		if !ok {
+			return "$-1\r\n"
+		}
+
+		return bulk(string(value))
+
+	case "SET":
+		s.values[args[0]] = []byte(args[1])
+		delete(s.expires, args[0])
+
+		if len(args) == 4 && strings.ToUpper(args[2]) == "PX" {
+			ms, _ := strconv.Atoi(args[3])
+			s.expires[args[0]] = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		}
+
+		return "+OK\r\n"
+
+	case "DEL":
+		deleted := 0
+
+		for _, key := range args {
+			if _, ok := s.get(key); ok {
+				deleted++
+			}
+
+			delete(s.values, key)
+			delete(s.expires, key)
+		}
+
+		return fmt.Sprintf(":%d\r\n", deleted)
+
+	case "SCAN":
+		keys := make([]string, 0, len(s.values))
+
+		for key := range s.values {
+			if ok, _ := path.Match(args[2], key); ok {
+				keys = append(keys, key)
+			}
+		}
+
+		slices.Sort(keys)
+
+		// Отдаём по одному ключу, чтобы проверить обход курсора.
+		// Курсор — последний отданный ключ, поэтому удаление не сдвигает обход
+		next := "0"
+		batch := make([]string, 0, 1)
+
+		for _, key := range keys {
+			if args[0] == "0" || key > args[0] {
+				batch = append(batch, key)
+				next = key
+
+				break
+			}
+		}
+
+		reply := "*2\r\n" + bulk(next) + fmt.Sprintf("*%d\r\n", len(batch))
+		for _, key := range batch {
+			reply += bulk(key)
+		}
+
+		return reply
+
+	default:
+		return "-ERR unknown command '" + command + "'\r\n"
+	}
+}
+
+func (s *respServer) get(
+	key string,
+) ([]byte, bool) {
+
+	if expires, ok := s.expires[key]; ok && time.Now().After(expires) {
+		delete(s.values, key)
+		delete(s.expires, key)
+	}
+
+	value, ok := s.values[key]
+
+	return value, ok
+}
+
+func bulk(
+	value string,
+) string {
+
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+}
+
+type RedisTestSuite struct {
+	suite.Suite
+
+	// Вспомогательные параметры
+	ctx    context.Context
+	logger log.Logger
+	cache  Database
+
+	// Служебные параметры
+	server *respServer
+}
+
+func TestSuiteRedis(t *testing.T) {
+	suite.Run(t, &RedisTestSuite{})
+}
+
+func (s *RedisTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.logger = log.NewNullLogger()
+}
+
+func (s *RedisTestSuite) BeforeTest(_, _ string) {
+	server, err := newRespServer("secret")
+	s.NoError(err)
+
+	s.server = server
+
+	c, err := New(s.ctx, config.Cache{
+		Driver:   DriverRedis,
+		Address:  server.Address(),
+		Password: "secret",
+		DB:       1,
+		PoolSize: 2,
+	}, s.logger)
+	s.NoError(err)
+
+	s.cache = c
+}
+
+func (s *RedisTestSuite) AfterTest(_, _ string) {
+	s.NoError(s.cache.Close())
+	s.NoError(s.server.Close())
+}
+
+func (s *RedisTestSuite) TestSetGetSuccessful() {
+	testCases := []struct {
+		testName string
+		key      string
+		value    []byte
+	}{
+		{
+			testName: "Value",
+			key:      "product:id:1",
+			value:    []byte(`{"id":1,"name":"Товар"}`),
+		},
+		{
+			testName: "Empty value",
+			key:      "product:id:2",
+			value:    []byte{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		s.Run(testCase.testName, func() {
+			s.NoError(s.cache.Set(s.ctx, testCase.key, testCase.value, time.Minute))
+
+			value, ok, err := s.cache.Get(s.ctx, testCase.key)
+
+			s.NoError(err)
+			s.True(ok)
+			s.Equal(testCase.value, value)
+		})
+	}
+}
+
+func (s *RedisTestSuite) TestGetMissing() {
+	value, ok, err := s.cache.Get(s.ctx, "product:id:1")
+
+	s.NoError(err)
+	s.False(ok)
+	s.Nil(value)
+}
+
+func (s *RedisTestSuite) TestSetExpired() {
+	s.NoError(s.cache.Set(s.ctx, "product:id:1", []byte("1"), time.Millisecond))
+
+	time.Sleep(10 * time.Millisecond)
+
+	_, ok, err := s.cache.Get(s.ctx, "product:id:1")
+
+	s.NoError(err)
+	s.False(ok)
+}
+
+func (s *RedisTestSuite) TestDeleteSuccessful() {
+	s.NoError(s.cache.Set(s.ctx, "product:id:1", []byte("1"), time.Minute))
+	s.NoError(s.cache.Set(s.ctx, "product:id:2", []byte("2"), time.Minute))
+
+	s.NoError(s.cache.Delete(s.ctx, "product:id:1", "product:id:2"))
+	s.NoError(s.cache.Delete(s.ctx))
+
+	for _, key := range []string{"product:id:1", "product:id:2"} {
+		_, ok, err := s.cache.Get(s.ctx, key)
+
+		s.NoError(err)
+		s.False(ok)
+	}
+}
+
+func (s *RedisTestSuite) TestDeleteByPrefixSuccessful() {
+	keys := []string{"product:list:1", "product:list:2", "product:list:3", "category:list:1"}
+
+	for _, key := range keys {
+		s.NoError(s.cache.Set(s.ctx, key, []byte(key), time.Minute))
+	}
+
+	s.NoError(s.cache.DeleteByPrefix(s.ctx, "product:list:"))
+
+	for _, key := range keys {
+		_, ok, err := s.cache.Get(s.ctx, key)
+
+		s.NoError(err)
+		s.Equal(strings.HasPrefix(key, "category:"), ok)
+	}
+}
+
+func (s *RedisTestSuite) TestConnectFailed() {
+	const (
+		expectedErrorMsg = "can't connect to redis: AUTH: WRONGPASS invalid password"
+	)
+
+	_, err := New(s.ctx, config.Cache{
+		Driver:   DriverRedis,
+		Address:  s.server.Address(),
+		Password: "invalid",
+	}, s.logger)
+
+	s.NotNil(err)
+	s.Equal(expectedErrorMsg, err.Error())
+}
+
+func (s *RedisTestSuite) TestUnknownDriverFailed() {
+	const (
+		expectedErrorMsg = "unknown cache driver: memcached"
+	)
+
+	_, err := New(s.ctx, config.Cache{Driver: "memcached"}, s.logger)
+
+	s.NotNil(err)
+	s.Equal(expectedErrorMsg, err.Error())
+}
@@ -0,0 +1,116 @@
+package cache
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// respError — ответ сервера с префиксом "-"
+type respError string
+
+func (e respError) Error() string { return string(e) }
+
+func writeCommand(
+	w *bufio.Writer,
+	args ...string,
+) error {
+
+	if _, err := fmt.Fprintf(w, "*%d\r\n", len(args)); err != nil {
+		return err
+	}
+
+	for _, arg := range args {
+		if _, err := fmt.Fprintf(w, "$%d\r\n%s\r\n", len(arg), arg); err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
+
+// readReply возвращает string, int64, []byte, []any, nil или respError
+func readReply(
+	r *bufio.Reader,
+) (any, error) {
+
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(line) == 0 {
+		return nil, errors.New("empty reply")
+	}
+
+	switch line[0] {
+
+	case '+':
+		return line[1:], nil
+
+	case '-':
+		return respError(line[1:]), nil
+
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		if size < 0 {
+			return nil, nil
+		}
+
+		data := make([]byte, size+2)
+
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+
+		return data[:size], nil
+
+	case '*':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		if size < 0 {
+			return nil, nil
+		}
+
+		items := make([]any, size)
+
+		for i := range items {
+			if items[i], err = readReply(r); err != nil {
+				return nil, err
+			}
+		}
+
+		return items, nil
+
+	default:
+		return nil, fmt.Errorf("unknown reply type: %q", line[0])
+	}
+}
+
+func readLine(
+	r *bufio.Reader,
+) (string, error) {
+
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return "", errors.New("invalid reply line")
+	}
+
+	return line[:len(line)-2], nil
+}
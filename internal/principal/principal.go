@@ -0,0 +1,63 @@
+package principal
+
+import (
+	"context"
+	"slices"
+)
+
+type contextKey struct{}
+
+type Principal struct {
+	UserId         int
+	Username       string
+	RefreshTokenId int
+	Role           string
+}
+
+func WithContext(
+	ctx context.Context,
+	principal Principal,
+) context.Context {
+
+	return context.WithValue(ctx, contextKey{}, principal)
+}
+
+func FromContext(
+	ctx context.Context,
+) (Principal, bool) {
+
+	principal, ok := ctx.Value(contextKey{}).(Principal)
+
+	return principal, ok
+}
+
+func UserId(
+	ctx context.Context,
+) int {
+
+	principal, _ := FromContext(ctx)
+
+	return principal.UserId
+}
+
+func Username(
+	ctx context.Context,
+) string {
+
+	principal, _ := FromContext(ctx)
+
+	return principal.Username
+}
+
+func HasRole(
+	ctx context.Context,
+	roles ...string,
+) bool {
+
+	principal, ok := FromContext(ctx)
+	if !ok {
+		return false
+	}
+
+	return slices.Contains(roles, principal.Role)
+}
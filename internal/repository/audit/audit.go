@@ -0,0 +1,184 @@
+package audit
+
+import (
+	"context"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/jmoiron/sqlx"
+)
+
+type auditRow struct {
+	dto.AuditRecord
+
+	Total int `db:"total"`
+}
+
+type Repository struct {
+	logger log.Logger
+
+	db *sqlx.DB
+}
+
+func New(
+	db *sqlx.DB,
+	logger log.Logger,
+) Repository {
+
+	return Repository{
+		logger: logger.WithField("unit", "audit"),
+		db:     db,
+	}
+}
+
+func (r Repository) Create(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	record dto.AuditRecord,
+) error {
+
+	query, args, err := sq.
+		Insert("audit_log").
+		Columns(
+			"actor_id", "actor", "action", "entity", "entity_id",
+			"before", "after", "request_id",
+		).
+		Values(
+			record.ActorId, record.Actor, record.Action, record.Entity, record.EntityId,
+			r.jsonValue(record.Before), r.jsonValue(record.After), record.RequestId,
+		).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"actor":      record.Actor,
+			"action":     record.Action,
+			"entity":     record.Entity,
+			"entity_id":  record.EntityId,
+			"request_id": record.RequestId,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return r.errInternalBuildSql(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		logger.Warnf("unknown error on creating audit record: %s", err)
+
+		return r.errInternalCreateAuditRecord(err)
+	}
+
+	return nil
+}
+
+func (r Repository) Get(
+	ctx context.Context,
+	data dto.GetAudit,
+) (dto.AuditPage, error) {
+
+	var (
+		offset = uint64(data.Offset)
+		limit  = uint64(data.Limit)
+	)
+
+	filter := sq.Eq{"entity": data.Entity}
+
+	if data.EntityId > 0 {
+		filter["entity_id"] = data.EntityId
+	}
+
+	count := sq.
+		Select("COUNT(*)").
+		From("audit_log").
+		Where(filter)
+
+	query, args, err := sq.
+		Select(
+			"id", "actor_id", "actor", "action", "entity", "entity_id",
+			"before", "after", "request_id", "created_at",
+		).
+		Column(sq.Alias(count, "total")).
+		From("audit_log").
+		Where(filter).
+		OrderBy("id DESC").
+		Offset(offset).
+		Limit(limit).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"entity":    data.Entity,
+			"entity_id": data.EntityId,
+			"limit":     data.Limit,
+			"offset":    data.Offset,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return dto.AuditPage{}, r.errInternalBuildSql(err)
+	}
+
+	rows := make([]auditRow, 0)
+
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		logger.Warnf("unknown error on getting audit records: %s", err)
+
+		return dto.AuditPage{}, r.errInternalGetAuditRecords(err)
+	}
+
+	page := dto.AuditPage{
+		Items: make([]dto.AuditRecord, 0, len(rows)),
+	}
+
+	for _, row := range rows {
+		page.Items = append(page.Items, row.AuditRecord)
+	}
+
+	if len(rows) > 0 {
+		page.Total = rows[0].Total
+
+		return page, nil
+	}
+
+	if data.Offset == 0 {
+		return page, nil
+	}
+
+	countQuery, countArgs, err := count.
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return dto.AuditPage{}, r.errInternalBuildSql(err)
+	}
+
+	if err := r.db.GetContext(ctx, &page.Total, countQuery, countArgs...); err != nil {
+		logger.Warnf("unknown error on counting audit records: %s", err)
+
+		return dto.AuditPage{}, r.errInternalCountAuditRecords(err)
+	}
+
+	return page, nil
+}
+
+func (r Repository) jsonValue(
+	data []byte,
+) any {
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	return string(data)
+}
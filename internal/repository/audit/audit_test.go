@@ -0,0 +1,271 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type AuditTestSuite struct {
+	suite.Suite
+
+	// Вспомогательные параметры
+	ctx        context.Context
+	logger     log.Logger
+	repository Repository
+
+	// Входные параметры
+	record dto.AuditRecord
+	get    dto.GetAudit
+
+	// Служебные параметры
+	db   *sqlx.DB
+	mock sqlmock.Sqlmock
+}
+
+func convertArgs(args []any) []driver.Value {
+	converted := make([]driver.Value, len(args))
+
+	for i, arg := range args {
+		converted[i] = arg
+	}
+
+	return converted
+}
+
+func TestSuiteAudit(t *testing.T) {
+	suite.Run(t, &AuditTestSuite{})
+}
+
+func (s *AuditTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.logger = log.NewNullLogger()
+}
+
+func (s *AuditTestSuite) BeforeTest(_, _ string) {
+	db, mock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+	)
+	s.NoError(err)
+
+	s.setupDatabase(db).setupMock(mock).setupRepository()
+
+	// Входные значения по-умолчанию
+	s.setupRecord(1, "admin", "update", "product", 1).
+		setupGet("product", 1, 10, 0)
+}
+
+func (s *AuditTestSuite) setupDatabase(
+	db *sql.DB,
+) *AuditTestSuite {
+
+	wrappedDb := sqlx.NewDb(db, "sqlmock")
+
+	s.db = wrappedDb
+
+	return s
+}
+
+func (s *AuditTestSuite) setupMock(
+	mock sqlmock.Sqlmock,
+) *AuditTestSuite {
+
+	s.mock = mock
+
+	return s
+}
+
+func (s *AuditTestSuite) setupRepository() *AuditTestSuite {
+	s.repository = New(s.db, s.logger)
+
+	return s
+}
+
+func (s *AuditTestSuite) setupRecord(
+	actorId int,
+	actor, action, entity string,
+	entityId int,
+) *AuditTestSuite {
+
+	s.record = dto.AuditRecord{
+		ID:        1,
+		ActorId:   &actorId,
+		Actor:     actor,
+		Action:    action,
+		Entity:    entity,
+		EntityId:  entityId,
+		Before:    json.RawMessage(`{"id":1,"name":"Товар"}`),
+		After:     json.RawMessage(`{"id":1,"name":"Новый товар"}`),
+		RequestId: "request",
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	return s
+}
+
+func (s *AuditTestSuite) setupGet(
+	entity string,
+	entityId, limit, offset int,
+) *AuditTestSuite {
+
+	s.get = dto.GetAudit{
+		Entity:   entity,
+		EntityId: entityId,
+		Limit:    limit,
+		Offset:   offset,
+	}
+
+	return s
+}
+
+func (s *AuditTestSuite) insertQuery() (string, []any) {
+	query, args, err := sq.
+		Insert("audit_log").
+		Columns(
+			"actor_id", "actor", "action", "entity", "entity_id",
+			"before", "after", "request_id",
+		).
+		Values(
+			s.record.ActorId, s.record.Actor, s.record.Action, s.record.Entity, s.record.EntityId,
+			string(s.record.Before), string(s.record.After), s.record.RequestId,
+		).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	s.NoError(err)
+
+	return query, args
+}
+
+func (s *AuditTestSuite) selectQuery() (string, []any) {
+	filter := sq.Eq{
+		"entity":    s.get.Entity,
+		"entity_id": s.get.EntityId,
+	}
+
+	count := sq.
+		Select("COUNT(*)").
+		From("audit_log").
+		Where(filter)
+
+	query, args, err := sq.
+		Select(
+			"id", "actor_id", "actor", "action", "entity", "entity_id",
+			"before", "after", "request_id", "created_at",
+		).
+		Column(sq.Alias(count, "total")).
+		From("audit_log").
+		Where(filter).
+		OrderBy("id DESC").
+		Offset(uint64(s.get.Offset)).
+		Limit(uint64(s.get.Limit)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	s.NoError(err)
+
+	return query, args
+}
+
+func (s *AuditTestSuite) TestCreateSuccessful() {
+	s.mock.ExpectBegin().WillReturnError(nil)
+
+	query, args := s.insertQuery()
+
+	s.mock.
+		ExpectExec(query).
+		WithArgs(convertArgs(args)...).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	s.mock.ExpectCommit().WillReturnError(nil)
+
+	tx, err := s.db.BeginTxx(s.ctx, nil)
+	s.NoError(err)
+
+	s.NoError(s.repository.Create(s.ctx, tx, s.record))
+	s.NoError(tx.Commit())
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *AuditTestSuite) TestCreateFailed() {
+	const (
+		expectedErrorMsg = "unknown error on creating audit record"
+	)
+
+	s.mock.ExpectBegin().WillReturnError(nil)
+
+	query, args := s.insertQuery()
+
+	s.mock.
+		ExpectExec(query).
+		WithArgs(convertArgs(args)...).
+		WillReturnError(errors.New("unknown error"))
+
+	s.mock.ExpectRollback().WillReturnError(nil)
+
+	tx, err := s.db.BeginTxx(s.ctx, nil)
+	s.NoError(err)
+
+	err = s.repository.Create(s.ctx, tx, s.record)
+
+	s.NotNil(err)
+	s.Equal(expectedErrorMsg, err.Error())
+	s.NoError(tx.Rollback())
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *AuditTestSuite) TestGetSuccessful() {
+	query, args := s.selectQuery()
+
+	s.mock.
+		ExpectQuery(query).
+		WithArgs(convertArgs(args)...).
+		WillReturnRows(
+			s.mock.
+				NewRows([]string{
+					"id", "actor_id", "actor", "action", "entity", "entity_id",
+					"before", "after", "request_id", "created_at", "total",
+				}).
+				AddRow(
+					s.record.ID, *s.record.ActorId, s.record.Actor, s.record.Action,
+					s.record.Entity, s.record.EntityId, []byte(s.record.Before),
+					[]byte(s.record.After), s.record.RequestId, s.record.CreatedAt, 1,
+				),
+		)
+
+	page, err := s.repository.Get(s.ctx, s.get)
+
+	s.NoError(err)
+	s.Equal(dto.AuditPage{Items: []dto.AuditRecord{s.record}, Total: 1}, page)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *AuditTestSuite) TestGetFailed() {
+	const (
+		expectedErrorMsg = "unknown error on getting audit records"
+	)
+
+	query, args := s.selectQuery()
+
+	s.mock.
+		ExpectQuery(query).
+		WithArgs(convertArgs(args)...).
+		WillReturnError(errors.New("unknown error"))
+
+	page, err := s.repository.Get(s.ctx, s.get)
+
+	s.NotNil(err)
+	s.Equal(expectedErrorMsg, err.Error())
+	s.Equal(dto.AuditPage{}, page)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
@@ -0,0 +1,33 @@
+package audit
+
+import (
+	"github.com/jackvonhouse/product-catalog/internal/repository/errors"
+)
+
+func (r Repository) errInternalCreateAuditRecord(
+	err error,
+) error {
+
+	return errors.ErrInternal("creating", "audit record", err)
+}
+
+func (r Repository) errInternalGetAuditRecords(
+	err error,
+) error {
+
+	return errors.ErrInternal("getting", "audit records", err)
+}
+
+func (r Repository) errInternalCountAuditRecords(
+	err error,
+) error {
+
+	return errors.ErrInternal("counting", "audit records", err)
+}
+
+func (r Repository) errInternalBuildSql(
+	err error,
+) error {
+
+	return errors.ErrInternal("building", "sql query", err)
+}
@@ -0,0 +1,105 @@
+package category
+
+import (
+	"context"
+	"database/sql"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackvonhouse/product-catalog/internal/audit"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	errpkg "github.com/jackvonhouse/product-catalog/pkg/errors"
+	"github.com/jmoiron/sqlx"
+)
+
+type auditRepository interface {
+	Create(context.Context, *sqlx.Tx, dto.AuditRecord) error
+}
+
+func (r Repository) auditBefore(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	categoryId int,
+) (any, error) {
+
+	if _, ok := audit.FromContext(ctx); !ok {
+		return nil, nil
+	}
+
+	return r.snapshot(ctx, tx, categoryId)
+}
+
+func (r Repository) auditAfter(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	categoryId int,
+	before any,
+) error {
+
+	entry, ok := audit.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	var after any
+
+	if entry.Action != audit.ActionDelete {
+		category, err := r.snapshot(ctx, tx, categoryId)
+		if err != nil {
+			return err
+		}
+
+		after = category
+	}
+
+	record, err := entry.Record(categoryId, before, after)
+	if err != nil {
+		r.logger.Warnf("can't create audit record: %s", err)
+
+		return err
+	}
+
+	return r.audit.Create(ctx, tx, record)
+}
+
+func (r Repository) snapshot(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	categoryId int,
+) (dto.Category, error) {
+
+	query, args, err := sq.
+		Select("*").
+		From("category").
+		Where(sq.Eq{"id": categoryId}).
+		Suffix("FOR UPDATE").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"category_id": categoryId,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return dto.Category{}, r.errInternalBuildSql(err)
+	}
+
+	category := dto.Category{}
+
+	if err := tx.GetContext(ctx, &category, query, args...); err != nil {
+		if errpkg.Is(err, sql.ErrNoRows) {
+			logger.Warnf("category not found: %s", err)
+
+			return dto.Category{}, r.errNotFound("category", err)
+		}
+
+		logger.Warnf("unknown error on getting category snapshot: %s", err)
+
+		return dto.Category{}, r.errInternalGetCategory(err)
+	}
+
+	return category, nil
+}
@@ -6,26 +6,43 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	pgerr "github.com/jackc/pgerrcode"
 	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/transaction"
 	errpkg "github.com/jackvonhouse/product-catalog/pkg/errors"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
+	"time"
 )
 
+const (
+	maxCategoryDepth = 64
+)
+
+type categoryRow struct {
+	dto.Category
+
+	Total int `db:"total"`
+}
+
 type Repository struct {
 	logger log.Logger
 
-	db *sqlx.DB
+	db          *sqlx.DB
+	transaction transaction.Manager
+	audit       auditRepository
 }
 
 func New(
 	db *sqlx.DB,
+	audit auditRepository,
 	logger log.Logger,
 ) Repository {
 
 	return Repository{
-		logger: logger.WithField("unit", "category"),
-		db:     db,
+		logger:      logger.WithField("unit", "category"),
+		db:          db,
+		transaction: transaction.New(db, logger),
+		audit:       audit,
 	}
 }
 
@@ -34,10 +51,36 @@ func (r Repository) Create(
 	data dto.CreateCategory,
 ) (int, error) {
 
+	var categoryId int
+
+	err := r.transaction.Run(ctx, r.errInternalCreateCategory, func(ctx context.Context, tx *sqlx.Tx) error {
+		id, err := r.createCategory(ctx, tx, data)
+		if err != nil {
+			return err
+		}
+
+		if err := r.auditAfter(ctx, tx, id, nil); err != nil {
+			return err
+		}
+
+		categoryId = id
+
+		return nil
+	})
+
+	return categoryId, err
+}
+
+func (r Repository) createCategory(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	data dto.CreateCategory,
+) (int, error) {
+
 	query, args, err := sq.
 		Insert("category").
-		Columns("name").
-		Values(data.Name).
+		Columns("name", "parent_id").
+		Values(data.Name, data.ParentId).
 		Suffix("RETURNING id").
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
@@ -45,7 +88,8 @@ func (r Repository) Create(
 	logger := r.logger.WithFields(map[string]any{
 		"query": query,
 		"args": map[string]any{
-			"name": data.Name,
+			"name":      data.Name,
+			"parent_id": data.ParentId,
 		},
 	})
 
@@ -57,7 +101,7 @@ func (r Repository) Create(
 
 	var categoryId int
 
-	if err := r.db.GetContext(ctx, &categoryId, query, args...); err != nil {
+	if err := tx.GetContext(ctx, &categoryId, query, args...); err != nil {
 		if e, ok := err.(*pq.Error); ok {
 			switch e.Code {
 
@@ -66,6 +110,11 @@ func (r Repository) Create(
 
 				return 0, r.errCategoryAlreadyExists(err)
 
+			case pgerr.ForeignKeyViolation:
+				logger.Warnf("parent category not found: %s", err)
+
+				return 0, r.errNotFound("parent category", err)
+
 			default:
 				logger.Warnf("unknown error on creating category: %s", err)
 
@@ -84,51 +133,98 @@ func (r Repository) Create(
 func (r Repository) Get(
 	ctx context.Context,
 	data dto.GetCategory,
-) ([]dto.Category, error) {
+) (dto.CategoryPage, error) {
 
 	var (
 		offset = uint64(data.Offset)
 		limit  = uint64(data.Limit)
 	)
 
-	query, args, err := sq.
+	count := sq.
+		Select("COUNT(*)").
+		From("category")
+
+	if !data.IncludeDeleted {
+		count = count.Where(sq.Eq{"deleted_at": nil})
+	}
+
+	builder := sq.
 		Select("*").
+		Column(sq.Alias(count, "total")).
 		From("category").
 		OrderBy("id ASC").
-		Offset(offset).
-		Limit(limit).
+		Limit(limit)
+
+	if !data.IncludeDeleted {
+		builder = builder.Where(sq.Eq{"deleted_at": nil})
+	}
+
+	if data.AfterId > 0 {
+		builder = builder.Where(sq.Gt{"id": data.AfterId})
+	} else {
+		builder = builder.Offset(offset)
+	}
+
+	query, args, err := builder.
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 
 	logger := r.logger.WithFields(map[string]any{
 		"query": query,
 		"args": map[string]any{
-			"limit":  data.Limit,
-			"offset": data.Offset,
+			"limit":           data.Limit,
+			"offset":          data.Offset,
+			"after_id":        data.AfterId,
+			"include_deleted": data.IncludeDeleted,
 		},
 	})
 
 	if err != nil {
 		logger.Warnf("unknown error on building sql query: %s", err)
 
-		return []dto.Category{}, r.errInternalBuildSql(err)
+		return dto.CategoryPage{}, r.errInternalBuildSql(err)
 	}
 
-	categories := make([]dto.Category, 0)
+	rows := make([]categoryRow, 0)
 
-	if err := r.db.SelectContext(ctx, &categories, query, args...); err != nil {
+	if err := sqlx.SelectContext(ctx, r.transaction.Executor(ctx), &rows, query, args...); err != nil {
 		if !errpkg.Is(err, sql.ErrNoRows) {
 			logger.Warnf("unknown error on getting categories: %s", err)
 
-			return []dto.Category{}, r.errInternalGetCategories(err)
+			return dto.CategoryPage{}, r.errInternalGetCategories(err)
 		}
 
 		logger.Warnf("no categories: %s", err)
 
-		return []dto.Category{}, r.errNotFound("categories", err)
+		return dto.CategoryPage{}, r.errNotFound("categories", err)
 	}
 
-	return categories, nil
+	page := dto.CategoryPage{
+		Items: make([]dto.Category, 0, len(rows)),
+	}
+
+	for _, row := range rows {
+		page.Items = append(page.Items, row.Category)
+	}
+
+	if len(rows) > 0 {
+		page.Total = rows[0].Total
+
+		return page, nil
+	}
+
+	if data.Offset == 0 && data.AfterId == 0 {
+		return page, nil
+	}
+
+	total, err := r.count(ctx, count)
+	if err != nil {
+		return dto.CategoryPage{}, err
+	}
+
+	page.Total = total
+
+	return page, nil
 }
 
 func (r Repository) GetById(
@@ -139,7 +235,7 @@ func (r Repository) GetById(
 	query, args, err := sq.
 		Select("*").
 		From("category").
-		Where(sq.Eq{"id": id}).
+		Where(sq.Eq{"id": id, "deleted_at": nil}).
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 
@@ -158,7 +254,7 @@ func (r Repository) GetById(
 
 	category := dto.Category{}
 
-	if err := r.db.GetContext(ctx, &category, query, args...); err != nil {
+	if err := sqlx.GetContext(ctx, r.transaction.Executor(ctx), &category, query, args...); err != nil {
 		if !errpkg.Is(err, sql.ErrNoRows) {
 			logger.Warnf("unknown error on getting category: %s", err)
 
@@ -173,17 +269,267 @@ func (r Repository) GetById(
 	return category, nil
 }
 
+func (r Repository) GetAll(
+	ctx context.Context,
+) ([]dto.Category, error) {
+
+	query, args, err := sq.
+		Select("*").
+		From("category").
+		Where(sq.Eq{"deleted_at": nil}).
+		OrderBy("id ASC").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return []dto.Category{}, r.errInternalBuildSql(err)
+	}
+
+	categories := make([]dto.Category, 0)
+
+	if err := sqlx.SelectContext(ctx, r.transaction.Executor(ctx), &categories, query, args...); err != nil {
+		logger.Warnf("unknown error on getting categories: %s", err)
+
+		return []dto.Category{}, r.errInternalGetCategories(err)
+	}
+
+	return categories, nil
+}
+
+func (r Repository) GetChildren(
+	ctx context.Context,
+	category dto.Category,
+) ([]dto.Category, error) {
+
+	query, args, err := sq.
+		Select("*").
+		From("category").
+		Where(sq.Eq{"parent_id": category.ID, "deleted_at": nil}).
+		OrderBy("id ASC").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"category_id": category.ID,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return []dto.Category{}, r.errInternalBuildSql(err)
+	}
+
+	categories := make([]dto.Category, 0)
+
+	if err := sqlx.SelectContext(ctx, r.transaction.Executor(ctx), &categories, query, args...); err != nil {
+		logger.Warnf("unknown error on getting children of category: %s", err)
+
+		return []dto.Category{}, r.errInternalGetCategories(err)
+	}
+
+	return categories, nil
+}
+
+func (r Repository) GetAncestors(
+	ctx context.Context,
+	category dto.Category,
+) ([]dto.Category, error) {
+
+	query, args, err := sq.
+		Select("id", "name", "parent_id").
+		Prefix(
+			"WITH RECURSIVE ancestors AS ("+
+				"SELECT c.id, c.name, c.parent_id, 1 AS depth "+
+				"FROM category c WHERE c.id = (SELECT parent_id FROM category WHERE id = ?) "+
+				"UNION ALL "+
+				"SELECT c.id, c.name, c.parent_id, a.depth + 1 "+
+				"FROM category c JOIN ancestors a ON c.id = a.parent_id "+
+				"WHERE a.depth < ?"+
+				")",
+			category.ID, maxCategoryDepth,
+		).
+		From("ancestors").
+		OrderBy("depth DESC").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"category_id": category.ID,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return []dto.Category{}, r.errInternalBuildSql(err)
+	}
+
+	categories := make([]dto.Category, 0)
+
+	if err := sqlx.SelectContext(ctx, r.transaction.Executor(ctx), &categories, query, args...); err != nil {
+		logger.Warnf("unknown error on getting ancestors of category: %s", err)
+
+		return []dto.Category{}, r.errInternalGetCategories(err)
+	}
+
+	return categories, nil
+}
+
+// LockAncestors блокирует перемещаемую категорию и цепочку предков нового
+// родителя до конца транзакции. Параллельное перемещение любой из них ждёт,
+// поэтому проверка на цикл после блокировки видит актуальное дерево
+func (r Repository) LockAncestors(
+	ctx context.Context,
+	id, parentId int,
+) error {
+
+	query, args, err := sq.
+		Select("id").
+		Prefix(
+			"WITH RECURSIVE ancestors AS ("+
+				"SELECT c.id, c.parent_id, 1 AS depth "+
+				"FROM category c WHERE c.id = ? "+
+				"UNION ALL "+
+				"SELECT c.id, c.parent_id, a.depth + 1 "+
+				"FROM category c JOIN ancestors a ON c.id = a.parent_id "+
+				"WHERE a.depth < ?"+
+				")",
+			parentId, maxCategoryDepth,
+		).
+		From("category").
+		Where(sq.Or{
+			sq.Eq{"id": id},
+			sq.Expr("id IN (SELECT id FROM ancestors)"),
+		}).
+		OrderBy("id ASC").
+		Suffix("FOR UPDATE").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"category_id": id,
+			"parent_id":   parentId,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return r.errInternalBuildSql(err)
+	}
+
+	locked := make([]int, 0)
+
+	if err := sqlx.SelectContext(ctx, r.transaction.Executor(ctx), &locked, query, args...); err != nil {
+		logger.Warnf("unknown error on locking ancestors of category: %s", err)
+
+		return r.errInternalGetCategories(err)
+	}
+
+	return nil
+}
+
+func (r Repository) GetByProductId(
+	ctx context.Context,
+	product dto.Product,
+) ([]dto.Category, error) {
+
+	query, args, err := sq.
+		Select("c.*").
+		From("category c").
+		Join("product_of_category pc ON pc.category_id = c.id").
+		Where(sq.Eq{"pc.product_id": product.ID, "c.deleted_at": nil}).
+		OrderBy("c.id ASC").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"product_id": product.ID,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return []dto.Category{}, r.errInternalBuildSql(err)
+	}
+
+	categories := make([]dto.Category, 0)
+
+	if err := sqlx.SelectContext(ctx, r.transaction.Executor(ctx), &categories, query, args...); err != nil {
+		logger.Warnf("unknown error on getting categories of product: %s", err)
+
+		return []dto.Category{}, r.errInternalGetCategories(err)
+	}
+
+	return categories, nil
+}
+
 func (r Repository) Update(
 	ctx context.Context,
 	data dto.UpdateCategory,
 ) (int, error) {
 
+	var categoryId int
+
+	err := r.transaction.Run(ctx, r.errInternalUpdateCategory, func(ctx context.Context, tx *sqlx.Tx) error {
+		before, err := r.auditBefore(ctx, tx, data.ID)
+		if err != nil {
+			return err
+		}
+
+		id, err := r.updateCategory(ctx, tx, data)
+		if err != nil {
+			return err
+		}
+
+		if err := r.auditAfter(ctx, tx, id, before); err != nil {
+			return err
+		}
+
+		categoryId = id
+
+		return nil
+	})
+
+	return categoryId, err
+}
+
+func (r Repository) updateCategory(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	data dto.UpdateCategory,
+) (int, error) {
+
+	where := sq.Eq{"id": data.ID, "deleted_at": nil}
+	if data.Version > 0 {
+		where["version"] = data.Version
+	}
+
 	query, args, err := sq.
 		Update("category").
 		SetMap(map[string]any{
-			"name": data.Name,
+			"name":       data.Name,
+			"parent_id":  data.ParentId,
+			"version":    sq.Expr("version + 1"),
+			"updated_at": sq.Expr("now()"),
 		}).
-		Where(sq.Eq{"id": data.ID}).
+		Where(where).
 		Suffix("RETURNING id").
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
@@ -193,6 +539,8 @@ func (r Repository) Update(
 		"args": map[string]any{
 			"category_id": data.ID,
 			"name":        data.Name,
+			"parent_id":   data.ParentId,
+			"version":     data.Version,
 		},
 	})
 
@@ -204,8 +552,14 @@ func (r Repository) Update(
 
 	var categoryId int
 
-	if err := r.db.GetContext(ctx, &categoryId, query, args...); err != nil {
+	if err := tx.GetContext(ctx, &categoryId, query, args...); err != nil {
 		if errpkg.Is(err, sql.ErrNoRows) {
+			if data.Version > 0 {
+				logger.Warnf("category has been modified: %s", err)
+
+				return 0, r.errCategoryModified(err)
+			}
+
 			logger.Warnf("category not found: %s", err)
 
 			return 0, r.errNotFound("category", err)
@@ -219,6 +573,16 @@ func (r Repository) Update(
 
 				return 0, r.errCategoryAlreadyExists(err)
 
+			case pgerr.ForeignKeyViolation:
+				logger.Warnf("parent category not found: %s", err)
+
+				return 0, r.errNotFound("parent category", err)
+
+			case pgerr.CheckViolation:
+				logger.Warnf("category can't be parent of itself: %s", err)
+
+				return 0, r.errInvalidCategory(err)
+
 			default:
 				logger.Warnf("unknown error on updating category: %s", err)
 
@@ -237,11 +601,53 @@ func (r Repository) Update(
 func (r Repository) Delete(
 	ctx context.Context,
 	category dto.Category,
+	version int,
 ) (int, error) {
 
+	var categoryId int
+
+	err := r.transaction.Run(ctx, r.errInternalDeleteCategory, func(ctx context.Context, tx *sqlx.Tx) error {
+		before, err := r.auditBefore(ctx, tx, category.ID)
+		if err != nil {
+			return err
+		}
+
+		id, err := r.deleteCategory(ctx, tx, category, version)
+		if err != nil {
+			return err
+		}
+
+		if err := r.auditAfter(ctx, tx, id, before); err != nil {
+			return err
+		}
+
+		categoryId = id
+
+		return nil
+	})
+
+	return categoryId, err
+}
+
+func (r Repository) deleteCategory(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	category dto.Category,
+	version int,
+) (int, error) {
+
+	where := sq.Eq{"id": category.ID, "deleted_at": nil}
+	if version > 0 {
+		where["version"] = version
+	}
+
 	query, args, err := sq.
-		Delete("category").
-		Where(sq.Eq{"id": category.ID}).
+		Update("category").
+		Set("deleted_at", sq.Expr("now()")).
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", sq.Expr("now()")).
+		Where(where).
+		Where(liveChildrenMissing).
 		Suffix("RETURNING id").
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
@@ -250,6 +656,7 @@ func (r Repository) Delete(
 		"query": query,
 		"args": map[string]any{
 			"category_id": category.ID,
+			"version":     version,
 		},
 	})
 
@@ -261,13 +668,30 @@ func (r Repository) Delete(
 
 	var categoryId int
 
-	if err := r.db.GetContext(ctx, &categoryId, query, args...); err != nil {
+	if err := tx.GetContext(ctx, &categoryId, query, args...); err != nil {
 		if !errpkg.Is(err, sql.ErrNoRows) {
 			logger.Warnf("unknown error on deleting category: %s", err)
 
 			return 0, r.errInternalDeleteCategory(err)
 		}
 
+		hasChildren, existsErr := r.exists(ctx, tx, liveChildren(category.ID))
+		if existsErr != nil {
+			return 0, existsErr
+		}
+
+		if hasChildren {
+			logger.Warn("category has live children")
+
+			return 0, r.errCategoryHasChildren()
+		}
+
+		if version > 0 {
+			logger.Warnf("category has been modified: %s", err)
+
+			return 0, r.errCategoryModified(err)
+		}
+
 		logger.Warnf("category not found: %s", err)
 
 		return 0, r.errNotFound("category", err)
@@ -275,3 +699,240 @@ func (r Repository) Delete(
 
 	return categoryId, nil
 }
+
+func (r Repository) Restore(
+	ctx context.Context,
+	id int,
+) (int, error) {
+
+	var categoryId int
+
+	err := r.transaction.Run(ctx, r.errInternalRestoreCategory, func(ctx context.Context, tx *sqlx.Tx) error {
+		before, err := r.auditBefore(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		id, err := r.restoreCategory(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := r.auditAfter(ctx, tx, id, before); err != nil {
+			return err
+		}
+
+		categoryId = id
+
+		return nil
+	})
+
+	return categoryId, err
+}
+
+func (r Repository) restoreCategory(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	id int,
+) (int, error) {
+
+	query, args, err := sq.
+		Update("category").
+		Set("deleted_at", nil).
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": id}).
+		Where(sq.NotEq{"deleted_at": nil}).
+		Where(deletedParentMissing).
+		Suffix("RETURNING id").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"category_id": id,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return 0, r.errInternalBuildSql(err)
+	}
+
+	var categoryId int
+
+	if err := tx.GetContext(ctx, &categoryId, query, args...); err != nil {
+		if errpkg.Is(err, sql.ErrNoRows) {
+			parentDeleted, existsErr := r.exists(ctx, tx, deletedParent(id))
+			if existsErr != nil {
+				return 0, existsErr
+			}
+
+			if parentDeleted {
+				logger.Warn("parent category is deleted")
+
+				return 0, r.errParentCategoryDeleted()
+			}
+
+			logger.Warnf("deleted category not found: %s", err)
+
+			return 0, r.errNotFound("deleted category", err)
+		}
+
+		if e, ok := err.(*pq.Error); ok && e.Code == pgerr.UniqueViolation {
+			logger.Warnf("category already exists: %s", err)
+
+			return 0, r.errCategoryAlreadyExists(err)
+		}
+
+		logger.Warnf("unknown error on restoring category: %s", err)
+
+		return 0, r.errInternalRestoreCategory(err)
+	}
+
+	return categoryId, nil
+}
+
+// Удалённая категория не должна оставаться родителем живых: иначе цепочка
+// предков живой категории проходит через удалённую
+var (
+	liveChildrenMissing = sq.Expr(
+		"NOT EXISTS (SELECT 1 FROM category child " +
+			"WHERE child.parent_id = category.id AND child.deleted_at IS NULL)",
+	)
+
+	deletedParentMissing = sq.Expr(
+		"NOT EXISTS (SELECT 1 FROM category parent " +
+			"WHERE parent.id = category.parent_id AND parent.deleted_at IS NOT NULL)",
+	)
+)
+
+func liveChildren(
+	id int,
+) sq.SelectBuilder {
+
+	return sq.
+		Select("1").
+		From("category").
+		Where(sq.Eq{"parent_id": id, "deleted_at": nil})
+}
+
+func deletedParent(
+	id int,
+) sq.SelectBuilder {
+
+	return sq.
+		Select("1").
+		From("category c").
+		Join("category parent ON parent.id = c.parent_id").
+		Where(sq.Eq{"c.id": id}).
+		Where(sq.NotEq{"parent.deleted_at": nil})
+}
+
+// exists уточняет причину, по которой условное изменение не затронуло строку
+func (r Repository) exists(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	builder sq.SelectBuilder,
+) (bool, error) {
+
+	query, args, err := builder.
+		Prefix("SELECT EXISTS (").
+		Suffix(")").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args":  args,
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return false, r.errInternalBuildSql(err)
+	}
+
+	var exists bool
+
+	if err := tx.GetContext(ctx, &exists, query, args...); err != nil {
+		logger.Warnf("unknown error on getting category: %s", err)
+
+		return false, r.errInternalGetCategory(err)
+	}
+
+	return exists, nil
+}
+
+func (r Repository) Purge(
+	ctx context.Context,
+	deletedBefore time.Time,
+) (int64, error) {
+
+	query, args, err := sq.
+		Delete("category").
+		Where(sq.Lt{"deleted_at": deletedBefore}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"deleted_before": deletedBefore,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return 0, r.errInternalBuildSql(err)
+	}
+
+	result, err := r.transaction.Executor(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		logger.Warnf("unknown error on purging categories: %s", err)
+
+		return 0, r.errInternalPurgeCategories(err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		logger.Warnf("unknown error on purging categories: %s", err)
+
+		return 0, r.errInternalPurgeCategories(err)
+	}
+
+	return purged, nil
+}
+
+func (r Repository) count(
+	ctx context.Context,
+	builder sq.SelectBuilder,
+) (int, error) {
+
+	query, args, err := builder.
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return 0, r.errInternalBuildSql(err)
+	}
+
+	var total int
+
+	if err := sqlx.GetContext(ctx, r.transaction.Executor(ctx), &total, query, args...); err != nil {
+		logger.Warnf("unknown error on counting categories: %s", err)
+
+		return 0, r.errInternalCountCategories(err)
+	}
+
+	return total, nil
+}
@@ -9,6 +9,7 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	pgerr "github.com/jackc/pgerrcode"
 	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/repository/audit"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
@@ -86,7 +87,7 @@ func (s *CreateTestSuite) setupMock(
 }
 
 func (s *CreateTestSuite) setupRepository() {
-	s.repository = New(s.db, s.logger)
+	s.repository = New(s.db, audit.New(s.db, s.logger), s.logger)
 }
 
 func (s *CreateTestSuite) setupCreate(
@@ -114,11 +115,15 @@ func (s *CreateTestSuite) setupCategory(
 }
 
 func (s *CreateTestSuite) TestSuccessful() {
+	{
+		s.mock.ExpectBegin().WillReturnError(nil)
+	}
+
 	{
 		query, args, err := sq.
 			Insert("category").
-			Columns("name").
-			Values(s.category.Name).
+			Columns("name", "parent_id").
+			Values(s.category.Name, s.category.ParentId).
 			Suffix("RETURNING id").
 			PlaceholderFormat(sq.Dollar).
 			ToSql()
@@ -135,6 +140,10 @@ func (s *CreateTestSuite) TestSuccessful() {
 			)
 	}
 
+	{
+		s.mock.ExpectCommit().WillReturnError(nil)
+	}
+
 	categoryId, err := s.repository.Create(s.ctx, s.create)
 
 	s.NoError(err)
@@ -148,6 +157,7 @@ func (s *CreateTestSuite) TestFailed() {
 	const (
 		expectedErrorMsg              = "unknown error on creating category"
 		expectedAlreadyExistsErrorMsg = "category already exists"
+		expectedParentNotFoundMsg     = "parent category not found"
 	)
 
 	testCases := []struct {
@@ -160,6 +170,11 @@ func (s *CreateTestSuite) TestFailed() {
 			expectedQueryError:    &pq.Error{Code: pgerr.UniqueViolation},
 			expectedQueryErrorMsg: expectedAlreadyExistsErrorMsg,
 		},
+		{
+			testName:              "Parent not found",
+			expectedQueryError:    &pq.Error{Code: pgerr.ForeignKeyViolation},
+			expectedQueryErrorMsg: expectedParentNotFoundMsg,
+		},
 		{
 			testName:              "Unknown database error",
 			expectedQueryError:    &pq.Error{Code: expectedErrorMsg},
@@ -174,11 +189,13 @@ func (s *CreateTestSuite) TestFailed() {
 
 	for _, testCase := range testCases {
 		s.Run(testCase.testName, func() {
+			s.mock.ExpectBegin().WillReturnError(nil)
+
 			{
 				query, args, err := sq.
 					Insert("category").
-					Columns("name").
-					Values(s.create.Name).
+					Columns("name", "parent_id").
+					Values(s.create.Name, s.create.ParentId).
 					Suffix("RETURNING id").
 					PlaceholderFormat(sq.Dollar).
 					ToSql()
@@ -193,6 +210,8 @@ func (s *CreateTestSuite) TestFailed() {
 					)
 			}
 
+			s.mock.ExpectRollback().WillReturnError(nil)
+
 			categoryId, err := s.repository.Create(s.ctx, s.create)
 
 			s.NotNil(err)
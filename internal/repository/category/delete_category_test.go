@@ -6,11 +6,21 @@ import (
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
 	sq "github.com/Masterminds/squirrel"
+	auditrecorder "github.com/jackvonhouse/product-catalog/internal/audit"
 	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/principal"
+	"github.com/jackvonhouse/product-catalog/internal/repository/audit"
+	"github.com/jackvonhouse/product-catalog/internal/requestid"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/suite"
 	"testing"
+	"time"
+)
+
+var liveChildrenMissingExpr = sq.Expr(
+	"NOT EXISTS (SELECT 1 FROM category child " +
+		"WHERE child.parent_id = category.id AND child.deleted_at IS NULL)",
 )
 
 type DeleteTestSuite struct {
@@ -71,7 +81,7 @@ func (s *DeleteTestSuite) setupMock(
 }
 
 func (s *DeleteTestSuite) setupRepository() {
-	s.repository = New(s.db, s.logger)
+	s.repository = New(s.db, audit.New(s.db, s.logger), s.logger)
 }
 
 func (s *DeleteTestSuite) setupCategory(
@@ -86,10 +96,18 @@ func (s *DeleteTestSuite) setupCategory(
 }
 
 func (s *DeleteTestSuite) TestSuccessful() {
+	{
+		s.mock.ExpectBegin().WillReturnError(nil)
+	}
+
 	{
 		query, args, err := sq.
-			Delete("category").
-			Where(sq.Eq{"id": s.category.ID}).
+			Update("category").
+			Set("deleted_at", sq.Expr("now()")).
+			Set("version", sq.Expr("version + 1")).
+			Set("updated_at", sq.Expr("now()")).
+			Where(sq.Eq{"id": s.category.ID, "deleted_at": nil}).
+			Where(liveChildrenMissingExpr).
 			Suffix("RETURNING id").
 			PlaceholderFormat(sq.Dollar).
 			ToSql()
@@ -106,29 +124,144 @@ func (s *DeleteTestSuite) TestSuccessful() {
 			)
 	}
 
-	categoryId, err := s.repository.Delete(s.ctx, s.category)
+	{
+		s.mock.ExpectCommit().WillReturnError(nil)
+	}
+
+	categoryId, err := s.repository.Delete(s.ctx, s.category, 0)
 
 	s.NoError(err)
 	s.Equal(1, categoryId)
 	s.NoError(s.mock.ExpectationsWereMet())
 }
 
+func (s *DeleteTestSuite) TestAuditSuccessful() {
+	const (
+		userId    = 1
+		username  = "admin"
+		requestId = "request"
+	)
+
+	updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	ctx := principal.WithContext(s.ctx, principal.Principal{
+		UserId:   userId,
+		Username: username,
+		Role:     dto.RoleAdmin,
+	})
+	ctx = requestid.WithContext(ctx, requestId)
+	ctx = auditrecorder.New(s.logger).
+		Record(ctx, auditrecorder.ActionDelete, auditrecorder.EntityCategory)
+
+	{
+		s.mock.ExpectBegin().WillReturnError(nil)
+	}
+
+	{
+		query, args, err := sq.
+			Select("*").
+			From("category").
+			Where(sq.Eq{"id": s.category.ID}).
+			Suffix("FOR UPDATE").
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnRows(
+				s.mock.
+					NewRows([]string{"id", "name", "parent_id", "updated_at", "deleted_at", "version"}).
+					AddRow(s.category.ID, "Категория", nil, updatedAt, nil, 1),
+			)
+	}
+
+	{
+		query, args, err := sq.
+			Update("category").
+			Set("deleted_at", sq.Expr("now()")).
+			Set("version", sq.Expr("version + 1")).
+			Set("updated_at", sq.Expr("now()")).
+			Where(sq.Eq{"id": s.category.ID, "deleted_at": nil}).
+			Where(liveChildrenMissingExpr).
+			Suffix("RETURNING id").
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnRows(
+				s.mock.
+					NewRows([]string{"id"}).
+					AddRow(s.category.ID),
+			)
+	}
+
+	{
+		query, args, err := sq.
+			Insert("audit_log").
+			Columns(
+				"actor_id", "actor", "action", "entity", "entity_id",
+				"before", "after", "request_id",
+			).
+			Values(
+				userId, username, auditrecorder.ActionDelete, auditrecorder.EntityCategory, s.category.ID,
+				`{"id":1,"name":"Категория","parent_id":null,"updated_at":"2024-01-02T03:04:05Z","version":1}`, nil, requestId,
+			).
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectExec(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+
+	{
+		s.mock.ExpectCommit().WillReturnError(nil)
+	}
+
+	categoryId, err := s.repository.Delete(ctx, s.category, 0)
+
+	s.NoError(err)
+	s.Equal(s.category.ID, categoryId)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
 func (s *DeleteTestSuite) TestFailed() {
 	const (
 		expectedErrorMsg         = "unknown error on deleting category"
 		expectedInternalErrorMsg = "unknown error"
 		expectedNotFoundErrorMsg = "category not found"
+		expectedChildrenErrorMsg = "category has children"
 	)
 
 	testCases := []struct {
 		testName         string
 		expectedError    error
 		expectedErrorMsg string
+		checkChildren    bool
+		hasChildren      bool
 	}{
 		{
 			testName:         "Not found",
 			expectedError:    sql.ErrNoRows,
 			expectedErrorMsg: expectedNotFoundErrorMsg,
+			checkChildren:    true,
+		},
+		{
+			testName:         "Has children",
+			expectedError:    sql.ErrNoRows,
+			expectedErrorMsg: expectedChildrenErrorMsg,
+			checkChildren:    true,
+			hasChildren:      true,
 		},
 		{
 			testName:         "Unknown error",
@@ -139,9 +272,15 @@ func (s *DeleteTestSuite) TestFailed() {
 
 	for _, testCase := range testCases {
 		s.Run(testCase.testName, func() {
+			s.mock.ExpectBegin().WillReturnError(nil)
+
 			query, args, err := sq.
-				Delete("category").
-				Where(sq.Eq{"id": s.category.ID}).
+				Update("category").
+				Set("deleted_at", sq.Expr("now()")).
+				Set("version", sq.Expr("version + 1")).
+				Set("updated_at", sq.Expr("now()")).
+				Where(sq.Eq{"id": s.category.ID, "deleted_at": nil}).
+				Where(liveChildrenMissingExpr).
 				Suffix("RETURNING id").
 				PlaceholderFormat(sq.Dollar).
 				ToSql()
@@ -153,7 +292,31 @@ func (s *DeleteTestSuite) TestFailed() {
 				WithArgs(convertArgs(args)...).
 				WillReturnError(testCase.expectedError)
 
-			categoryId, err := s.repository.Delete(s.ctx, s.category)
+			if testCase.checkChildren {
+				query, args, err := sq.
+					Select("1").
+					From("category").
+					Where(sq.Eq{"parent_id": s.category.ID, "deleted_at": nil}).
+					Prefix("SELECT EXISTS (").
+					Suffix(")").
+					PlaceholderFormat(sq.Dollar).
+					ToSql()
+
+				s.NoError(err)
+
+				s.mock.
+					ExpectQuery(query).
+					WithArgs(convertArgs(args)...).
+					WillReturnRows(
+						s.mock.
+							NewRows([]string{"exists"}).
+							AddRow(testCase.hasChildren),
+					)
+			}
+
+			s.mock.ExpectRollback().WillReturnError(nil)
+
+			categoryId, err := s.repository.Delete(s.ctx, s.category, 0)
 
 			s.NotNil(err)
 			s.Equal(err.Error(), testCase.expectedErrorMsg)
@@ -19,6 +19,13 @@ func (r Repository) errInternalGetCategories(
 	return errors.ErrInternal("getting", "categories", err)
 }
 
+func (r Repository) errInternalCountCategories(
+	err error,
+) error {
+
+	return errors.ErrInternal("counting", "categories", err)
+}
+
 func (r Repository) errInternalGetCategory(
 	err error,
 ) error {
@@ -61,6 +68,20 @@ func (r Repository) errInternalDeleteCategory(
 	return errors.ErrInternal("deleting", "category", err)
 }
 
+func (r Repository) errInternalRestoreCategory(
+	err error,
+) error {
+
+	return errors.ErrInternal("restoring", "category", err)
+}
+
+func (r Repository) errInternalPurgeCategories(
+	err error,
+) error {
+
+	return errors.ErrInternal("purging", "categories", err)
+}
+
 func (r Repository) errCategoryAlreadyExists(
 	err error,
 ) error {
@@ -68,6 +89,28 @@ func (r Repository) errCategoryAlreadyExists(
 	return errors.ErrAlreadyExists("category", err)
 }
 
+func (r Repository) errCategoryModified(
+	err error,
+) error {
+
+	return errors.ErrConflict("category", err)
+}
+
+func (r Repository) errCategoryHasChildren() error {
+	return errors.ErrHasChildren("category")
+}
+
+func (r Repository) errParentCategoryDeleted() error {
+	return errors.ErrDeleted("parent category")
+}
+
+func (r Repository) errInvalidCategory(
+	err error,
+) error {
+
+	return errors.ErrInvalid("category", err)
+}
+
 func (r Repository) errCategoryInCategoryAlreadyExists(
 	err error,
 ) error {
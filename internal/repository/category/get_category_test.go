@@ -7,6 +7,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/repository/audit"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/suite"
@@ -77,7 +78,7 @@ func (s *GetTestSuite) setupMock(
 }
 
 func (s *GetTestSuite) setupRepository() {
-	s.repository = New(s.db, s.logger)
+	s.repository = New(s.db, audit.New(s.db, s.logger), s.logger)
 }
 
 func (s *GetTestSuite) setupGet(
@@ -124,7 +125,9 @@ func (s *GetTestSuite) TestGetSuccessful() {
 	{
 		query, args, err := sq.
 			Select("*").
+			Column(sq.Alias(sq.Select("COUNT(*)").From("category").Where(sq.Eq{"deleted_at": nil}), "total")).
 			From("category").
+			Where(sq.Eq{"deleted_at": nil}).
 			OrderBy("id ASC").
 			Offset(uint64(s.get.Offset)).
 			Limit(uint64(s.get.Limit)).
@@ -138,18 +141,19 @@ func (s *GetTestSuite) TestGetSuccessful() {
 			WithArgs(convertArgs(args)...).
 			WillReturnRows(
 				s.mock.
-					NewRows([]string{"id", "name"}).
+					NewRows([]string{"id", "name", "total"}).
 					AddRow(
 						s.category.ID,
 						s.category.Name,
+						1,
 					),
 			)
 	}
 
-	categories, err := s.repository.Get(s.ctx, s.get)
+	page, err := s.repository.Get(s.ctx, s.get)
 
 	s.NoError(err)
-	s.Equal(categories, s.categories)
+	s.Equal(dto.CategoryPage{Items: s.categories, Total: 1}, page)
 	s.NoError(s.mock.ExpectationsWereMet())
 }
 
@@ -182,7 +186,9 @@ func (s *GetTestSuite) TestGetFailure() {
 			{
 				query, args, err := sq.
 					Select("*").
+					Column(sq.Alias(sq.Select("COUNT(*)").From("category").Where(sq.Eq{"deleted_at": nil}), "total")).
 					From("category").
+					Where(sq.Eq{"deleted_at": nil}).
 					OrderBy("id ASC").
 					Offset(uint64(s.get.Offset)).
 					Limit(uint64(s.get.Limit)).
@@ -197,11 +203,11 @@ func (s *GetTestSuite) TestGetFailure() {
 					WillReturnError(testCase.expectedError)
 			}
 
-			categories, err := s.repository.Get(s.ctx, s.get)
+			page, err := s.repository.Get(s.ctx, s.get)
 
 			s.NotNil(err)
 			s.Equal(err.Error(), testCase.expectedErrorMsg)
-			s.Equal(categories, []dto.Category{})
+			s.Equal(dto.CategoryPage{}, page)
 			s.NoError(s.mock.ExpectationsWereMet())
 		})
 	}
@@ -212,7 +218,7 @@ func (s *GetTestSuite) TestGetByIdSuccessful() {
 		query, args, err := sq.
 			Select("*").
 			From("category").
-			Where(sq.Eq{"id": s.category.ID}).
+			Where(sq.Eq{"id": s.category.ID, "deleted_at": nil}).
 			PlaceholderFormat(sq.Dollar).
 			ToSql()
 
@@ -268,7 +274,7 @@ func (s *GetTestSuite) TestGetByIdFailure() {
 				query, args, err := sq.
 					Select("*").
 					From("category").
-					Where(sq.Eq{"id": s.category.ID}).
+					Where(sq.Eq{"id": s.category.ID, "deleted_at": nil}).
 					PlaceholderFormat(sq.Dollar).
 					ToSql()
 
@@ -289,3 +295,275 @@ func (s *GetTestSuite) TestGetByIdFailure() {
 		})
 	}
 }
+
+func (s *GetTestSuite) TestGetByProductIdSuccessful() {
+	product := dto.Product{ID: 1}
+
+	{
+		query, args, err := sq.
+			Select("c.*").
+			From("category c").
+			Join("product_of_category pc ON pc.category_id = c.id").
+			Where(sq.Eq{"pc.product_id": product.ID, "c.deleted_at": nil}).
+			OrderBy("c.id ASC").
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnRows(
+				s.mock.
+					NewRows([]string{"id", "name"}).
+					AddRow(
+						s.category.ID,
+						s.category.Name,
+					),
+			)
+	}
+
+	categories, err := s.repository.GetByProductId(s.ctx, product)
+
+	s.NoError(err)
+	s.Equal(categories, s.categories)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *GetTestSuite) TestGetByProductIdFailure() {
+	const (
+		expectedInternalErrorMsg = "unknown database error"
+		expectedErrorMsg         = "unknown error on getting categories"
+	)
+
+	product := dto.Product{ID: 1}
+
+	{
+		query, args, err := sq.
+			Select("c.*").
+			From("category c").
+			Join("product_of_category pc ON pc.category_id = c.id").
+			Where(sq.Eq{"pc.product_id": product.ID, "c.deleted_at": nil}).
+			OrderBy("c.id ASC").
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnError(errors.New(expectedInternalErrorMsg))
+	}
+
+	categories, err := s.repository.GetByProductId(s.ctx, product)
+
+	s.NotNil(err)
+	s.Equal(err.Error(), expectedErrorMsg)
+	s.Equal(categories, []dto.Category{})
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *GetTestSuite) TestGetChildrenSuccessful() {
+	parentId := 1
+
+	s.categories[0].ParentId = &parentId
+
+	{
+		query, args, err := sq.
+			Select("*").
+			From("category").
+			Where(sq.Eq{"parent_id": s.category.ID, "deleted_at": nil}).
+			OrderBy("id ASC").
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnRows(
+				s.mock.
+					NewRows([]string{"id", "name", "parent_id"}).
+					AddRow(
+						s.categories[0].ID,
+						s.categories[0].Name,
+						parentId,
+					),
+			)
+	}
+
+	categories, err := s.repository.GetChildren(s.ctx, s.category)
+
+	s.NoError(err)
+	s.Equal(categories, s.categories)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *GetTestSuite) TestGetChildrenFailure() {
+	const (
+		expectedInternalErrorMsg = "unknown database error"
+		expectedErrorMsg         = "unknown error on getting categories"
+	)
+
+	{
+		query, args, err := sq.
+			Select("*").
+			From("category").
+			Where(sq.Eq{"parent_id": s.category.ID, "deleted_at": nil}).
+			OrderBy("id ASC").
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnError(errors.New(expectedInternalErrorMsg))
+	}
+
+	categories, err := s.repository.GetChildren(s.ctx, s.category)
+
+	s.NotNil(err)
+	s.Equal(err.Error(), expectedErrorMsg)
+	s.Equal(categories, []dto.Category{})
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *GetTestSuite) TestGetAncestorsSuccessful() {
+	{
+		query, args, err := sq.
+			Select("id", "name", "parent_id").
+			Prefix(
+				"WITH RECURSIVE ancestors AS ("+
+					"SELECT c.id, c.name, c.parent_id, 1 AS depth "+
+					"FROM category c WHERE c.id = (SELECT parent_id FROM category WHERE id = ?) "+
+					"UNION ALL "+
+					"SELECT c.id, c.name, c.parent_id, a.depth + 1 "+
+					"FROM category c JOIN ancestors a ON c.id = a.parent_id "+
+					"WHERE a.depth < ?"+
+					")",
+				s.category.ID, maxCategoryDepth,
+			).
+			From("ancestors").
+			OrderBy("depth DESC").
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnRows(
+				s.mock.
+					NewRows([]string{"id", "name", "parent_id"}).
+					AddRow(
+						s.categories[0].ID,
+						s.categories[0].Name,
+						nil,
+					),
+			)
+	}
+
+	categories, err := s.repository.GetAncestors(s.ctx, s.category)
+
+	s.NoError(err)
+	s.Equal(categories, s.categories)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *GetTestSuite) TestGetAfterIdSuccessful() {
+	s.get.AfterId = 1
+
+	{
+		query, args, err := sq.
+			Select("*").
+			Column(sq.Alias(sq.Select("COUNT(*)").From("category").Where(sq.Eq{"deleted_at": nil}), "total")).
+			From("category").
+			Where(sq.Eq{"deleted_at": nil}).
+			Where(sq.Gt{"id": s.get.AfterId}).
+			OrderBy("id ASC").
+			Limit(uint64(s.get.Limit)).
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnRows(
+				s.mock.
+					NewRows([]string{"id", "name", "total"}).
+					AddRow(
+						s.category.ID,
+						s.category.Name,
+						1,
+					),
+			)
+	}
+
+	page, err := s.repository.Get(s.ctx, s.get)
+
+	s.NoError(err)
+	s.Equal(dto.CategoryPage{Items: s.categories, Total: 1}, page)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *GetTestSuite) TestGetOutOfRangeSuccessful() {
+	s.get.Offset = 10
+
+	count := sq.
+		Select("COUNT(*)").
+		From("category").
+		Where(sq.Eq{"deleted_at": nil})
+
+	{
+		query, args, err := sq.
+			Select("*").
+			Column(sq.Alias(count, "total")).
+			From("category").
+			Where(sq.Eq{"deleted_at": nil}).
+			OrderBy("id ASC").
+			Offset(uint64(s.get.Offset)).
+			Limit(uint64(s.get.Limit)).
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnRows(
+				s.mock.NewRows([]string{"id", "name", "total"}),
+			)
+	}
+
+	{
+		query, args, err := count.
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnRows(
+				s.mock.
+					NewRows([]string{"count"}).
+					AddRow(3),
+			)
+	}
+
+	page, err := s.repository.Get(s.ctx, s.get)
+
+	s.NoError(err)
+	s.Equal(dto.CategoryPage{Items: []dto.Category{}, Total: 3}, page)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
@@ -8,6 +8,7 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	pgerr "github.com/jackc/pgerrcode"
 	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/repository/audit"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
@@ -75,7 +76,7 @@ func (s *UpdateTestSuite) setupMock(
 }
 
 func (s *UpdateTestSuite) setupRepository() {
-	s.repository = New(s.db, s.logger)
+	s.repository = New(s.db, audit.New(s.db, s.logger), s.logger)
 }
 
 func (s *UpdateTestSuite) setupUpdate(
@@ -104,13 +105,20 @@ func (s *UpdateTestSuite) setupCategory(
 }
 
 func (s *UpdateTestSuite) TestSuccessful() {
+	{
+		s.mock.ExpectBegin().WillReturnError(nil)
+	}
+
 	{
 		query, args, err := sq.
 			Update("category").
 			SetMap(map[string]any{
-				"name": s.update.Name,
+				"name":       s.update.Name,
+				"parent_id":  s.update.ParentId,
+				"version":    sq.Expr("version + 1"),
+				"updated_at": sq.Expr("now()"),
 			}).
-			Where(sq.Eq{"id": s.category.ID}).
+			Where(sq.Eq{"id": s.category.ID, "deleted_at": nil}).
 			Suffix("RETURNING id").
 			PlaceholderFormat(sq.Dollar).
 			ToSql()
@@ -127,6 +135,10 @@ func (s *UpdateTestSuite) TestSuccessful() {
 			)
 	}
 
+	{
+		s.mock.ExpectCommit().WillReturnError(nil)
+	}
+
 	categoryId, err := s.repository.Update(s.ctx, s.update)
 
 	s.NoError(err)
@@ -140,6 +152,8 @@ func (s *UpdateTestSuite) TestFailed() {
 		expectedInternalErrorMsg      = "unknown database error"
 		expectedNotFoundErrorMsg      = "category not found"
 		expectedAlreadyExistsErrorMsg = "category already exists"
+		expectedParentNotFoundMsg     = "parent category not found"
+		expectedInvalidErrorMsg       = "invalid category data"
 	)
 
 	testCases := []struct {
@@ -157,6 +171,16 @@ func (s *UpdateTestSuite) TestFailed() {
 			expectedError:    &pq.Error{Code: pgerr.UniqueViolation, Message: expectedAlreadyExistsErrorMsg},
 			expectedErrorMsg: expectedAlreadyExistsErrorMsg,
 		},
+		{
+			testName:         "Parent not found",
+			expectedError:    &pq.Error{Code: pgerr.ForeignKeyViolation},
+			expectedErrorMsg: expectedParentNotFoundMsg,
+		},
+		{
+			testName:         "Parent of itself",
+			expectedError:    &pq.Error{Code: pgerr.CheckViolation},
+			expectedErrorMsg: expectedInvalidErrorMsg,
+		},
 		{
 			testName:         "Unknown database error",
 			expectedError:    &pq.Error{Message: expectedInternalErrorMsg},
@@ -171,13 +195,18 @@ func (s *UpdateTestSuite) TestFailed() {
 
 	for _, testCase := range testCases {
 		s.Run(testCase.testName, func() {
+			s.mock.ExpectBegin().WillReturnError(nil)
+
 			{
 				query, args, err := sq.
 					Update("category").
 					SetMap(map[string]any{
-						"name": s.update.Name,
+						"name":       s.update.Name,
+						"parent_id":  s.update.ParentId,
+						"version":    sq.Expr("version + 1"),
+						"updated_at": sq.Expr("now()"),
 					}).
-					Where(sq.Eq{"id": s.category.ID}).
+					Where(sq.Eq{"id": s.category.ID, "deleted_at": nil}).
 					Suffix("RETURNING id").
 					PlaceholderFormat(sq.Dollar).
 					ToSql()
@@ -190,6 +219,8 @@ func (s *UpdateTestSuite) TestFailed() {
 					WillReturnError(testCase.expectedError)
 			}
 
+			s.mock.ExpectRollback().WillReturnError(nil)
+
 			categoryId, err := s.repository.Update(s.ctx, s.update)
 
 			s.NotNil(err)
@@ -199,3 +230,81 @@ func (s *UpdateTestSuite) TestFailed() {
 		})
 	}
 }
+
+func (s *UpdateTestSuite) lockAncestorsQuery(
+	parentId int,
+) (string, []any, error) {
+
+	return sq.
+		Select("id").
+		Prefix(
+			"WITH RECURSIVE ancestors AS ("+
+				"SELECT c.id, c.parent_id, 1 AS depth "+
+				"FROM category c WHERE c.id = ? "+
+				"UNION ALL "+
+				"SELECT c.id, c.parent_id, a.depth + 1 "+
+				"FROM category c JOIN ancestors a ON c.id = a.parent_id "+
+				"WHERE a.depth < ?"+
+				")",
+			parentId, maxCategoryDepth,
+		).
+		From("category").
+		Where(sq.Or{
+			sq.Eq{"id": s.category.ID},
+			sq.Expr("id IN (SELECT id FROM ancestors)"),
+		}).
+		OrderBy("id ASC").
+		Suffix("FOR UPDATE").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+}
+
+func (s *UpdateTestSuite) TestLockAncestorsSuccessful() {
+	const (
+		parentId = 2
+	)
+
+	{
+		query, args, err := s.lockAncestorsQuery(parentId)
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnRows(
+				s.mock.
+					NewRows([]string{"id"}).
+					AddRow(s.category.ID).
+					AddRow(parentId),
+			)
+	}
+
+	err := s.repository.LockAncestors(s.ctx, s.category.ID, parentId)
+
+	s.NoError(err)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *UpdateTestSuite) TestLockAncestorsFailed() {
+	const (
+		parentId         = 2
+		expectedErrorMsg = "unknown error on getting categories"
+	)
+
+	{
+		query, args, err := s.lockAncestorsQuery(parentId)
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnError(errors.New("deadlock detected"))
+	}
+
+	err := s.repository.LockAncestors(s.ctx, s.category.ID, parentId)
+
+	s.EqualError(err, expectedErrorMsg)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
@@ -39,6 +39,17 @@ func ErrAlreadyExists(
 		Wrap(err)
 }
 
+func ErrConflict(
+	unit string,
+	err error,
+) error {
+
+	return errors.
+		ErrConflict.
+		New(fmt.Sprintf("%s has been modified", unit)).
+		Wrap(err)
+}
+
 func ErrExpired(
 	unit string,
 	err error,
@@ -49,3 +60,60 @@ func ErrExpired(
 		New(fmt.Sprintf("%s expired", unit)).
 		Wrap(err)
 }
+
+func ErrInvalid(
+	unit string,
+	err error,
+) error {
+
+	return errors.
+		ErrInvalid.
+		New(fmt.Sprintf("invalid %s data", unit)).
+		Wrap(err)
+}
+
+func ErrUnknown(
+	unit string,
+	value string,
+) error {
+
+	return errors.
+		ErrInvalid.
+		New(fmt.Sprintf("unknown %s %q", unit, value))
+}
+
+func ErrUsed(
+	unit string,
+) error {
+
+	return errors.
+		ErrAlreadyExists.
+		New(fmt.Sprintf("%s already used", unit))
+}
+
+func ErrFinished(
+	unit string,
+) error {
+
+	return errors.
+		ErrAlreadyExists.
+		New(fmt.Sprintf("%s already finished", unit))
+}
+
+func ErrHasChildren(
+	unit string,
+) error {
+
+	return errors.
+		ErrAlreadyExists.
+		New(fmt.Sprintf("%s has children", unit))
+}
+
+func ErrDeleted(
+	unit string,
+) error {
+
+	return errors.
+		ErrInvalid.
+		New(fmt.Sprintf("%s is deleted", unit))
+}
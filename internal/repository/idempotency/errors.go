@@ -0,0 +1,54 @@
+package idempotency
+
+import (
+	"github.com/jackvonhouse/product-catalog/internal/repository/errors"
+)
+
+func (r Repository) errInternalCreateKey(
+	err error,
+) error {
+
+	return errors.ErrInternal("creating", "idempotency key", err)
+}
+
+func (r Repository) errInternalGetKey(
+	err error,
+) error {
+
+	return errors.ErrInternal("getting", "idempotency key", err)
+}
+
+func (r Repository) errInternalUpdateKey(
+	err error,
+) error {
+
+	return errors.ErrInternal("updating", "idempotency key", err)
+}
+
+func (r Repository) errInternalDeleteKey(
+	err error,
+) error {
+
+	return errors.ErrInternal("deleting", "idempotency key", err)
+}
+
+func (r Repository) errInternalPurgeKeys(
+	err error,
+) error {
+
+	return errors.ErrInternal("purging", "idempotency keys", err)
+}
+
+func (r Repository) errInternalBuildSql(
+	err error,
+) error {
+
+	return errors.ErrInternal("building", "sql query", err)
+}
+
+func (r Repository) errNotFound(
+	err error,
+) error {
+
+	return errors.ErrNotFound("idempotency key", err)
+}
@@ -0,0 +1,252 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	errpkg "github.com/jackvonhouse/product-catalog/pkg/errors"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/jmoiron/sqlx"
+	"strings"
+	"time"
+)
+
+var (
+	keyColumns = []string{
+		"key", "user_id", "fingerprint", "status_code", "content_type", "body", "expires_at",
+	}
+)
+
+type Repository struct {
+	logger log.Logger
+
+	db *sqlx.DB
+}
+
+func New(
+	db *sqlx.DB,
+	logger log.Logger,
+) Repository {
+
+	return Repository{
+		logger: logger.WithField("unit", "idempotency_key"),
+		db:     db,
+	}
+}
+
+// Begin занимает ключ за запросом. Истёкший ключ занимается заново.
+// Если ключ уже занят, возвращается сохранённая запись и false
+func (r Repository) Begin(
+	ctx context.Context,
+	data dto.IdempotencyKey,
+) (dto.IdempotencyKey, bool, error) {
+
+	query, args, err := sq.
+		Insert("idempotency_key").
+		Columns("key", "user_id", "fingerprint", "expires_at").
+		Values(data.Key, data.UserId, data.Fingerprint, data.ExpiresAt).
+		Suffix(
+			"ON CONFLICT (key, user_id) DO UPDATE SET " +
+				"fingerprint = EXCLUDED.fingerprint, status_code = 0, content_type = '', body = '', " +
+				"created_at = now(), expires_at = EXCLUDED.expires_at " +
+				"WHERE idempotency_key.expires_at < now() " +
+				"RETURNING " + strings.Join(keyColumns, ", "),
+		).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"key":     data.Key,
+			"user_id": data.UserId,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return dto.IdempotencyKey{}, false, r.errInternalBuildSql(err)
+	}
+
+	key := dto.IdempotencyKey{}
+
+	err = r.db.GetContext(ctx, &key, query, args...)
+	if err == nil {
+		return key, true, nil
+	}
+
+	if !errpkg.Is(err, sql.ErrNoRows) {
+		logger.Warnf("unknown error on creating idempotency key: %s", err)
+
+		return dto.IdempotencyKey{}, false, r.errInternalCreateKey(err)
+	}
+
+	key, err = r.get(ctx, data.Key, data.UserId)
+	if err != nil {
+		return dto.IdempotencyKey{}, false, err
+	}
+
+	return key, false, nil
+}
+
+// Complete сохраняет ответ, который будет повторён для этого ключа
+func (r Repository) Complete(
+	ctx context.Context,
+	data dto.IdempotencyKey,
+) error {
+
+	query, args, err := sq.
+		Update("idempotency_key").
+		Set("status_code", data.StatusCode).
+		Set("content_type", data.ContentType).
+		Set("body", data.Body).
+		Where(sq.Eq{"key": data.Key, "user_id": data.UserId}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"key":         data.Key,
+			"user_id":     data.UserId,
+			"status_code": data.StatusCode,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return r.errInternalBuildSql(err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		logger.Warnf("unknown error on updating idempotency key: %s", err)
+
+		return r.errInternalUpdateKey(err)
+	}
+
+	return nil
+}
+
+// Release освобождает ключ запроса, который не удалось выполнить,
+// чтобы его можно было повторить
+func (r Repository) Release(
+	ctx context.Context,
+	data dto.IdempotencyKey,
+) error {
+
+	query, args, err := sq.
+		Delete("idempotency_key").
+		Where(sq.Eq{"key": data.Key, "user_id": data.UserId, "status_code": 0}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"key":     data.Key,
+			"user_id": data.UserId,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return r.errInternalBuildSql(err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		logger.Warnf("unknown error on deleting idempotency key: %s", err)
+
+		return r.errInternalDeleteKey(err)
+	}
+
+	return nil
+}
+
+func (r Repository) Purge(
+	ctx context.Context,
+	expiredBefore time.Time,
+) (int64, error) {
+
+	query, args, err := sq.
+		Delete("idempotency_key").
+		Where(sq.Lt{"expires_at": expiredBefore}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"expired_before": expiredBefore,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return 0, r.errInternalBuildSql(err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		logger.Warnf("unknown error on purging idempotency keys: %s", err)
+
+		return 0, r.errInternalPurgeKeys(err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		logger.Warnf("unknown error on purging idempotency keys: %s", err)
+
+		return 0, r.errInternalPurgeKeys(err)
+	}
+
+	return purged, nil
+}
+
+func (r Repository) get(
+	ctx context.Context,
+	keyValue string,
+	userId int,
+) (dto.IdempotencyKey, error) {
+
+	query, args, err := sq.
+		Select(keyColumns...).
+		From("idempotency_key").
+		Where(sq.Eq{"key": keyValue, "user_id": userId}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"key":     keyValue,
+			"user_id": userId,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return dto.IdempotencyKey{}, r.errInternalBuildSql(err)
+	}
+
+	key := dto.IdempotencyKey{}
+
+	if err := r.db.GetContext(ctx, &key, query, args...); err != nil {
+		if errpkg.Is(err, sql.ErrNoRows) {
+			logger.Warnf("idempotency key not found: %s", err)
+
+			return dto.IdempotencyKey{}, r.errNotFound(err)
+		}
+
+		logger.Warnf("unknown error on getting idempotency key: %s", err)
+
+		return dto.IdempotencyKey{}, r.errInternalGetKey(err)
+	}
+
+	return key, nil
+}
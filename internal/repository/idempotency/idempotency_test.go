@@ -0,0 +1,219 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+	"time"
+)
+
+type IdempotencyTestSuite struct {
+	suite.Suite
+
+	// Вспомогательные параметры
+	ctx        context.Context
+	logger     log.Logger
+	repository Repository
+
+	// Входные параметры
+	key dto.IdempotencyKey
+
+	// Служебные параметры
+	db   *sqlx.DB
+	mock sqlmock.Sqlmock
+}
+
+func convertArgs(args []any) []driver.Value {
+	converted := make([]driver.Value, len(args))
+
+	for i, arg := range args {
+		converted[i] = arg
+	}
+
+	return converted
+}
+
+func TestSuiteIdempotency(t *testing.T) {
+	suite.Run(t, &IdempotencyTestSuite{})
+}
+
+func (s *IdempotencyTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.logger = log.NewNullLogger()
+}
+
+func (s *IdempotencyTestSuite) BeforeTest(_, _ string) {
+	db, mock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+	)
+	s.NoError(err)
+
+	s.setupDatabase(db).setupMock(mock).setupRepository()
+
+	// Входные значения по-умолчанию
+	s.setupKey("key", 1, "fingerprint")
+}
+
+func (s *IdempotencyTestSuite) setupDatabase(
+	db *sql.DB,
+) *IdempotencyTestSuite {
+
+	wrappedDb := sqlx.NewDb(db, "sqlmock")
+
+	s.db = wrappedDb
+
+	return s
+}
+
+func (s *IdempotencyTestSuite) setupMock(
+	mock sqlmock.Sqlmock,
+) *IdempotencyTestSuite {
+
+	s.mock = mock
+
+	return s
+}
+
+func (s *IdempotencyTestSuite) setupRepository() *IdempotencyTestSuite {
+	s.repository = New(s.db, s.logger)
+
+	return s
+}
+
+func (s *IdempotencyTestSuite) setupKey(
+	key string,
+	userId int,
+	fingerprint string,
+) {
+
+	s.key = dto.IdempotencyKey{
+		Key:         key,
+		UserId:      userId,
+		Fingerprint: fingerprint,
+		ExpiresAt:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (s *IdempotencyTestSuite) keyRows(
+	statusCode int,
+	body string,
+) *sqlmock.Rows {
+
+	return sqlmock.
+		NewRows(keyColumns).
+		AddRow(
+			s.key.Key, s.key.UserId, s.key.Fingerprint,
+			statusCode, "application/json", []byte(body), s.key.ExpiresAt,
+		)
+}
+
+func (s *IdempotencyTestSuite) expectBegin() *sqlmock.ExpectedQuery {
+	query, args, err := sq.
+		Insert("idempotency_key").
+		Columns("key", "user_id", "fingerprint", "expires_at").
+		Values(s.key.Key, s.key.UserId, s.key.Fingerprint, s.key.ExpiresAt).
+		Suffix(
+			"ON CONFLICT (key, user_id) DO UPDATE SET " +
+				"fingerprint = EXCLUDED.fingerprint, status_code = 0, content_type = '', body = '', " +
+				"created_at = now(), expires_at = EXCLUDED.expires_at " +
+				"WHERE idempotency_key.expires_at < now() " +
+				"RETURNING " + strings.Join(keyColumns, ", "),
+		).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	s.NoError(err)
+
+	return s.mock.
+		ExpectQuery(query).
+		WithArgs(convertArgs(args)...)
+}
+
+func (s *IdempotencyTestSuite) TestBeginCreatedSuccessful() {
+	s.expectBegin().WillReturnRows(s.keyRows(0, ""))
+
+	key, created, err := s.repository.Begin(s.ctx, s.key)
+
+	s.NoError(err)
+	s.True(created)
+	s.Equal(0, key.StatusCode)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *IdempotencyTestSuite) TestBeginExistingSuccessful() {
+	const (
+		expectedBody = `{"id":1}`
+	)
+
+	s.expectBegin().WillReturnError(sql.ErrNoRows)
+
+	query, args, err := sq.
+		Select(keyColumns...).
+		From("idempotency_key").
+		Where(sq.Eq{"key": s.key.Key, "user_id": s.key.UserId}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	s.NoError(err)
+
+	s.mock.
+		ExpectQuery(query).
+		WithArgs(convertArgs(args)...).
+		WillReturnRows(s.keyRows(200, expectedBody))
+
+	key, created, err := s.repository.Begin(s.ctx, s.key)
+
+	s.NoError(err)
+	s.False(created)
+	s.Equal(200, key.StatusCode)
+	s.Equal(expectedBody, string(key.Body))
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *IdempotencyTestSuite) TestBeginFailed() {
+	const (
+		expectedError = "unknown error on creating idempotency key"
+	)
+
+	s.expectBegin().WillReturnError(errors.New("unknown error"))
+
+	_, created, err := s.repository.Begin(s.ctx, s.key)
+
+	s.EqualError(err, expectedError)
+	s.False(created)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *IdempotencyTestSuite) TestCompleteSuccessful() {
+	s.key.StatusCode = 200
+	s.key.ContentType = "application/json"
+	s.key.Body = []byte(`{"id":1}`)
+
+	query, args, err := sq.
+		Update("idempotency_key").
+		Set("status_code", s.key.StatusCode).
+		Set("content_type", s.key.ContentType).
+		Set("body", s.key.Body).
+		Where(sq.Eq{"key": s.key.Key, "user_id": s.key.UserId}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	s.NoError(err)
+
+	s.mock.
+		ExpectExec(query).
+		WithArgs(convertArgs(args)...).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	s.NoError(s.repository.Complete(s.ctx, s.key))
+	s.NoError(s.mock.ExpectationsWereMet())
+}
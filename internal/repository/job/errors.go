@@ -0,0 +1,59 @@
+package job
+
+import (
+	"github.com/jackvonhouse/product-catalog/internal/repository/errors"
+)
+
+func (r Repository) errInternalCreateJob(
+	err error,
+) error {
+
+	return errors.ErrInternal("creating", "job", err)
+}
+
+func (r Repository) errInternalGetJob(
+	err error,
+) error {
+
+	return errors.ErrInternal("getting", "job", err)
+}
+
+func (r Repository) errInternalDequeueJob(
+	err error,
+) error {
+
+	return errors.ErrInternal("dequeuing", "job", err)
+}
+
+func (r Repository) errInternalUpdateJob(
+	err error,
+) error {
+
+	return errors.ErrInternal("updating", "job", err)
+}
+
+func (r Repository) errInternalPurgeJobs(
+	err error,
+) error {
+
+	return errors.ErrInternal("purging", "jobs", err)
+}
+
+func (r Repository) errInternalBuildSql(
+	err error,
+) error {
+
+	return errors.ErrInternal("building", "sql query", err)
+}
+
+func (r Repository) errJobFinished() error {
+	return errors.ErrFinished("job")
+}
+
+func (r Repository) errNotFound(
+	unit string,
+	err error,
+) error {
+
+	return errors.ErrNotFound(unit, err)
+}
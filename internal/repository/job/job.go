@@ -0,0 +1,538 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	errpkg "github.com/jackvonhouse/product-catalog/pkg/errors"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/jmoiron/sqlx"
+	"strings"
+	"time"
+)
+
+var (
+	jobColumns = []string{
+		"id", "type", "status", "payload", "result", "error", "progress", "attempt",
+		"file_name", "cancel_requested", "actor_id", "actor", "actor_role",
+		"request_id", "created_at", "started_at", "finished_at",
+	}
+
+	finishedStatuses = []string{dto.JobDone, dto.JobFailed, dto.JobCancelled}
+)
+
+type Repository struct {
+	logger log.Logger
+
+	db *sqlx.DB
+}
+
+func New(
+	db *sqlx.DB,
+	logger log.Logger,
+) Repository {
+
+	return Repository{
+		logger: logger.WithField("unit", "job"),
+		db:     db,
+	}
+}
+
+func (r Repository) Create(
+	ctx context.Context,
+	data dto.CreateJob,
+) (int, error) {
+
+	query, args, err := sq.
+		Insert("job").
+		Columns("type", "payload", "actor_id", "actor", "actor_role", "request_id").
+		Values(
+			data.Type, string(data.Payload),
+			data.ActorId, data.Actor, data.ActorRole, data.RequestId,
+		).
+		Suffix("RETURNING id").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"type":       data.Type,
+			"actor":      data.Actor,
+			"request_id": data.RequestId,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return 0, r.errInternalBuildSql(err)
+	}
+
+	var jobId int
+
+	if err := r.db.GetContext(ctx, &jobId, query, args...); err != nil {
+		logger.Warnf("unknown error on creating job: %s", err)
+
+		return 0, r.errInternalCreateJob(err)
+	}
+
+	return jobId, nil
+}
+
+func (r Repository) GetById(
+	ctx context.Context,
+	id int,
+) (dto.Job, error) {
+
+	query, args, err := sq.
+		Select(jobColumns...).
+		From("job").
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"id": id,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return dto.Job{}, r.errInternalBuildSql(err)
+	}
+
+	job := dto.Job{}
+
+	if err := r.db.GetContext(ctx, &job, query, args...); err != nil {
+		if errpkg.Is(err, sql.ErrNoRows) {
+			logger.Warnf("job not found: %s", err)
+
+			return dto.Job{}, r.errNotFound("job", err)
+		}
+
+		logger.Warnf("unknown error on getting job: %s", err)
+
+		return dto.Job{}, r.errInternalGetJob(err)
+	}
+
+	return job, nil
+}
+
+func (r Repository) GetFile(
+	ctx context.Context,
+	id int,
+) (dto.JobFile, error) {
+
+	query, args, err := sq.
+		Select("file_name", "content_type", "file").
+		From("job").
+		Where(sq.Eq{"id": id}).
+		Where(sq.NotEq{"file": nil}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"id": id,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return dto.JobFile{}, r.errInternalBuildSql(err)
+	}
+
+	file := dto.JobFile{}
+
+	if err := r.db.GetContext(ctx, &file, query, args...); err != nil {
+		if errpkg.Is(err, sql.ErrNoRows) {
+			logger.Warnf("job file not found: %s", err)
+
+			return dto.JobFile{}, r.errNotFound("job file", err)
+		}
+
+		logger.Warnf("unknown error on getting job file: %s", err)
+
+		return dto.JobFile{}, r.errInternalGetJob(err)
+	}
+
+	return file, nil
+}
+
+// Dequeue захватывает самую старую задачу в очереди. Задачи, чей обработчик
+// перестал отправлять heartbeat раньше staleBefore, считаются брошенными и
+// захватываются повторно, пока не исчерпаны maxAttempts попыток (ноль снимает
+// ограничение). SKIP LOCKED не даёт двум обработчикам взять одну задачу, а
+// номер попытки отсекает обновления от обработчика, у которого её перехватили
+func (r Repository) Dequeue(
+	ctx context.Context,
+	staleBefore time.Time,
+	maxAttempts int,
+) (dto.Job, error) {
+
+	stale := sq.And{
+		sq.Eq{"status": dto.JobRunning},
+		sq.Lt{"heartbeat_at": staleBefore},
+	}
+
+	if maxAttempts > 0 {
+		stale = append(stale, sq.Lt{"attempt": maxAttempts})
+	}
+
+	next := sq.
+		Select("id").
+		From("job").
+		Where(sq.Or{
+			sq.Eq{"status": dto.JobPending},
+			stale,
+		}).
+		OrderBy("id").
+		Limit(1).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	query, args, err := sq.
+		Update("job").
+		Set("status", dto.JobRunning).
+		Set("attempt", sq.Expr("attempt + 1")).
+		Set("started_at", sq.Expr("now()")).
+		Set("heartbeat_at", sq.Expr("now()")).
+		Where(sq.Expr("id = (?)", next)).
+		Suffix("RETURNING " + strings.Join(jobColumns, ", ")).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"stale_before": staleBefore,
+			"max_attempts": maxAttempts,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return dto.Job{}, r.errInternalBuildSql(err)
+	}
+
+	job := dto.Job{}
+
+	if err := r.db.GetContext(ctx, &job, query, args...); err != nil {
+		if errpkg.Is(err, sql.ErrNoRows) {
+			return dto.Job{}, r.errNotFound("job", err)
+		}
+
+		logger.Warnf("unknown error on dequeuing job: %s", err)
+
+		return dto.Job{}, r.errInternalDequeueJob(err)
+	}
+
+	return job, nil
+}
+
+// Heartbeat продлевает захват задачи и сохраняет прогресс. Возвращает true,
+// если задачу нужно прервать: её отменили, или она перехвачена другой попыткой
+func (r Repository) Heartbeat(
+	ctx context.Context,
+	id int,
+	attempt int,
+	progress int,
+) (bool, error) {
+
+	query, args, err := sq.
+		Update("job").
+		Set("heartbeat_at", sq.Expr("now()")).
+		Set("progress", progress).
+		Where(sq.Eq{"id": id, "attempt": attempt, "status": dto.JobRunning}).
+		Suffix("RETURNING cancel_requested").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"id":       id,
+			"attempt":  attempt,
+			"progress": progress,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return false, r.errInternalBuildSql(err)
+	}
+
+	var cancelRequested bool
+
+	if err := r.db.GetContext(ctx, &cancelRequested, query, args...); err != nil {
+		if errpkg.Is(err, sql.ErrNoRows) {
+			logger.Warnf("job is no longer running: %s", err)
+
+			return true, nil
+		}
+
+		logger.Warnf("unknown error on updating job heartbeat: %s", err)
+
+		return false, r.errInternalUpdateJob(err)
+	}
+
+	return cancelRequested, nil
+}
+
+func (r Repository) Finish(
+	ctx context.Context,
+	data dto.FinishJob,
+) error {
+
+	builder := sq.
+		Update("job").
+		Set("status", data.Status).
+		Set("result", r.jsonValue(data.Result)).
+		Set("error", data.Error).
+		Set("progress", data.Progress).
+		Set("finished_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": data.ID, "attempt": data.Attempt, "status": dto.JobRunning})
+
+	if data.File != nil {
+		builder = builder.
+			Set("file", data.File.Data).
+			Set("file_name", data.File.Name).
+			Set("content_type", data.File.ContentType)
+	}
+
+	query, args, err := builder.
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"id":       data.ID,
+			"attempt":  data.Attempt,
+			"status":   data.Status,
+			"progress": data.Progress,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return r.errInternalBuildSql(err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		logger.Warnf("unknown error on finishing job: %s", err)
+
+		return r.errInternalUpdateJob(err)
+	}
+
+	// Задачу перехватила другая попытка, её результат не сохраняется
+	if finished, err := result.RowsAffected(); err == nil && finished == 0 {
+		logger.Warn("job is no longer owned by this attempt")
+	}
+
+	return nil
+}
+
+// Requeue возвращает прерванную остановкой сервиса задачу в очередь
+func (r Repository) Requeue(
+	ctx context.Context,
+	id int,
+	attempt int,
+) error {
+
+	query, args, err := sq.
+		Update("job").
+		Set("status", dto.JobPending).
+		Set("progress", 0).
+		Set("started_at", nil).
+		Set("heartbeat_at", nil).
+		Where(sq.Eq{"id": id, "attempt": attempt, "status": dto.JobRunning}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"id":      id,
+			"attempt": attempt,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return r.errInternalBuildSql(err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		logger.Warnf("unknown error on requeuing job: %s", err)
+
+		return r.errInternalUpdateJob(err)
+	}
+
+	return nil
+}
+
+// Cancel сразу отменяет задачу из очереди, а выполняющейся — выставляет
+// флаг, который обработчик заметит при следующем heartbeat
+func (r Repository) Cancel(
+	ctx context.Context,
+	id int,
+) (dto.Job, error) {
+
+	query, args, err := sq.
+		Update("job").
+		Set("cancel_requested", true).
+		Set("status", sq.Expr("CASE WHEN status = ? THEN ? ELSE status END", dto.JobPending, dto.JobCancelled)).
+		Set("finished_at", sq.Expr("CASE WHEN status = ? THEN now() ELSE finished_at END", dto.JobPending)).
+		Where(sq.Eq{"id": id, "status": []string{dto.JobPending, dto.JobRunning}}).
+		Suffix("RETURNING " + strings.Join(jobColumns, ", ")).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"id": id,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return dto.Job{}, r.errInternalBuildSql(err)
+	}
+
+	job := dto.Job{}
+
+	if err := r.db.GetContext(ctx, &job, query, args...); err != nil {
+		if !errpkg.Is(err, sql.ErrNoRows) {
+			logger.Warnf("unknown error on cancelling job: %s", err)
+
+			return dto.Job{}, r.errInternalUpdateJob(err)
+		}
+
+		if _, err := r.GetById(ctx, id); err != nil {
+			return dto.Job{}, err
+		}
+
+		logger.Warn("job already finished")
+
+		return dto.Job{}, r.errJobFinished()
+	}
+
+	return job, nil
+}
+
+// FailExhausted завершает с ошибкой брошенные задачи, у которых исчерпаны
+// попытки: такая задача, скорее всего, роняет обработчик при каждом запуске
+func (r Repository) FailExhausted(
+	ctx context.Context,
+	staleBefore time.Time,
+	maxAttempts int,
+) (int64, error) {
+
+	query, args, err := sq.
+		Update("job").
+		Set("status", dto.JobFailed).
+		Set("error", "max attempts exceeded").
+		Set("finished_at", sq.Expr("now()")).
+		Where(sq.Eq{"status": dto.JobRunning}).
+		Where(sq.Lt{"heartbeat_at": staleBefore}).
+		Where(sq.GtOrEq{"attempt": maxAttempts}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"stale_before": staleBefore,
+			"max_attempts": maxAttempts,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return 0, r.errInternalBuildSql(err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		logger.Warnf("unknown error on failing exhausted jobs: %s", err)
+
+		return 0, r.errInternalUpdateJob(err)
+	}
+
+	failed, err := result.RowsAffected()
+	if err != nil {
+		logger.Warnf("unknown error on failing exhausted jobs: %s", err)
+
+		return 0, r.errInternalUpdateJob(err)
+	}
+
+	return failed, nil
+}
+
+func (r Repository) Purge(
+	ctx context.Context,
+	finishedBefore time.Time,
+) (int64, error) {
+
+	query, args, err := sq.
+		Delete("job").
+		Where(sq.Eq{"status": finishedStatuses}).
+		Where(sq.Lt{"finished_at": finishedBefore}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"finished_before": finishedBefore,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return 0, r.errInternalBuildSql(err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		logger.Warnf("unknown error on purging jobs: %s", err)
+
+		return 0, r.errInternalPurgeJobs(err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		logger.Warnf("unknown error on purging jobs: %s", err)
+
+		return 0, r.errInternalPurgeJobs(err)
+	}
+
+	return purged, nil
+}
+
+func (r Repository) jsonValue(
+	data []byte,
+) any {
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	return string(data)
+}
@@ -0,0 +1,397 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+	"time"
+)
+
+type JobTestSuite struct {
+	suite.Suite
+
+	// Вспомогательные параметры
+	ctx        context.Context
+	logger     log.Logger
+	repository Repository
+
+	// Входные параметры
+	create      dto.CreateJob
+	staleBefore time.Time
+	maxAttempts int
+
+	// Служебные параметры
+	db   *sqlx.DB
+	mock sqlmock.Sqlmock
+}
+
+func convertArgs(args []any) []driver.Value {
+	converted := make([]driver.Value, len(args))
+
+	for i, arg := range args {
+		converted[i] = arg
+	}
+
+	return converted
+}
+
+func TestSuiteJob(t *testing.T) {
+	suite.Run(t, &JobTestSuite{})
+}
+
+func (s *JobTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.logger = log.NewNullLogger()
+}
+
+func (s *JobTestSuite) BeforeTest(_, _ string) {
+	db, mock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+	)
+	s.NoError(err)
+
+	s.setupDatabase(db).setupMock(mock).setupRepository()
+
+	// Входные значения по-умолчанию
+	s.setupCreate(1, "admin").
+		setupStaleBefore(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 3)
+}
+
+func (s *JobTestSuite) setupDatabase(
+	db *sql.DB,
+) *JobTestSuite {
+
+	wrappedDb := sqlx.NewDb(db, "sqlmock")
+
+	s.db = wrappedDb
+
+	return s
+}
+
+func (s *JobTestSuite) setupMock(
+	mock sqlmock.Sqlmock,
+) *JobTestSuite {
+
+	s.mock = mock
+
+	return s
+}
+
+func (s *JobTestSuite) setupRepository() *JobTestSuite {
+	s.repository = New(s.db, s.logger)
+
+	return s
+}
+
+func (s *JobTestSuite) setupCreate(
+	actorId int,
+	actor string,
+) *JobTestSuite {
+
+	s.create = dto.CreateJob{
+		Type:      dto.JobProductExport,
+		Payload:   json.RawMessage(`{"format":"csv"}`),
+		ActorId:   &actorId,
+		Actor:     actor,
+		ActorRole: dto.RoleAdmin,
+		RequestId: "request",
+	}
+
+	return s
+}
+
+func (s *JobTestSuite) setupStaleBefore(
+	staleBefore time.Time,
+	maxAttempts int,
+) {
+
+	s.staleBefore = staleBefore
+	s.maxAttempts = maxAttempts
+}
+
+func (s *JobTestSuite) jobRows(
+	status string,
+) *sqlmock.Rows {
+
+	return sqlmock.
+		NewRows(jobColumns).
+		AddRow(
+			1, dto.JobProductExport, status, []byte(`{"format":"csv"}`), nil, "", 0, 1,
+			"", false, 1, "admin", dto.RoleAdmin,
+			"request", s.staleBefore, nil, nil,
+		)
+}
+
+func (s *JobTestSuite) dequeueQuery() (string, []any) {
+	next := sq.
+		Select("id").
+		From("job").
+		Where(sq.Or{
+			sq.Eq{"status": dto.JobPending},
+			sq.And{
+				sq.Eq{"status": dto.JobRunning},
+				sq.Lt{"heartbeat_at": s.staleBefore},
+				sq.Lt{"attempt": s.maxAttempts},
+			},
+		}).
+		OrderBy("id").
+		Limit(1).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	query, args, err := sq.
+		Update("job").
+		Set("status", dto.JobRunning).
+		Set("attempt", sq.Expr("attempt + 1")).
+		Set("started_at", sq.Expr("now()")).
+		Set("heartbeat_at", sq.Expr("now()")).
+		Where(sq.Expr("id = (?)", next)).
+		Suffix("RETURNING " + strings.Join(jobColumns, ", ")).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	s.NoError(err)
+
+	return query, args
+}
+
+func (s *JobTestSuite) cancelQuery() (string, []any) {
+	query, args, err := sq.
+		Update("job").
+		Set("cancel_requested", true).
+		Set("status", sq.Expr("CASE WHEN status = ? THEN ? ELSE status END", dto.JobPending, dto.JobCancelled)).
+		Set("finished_at", sq.Expr("CASE WHEN status = ? THEN now() ELSE finished_at END", dto.JobPending)).
+		Where(sq.Eq{"id": 1, "status": []string{dto.JobPending, dto.JobRunning}}).
+		Suffix("RETURNING " + strings.Join(jobColumns, ", ")).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	s.NoError(err)
+
+	return query, args
+}
+
+func (s *JobTestSuite) TestCreateSuccessful() {
+	const (
+		expectedId = 1
+	)
+
+	query, args, err := sq.
+		Insert("job").
+		Columns("type", "payload", "actor_id", "actor", "actor_role", "request_id").
+		Values(
+			s.create.Type, string(s.create.Payload),
+			s.create.ActorId, s.create.Actor, s.create.ActorRole, s.create.RequestId,
+		).
+		Suffix("RETURNING id").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	s.NoError(err)
+
+	s.mock.
+		ExpectQuery(query).
+		WithArgs(convertArgs(args)...).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(expectedId))
+
+	id, err := s.repository.Create(s.ctx, s.create)
+
+	s.NoError(err)
+	s.Equal(expectedId, id)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *JobTestSuite) TestDequeueSuccessful() {
+	query, args := s.dequeueQuery()
+
+	s.mock.
+		ExpectQuery(query).
+		WithArgs(convertArgs(args)...).
+		WillReturnRows(s.jobRows(dto.JobRunning))
+
+	job, err := s.repository.Dequeue(s.ctx, s.staleBefore, s.maxAttempts)
+
+	s.NoError(err)
+	s.Equal(1, job.ID)
+	s.Equal(dto.JobRunning, job.Status)
+	s.Equal(1, job.Attempt)
+	s.Equal(json.RawMessage(`{"format":"csv"}`), job.Payload)
+	s.Equal(dto.RoleAdmin, job.ActorRole)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *JobTestSuite) TestDequeueFailed() {
+	testCases := []struct {
+		testName      string
+		queryError    error
+		expectedError string
+	}{
+		{
+			testName:      "Empty queue",
+			queryError:    sql.ErrNoRows,
+			expectedError: "job not found",
+		},
+		{
+			testName:      "Unknown error",
+			queryError:    errors.New("unknown error"),
+			expectedError: "unknown error on dequeuing job",
+		},
+	}
+
+	for _, testCase := range testCases {
+		s.Run(testCase.testName, func() {
+			query, args := s.dequeueQuery()
+
+			s.mock.
+				ExpectQuery(query).
+				WithArgs(convertArgs(args)...).
+				WillReturnError(testCase.queryError)
+
+			_, err := s.repository.Dequeue(s.ctx, s.staleBefore, s.maxAttempts)
+
+			s.EqualError(err, testCase.expectedError)
+			s.NoError(s.mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *JobTestSuite) TestCancelSuccessful() {
+	query, args := s.cancelQuery()
+
+	s.mock.
+		ExpectQuery(query).
+		WithArgs(convertArgs(args)...).
+		WillReturnRows(s.jobRows(dto.JobCancelled))
+
+	job, err := s.repository.Cancel(s.ctx, 1)
+
+	s.NoError(err)
+	s.Equal(dto.JobCancelled, job.Status)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *JobTestSuite) TestCancelFinishedFailed() {
+	const (
+		expectedError = "job already finished"
+	)
+
+	query, args := s.cancelQuery()
+
+	s.mock.
+		ExpectQuery(query).
+		WithArgs(convertArgs(args)...).
+		WillReturnError(sql.ErrNoRows)
+
+	query, args, err := sq.
+		Select(jobColumns...).
+		From("job").
+		Where(sq.Eq{"id": 1}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	s.NoError(err)
+
+	s.mock.
+		ExpectQuery(query).
+		WithArgs(convertArgs(args)...).
+		WillReturnRows(s.jobRows(dto.JobDone))
+
+	_, err = s.repository.Cancel(s.ctx, 1)
+
+	s.EqualError(err, expectedError)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *JobTestSuite) TestHeartbeatNotRunningSuccessful() {
+	query, args, err := sq.
+		Update("job").
+		Set("heartbeat_at", sq.Expr("now()")).
+		Set("progress", 10).
+		Where(sq.Eq{"id": 1, "attempt": 2, "status": dto.JobRunning}).
+		Suffix("RETURNING cancel_requested").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	s.NoError(err)
+
+	s.mock.
+		ExpectQuery(query).
+		WithArgs(convertArgs(args)...).
+		WillReturnError(sql.ErrNoRows)
+
+	cancelRequested, err := s.repository.Heartbeat(s.ctx, 1, 2, 10)
+
+	s.NoError(err)
+	s.True(cancelRequested)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *JobTestSuite) TestFinishStaleAttemptSuccessful() {
+	finish := dto.FinishJob{
+		ID:       1,
+		Attempt:  1,
+		Status:   dto.JobDone,
+		Progress: 10,
+	}
+
+	query, args, err := sq.
+		Update("job").
+		Set("status", finish.Status).
+		Set("result", nil).
+		Set("error", finish.Error).
+		Set("progress", finish.Progress).
+		Set("finished_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": finish.ID, "attempt": finish.Attempt, "status": dto.JobRunning}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	s.NoError(err)
+
+	// Задачу перехватила другая попытка: результат отбрасывается без ошибки
+	s.mock.
+		ExpectExec(query).
+		WithArgs(convertArgs(args)...).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	s.NoError(s.repository.Finish(s.ctx, finish))
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *JobTestSuite) TestFailExhaustedSuccessful() {
+	const (
+		expectedFailed = 2
+	)
+
+	query, args, err := sq.
+		Update("job").
+		Set("status", dto.JobFailed).
+		Set("error", "max attempts exceeded").
+		Set("finished_at", sq.Expr("now()")).
+		Where(sq.Eq{"status": dto.JobRunning}).
+		Where(sq.Lt{"heartbeat_at": s.staleBefore}).
+		Where(sq.GtOrEq{"attempt": s.maxAttempts}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	s.NoError(err)
+
+	s.mock.
+		ExpectExec(query).
+		WithArgs(convertArgs(args)...).
+		WillReturnResult(sqlmock.NewResult(0, expectedFailed))
+
+	failed, err := s.repository.FailExhausted(s.ctx, s.staleBefore, s.maxAttempts)
+
+	s.NoError(err)
+	s.Equal(int64(expectedFailed), failed)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
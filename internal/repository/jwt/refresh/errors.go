@@ -32,6 +32,17 @@ func (r Repository) errInternalDeleteRefresh(
 	return errors.ErrInternal("creating", "refresh token", err)
 }
 
+func (r Repository) errInternalUpdateRefresh(
+	err error,
+) error {
+
+	return errors.ErrInternal("updating", "refresh token", err)
+}
+
+func (r Repository) errRefreshUsed() error {
+	return errors.ErrUsed("refresh token")
+}
+
 func (r Repository) errRefreshAlreadyExists(
 	err error,
 ) error {
@@ -6,6 +6,7 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	pgerr "github.com/jackc/pgerrcode"
 	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/transaction"
 	errpkg "github.com/jackvonhouse/product-catalog/pkg/errors"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 	"github.com/jmoiron/sqlx"
@@ -16,7 +17,8 @@ import (
 type Repository struct {
 	logger log.Logger
 
-	db *sqlx.DB
+	db          *sqlx.DB
+	transaction transaction.Manager
 }
 
 func New(
@@ -25,8 +27,9 @@ func New(
 ) Repository {
 
 	return Repository{
-		logger: logger.WithField("unit", "refresh-token"),
-		db:     db,
+		logger:      logger.WithField("unit", "refresh-token"),
+		db:          db,
+		transaction: transaction.New(db, logger),
 	}
 }
 
@@ -46,8 +49,14 @@ func (r Repository) Create(
 
 	query, args, err := sq.
 		Insert("refresh").
-		Columns("token", "user_id", "expire_at").
-		Values(refresh.Token, user.ID, expireAt).
+		Columns(
+			"token", "user_id", "family_id", "parent_id", "expire_at",
+			"device_name", "user_agent", "ip",
+		).
+		Values(
+			refresh.Token, user.ID, refresh.FamilyId, refresh.ParentId, expireAt,
+			refresh.Device.Name, refresh.Device.UserAgent, refresh.Device.IP,
+		).
 		Suffix("RETURNING id").
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
@@ -60,8 +69,11 @@ func (r Repository) Create(
 				"username": user.Username,
 			},
 			"refresh": map[string]any{
-				"id":    refresh.ID,
-				"token": refresh.Token,
+				"id":        refresh.ID,
+				"token":     refresh.Token,
+				"family_id": refresh.FamilyId,
+				"parent_id": refresh.ParentId,
+				"device":    refresh.Device,
 			},
 		},
 	})
@@ -74,7 +86,7 @@ func (r Repository) Create(
 
 	var refreshTokenId int
 
-	if err := r.db.GetContext(ctx, &refreshTokenId, query, args...); err != nil {
+	if err := sqlx.GetContext(ctx, r.transaction.Executor(ctx), &refreshTokenId, query, args...); err != nil {
 		if e, ok := err.(*pq.Error); ok {
 			switch e.Code {
 
@@ -136,7 +148,7 @@ func (r Repository) GetById(
 
 	var refreshToken dto.RefreshToken
 
-	if err := r.db.GetContext(ctx, &refreshToken, query, args...); err != nil {
+	if err := sqlx.GetContext(ctx, r.transaction.Executor(ctx), &refreshToken, query, args...); err != nil {
 		if !errpkg.Is(err, sql.ErrNoRows) {
 			logger.Warnf("unknown error on getting refresh token: %s", err)
 
@@ -157,6 +169,248 @@ func (r Repository) GetById(
 	return refreshToken, nil
 }
 
+// GetSessions возвращает активные сессии пользователя: последний
+// неиспользованный refresh-токен каждого семейства
+func (r Repository) GetSessions(
+	ctx context.Context,
+	userId int,
+) ([]dto.Session, error) {
+
+	if err := r.deleteExpired(ctx); err != nil {
+		r.logger.Warnf("can't delete expired tokens: %s", err)
+	}
+
+	query, args, err := sq.
+		Select("id", "device_name", "user_agent", "ip", "last_used_at").
+		From("refresh").
+		Where(sq.Eq{"user_id": userId, "used_at": nil}).
+		OrderBy("last_used_at DESC", "id DESC").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"user": map[string]any{
+				"id": userId,
+			},
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return nil, r.errInternalBuildSql(err)
+	}
+
+	sessions := make([]dto.Session, 0)
+
+	if err := sqlx.SelectContext(ctx, r.transaction.Executor(ctx), &sessions, query, args...); err != nil {
+		logger.Warnf("unknown error on getting sessions: %s", err)
+
+		return nil, r.errInternalGetRefresh(err)
+	}
+
+	return sessions, nil
+}
+
+// DeleteSession удаляет семейство refresh-токена пользователя
+func (r Repository) DeleteSession(
+	ctx context.Context,
+	userId int,
+	id int,
+) error {
+
+	query, args, err := sq.
+		Delete("refresh").
+		Where(sq.Eq{"user_id": userId}).
+		Where("family_id = (SELECT family_id FROM refresh WHERE id = ? AND user_id = ?)", id, userId).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"user": map[string]any{
+				"id": userId,
+			},
+			"token": map[string]any{
+				"id": id,
+			},
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return r.errInternalBuildSql(err)
+	}
+
+	result, err := r.transaction.Executor(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		logger.Warnf("unknown error on deleting refresh token: %s", err)
+
+		return r.errInternalDeleteRefresh(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.Warnf("unknown error on deleting refresh token: %s", err)
+
+		return r.errInternalDeleteRefresh(err)
+	}
+
+	if rowsAffected == 0 {
+		logger.Warn("session not found")
+
+		return r.errNotFound("session", nil)
+	}
+
+	return nil
+}
+
+// Exists сообщает, существует ли неистёкший refresh-токен
+func (r Repository) Exists(
+	ctx context.Context,
+	id int,
+) (bool, error) {
+
+	now := time.Now().Unix()
+
+	query, args, err := sq.
+		Select("1").
+		Prefix("SELECT EXISTS (").
+		From("refresh").
+		Where(sq.Eq{"id": id}).
+		Where(sq.Gt{"expire_at": now}).
+		Suffix(")").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"token": map[string]any{
+				"id": id,
+			},
+			"now": now,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return false, r.errInternalBuildSql(err)
+	}
+
+	var exists bool
+
+	if err := sqlx.GetContext(ctx, r.transaction.Executor(ctx), &exists, query, args...); err != nil {
+		logger.Warnf("unknown error on getting refresh token: %s", err)
+
+		return false, r.errInternalGetRefresh(err)
+	}
+
+	return exists, nil
+}
+
+// MarkUsed помечает refresh-токен использованным. Токен помечается только
+// один раз: если он уже использован, возвращается ошибка
+func (r Repository) MarkUsed(
+	ctx context.Context,
+	id int,
+) error {
+
+	now := time.Now().Unix()
+
+	query, args, err := sq.
+		Update("refresh").
+		Set("used_at", now).
+		Where(sq.Eq{"id": id, "used_at": nil}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"token": map[string]any{
+				"id": id,
+			},
+			"now": now,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return r.errInternalBuildSql(err)
+	}
+
+	result, err := r.transaction.Executor(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		logger.Warnf("unknown error on updating refresh token: %s", err)
+
+		return r.errInternalUpdateRefresh(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.Warnf("unknown error on updating refresh token: %s", err)
+
+		return r.errInternalUpdateRefresh(err)
+	}
+
+	if rowsAffected == 0 {
+		logger.Warn("refresh token already used")
+
+		return r.errRefreshUsed()
+	}
+
+	return nil
+}
+
+// DeleteByFamilyId удаляет все refresh-токены семейства
+func (r Repository) DeleteByFamilyId(
+	ctx context.Context,
+	familyId string,
+) (int64, error) {
+
+	query, args, err := sq.
+		Delete("refresh").
+		Where(sq.Eq{"family_id": familyId}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"family_id": familyId,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return 0, r.errInternalBuildSql(err)
+	}
+
+	result, err := r.transaction.Executor(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		logger.Warnf("unknown error on deleting refresh token: %s", err)
+
+		return 0, r.errInternalDeleteRefresh(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.Warnf("unknown error on deleting refresh token: %s", err)
+
+		return 0, r.errInternalDeleteRefresh(err)
+	}
+
+	return rowsAffected, nil
+}
+
 func (r Repository) DeleteByUserId(
 	ctx context.Context,
 	id int,
@@ -187,7 +441,7 @@ func (r Repository) DeleteByUserId(
 		return r.errInternalBuildSql(err)
 	}
 
-	result, err := r.db.ExecContext(ctx, query, args...)
+	result, err := r.transaction.Executor(ctx).ExecContext(ctx, query, args...)
 	if err != nil {
 		logger.Warnf("unknown error on deleting refresh token: %s", err)
 
@@ -236,7 +490,7 @@ func (r Repository) deleteExpired(
 		return r.errInternalBuildSql(err)
 	}
 
-	result, err := r.db.ExecContext(ctx, query, args...)
+	result, err := r.transaction.Executor(ctx).ExecContext(ctx, query, args...)
 	if err != nil {
 		logger.Warnf("unknown error on deleting refresh token: %s", err)
 
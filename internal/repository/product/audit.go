@@ -0,0 +1,105 @@
+package product
+
+import (
+	"context"
+	"database/sql"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackvonhouse/product-catalog/internal/audit"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	errpkg "github.com/jackvonhouse/product-catalog/pkg/errors"
+	"github.com/jmoiron/sqlx"
+)
+
+type auditRepository interface {
+	Create(context.Context, *sqlx.Tx, dto.AuditRecord) error
+}
+
+func (r Repository) auditBefore(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	productId int,
+) (any, error) {
+
+	if _, ok := audit.FromContext(ctx); !ok {
+		return nil, nil
+	}
+
+	return r.snapshot(ctx, tx, productId)
+}
+
+func (r Repository) auditAfter(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	productId int,
+	before any,
+) error {
+
+	entry, ok := audit.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	var after any
+
+	if entry.Action != audit.ActionDelete {
+		product, err := r.snapshot(ctx, tx, productId)
+		if err != nil {
+			return err
+		}
+
+		after = product
+	}
+
+	record, err := entry.Record(productId, before, after)
+	if err != nil {
+		r.logger.Warnf("can't create audit record: %s", err)
+
+		return err
+	}
+
+	return r.audit.Create(ctx, tx, record)
+}
+
+func (r Repository) snapshot(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	productId int,
+) (dto.Product, error) {
+
+	query, args, err := sq.
+		Select(productColumns...).
+		From("product").
+		Where(sq.Eq{"id": productId}).
+		Suffix("FOR UPDATE").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"product_id": productId,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return dto.Product{}, r.errInternalBuildSql(err)
+	}
+
+	product := dto.Product{}
+
+	if err := tx.GetContext(ctx, &product, query, args...); err != nil {
+		if errpkg.Is(err, sql.ErrNoRows) {
+			logger.Warnf("product not found: %s", err)
+
+			return dto.Product{}, r.errNotFound("product", err)
+		}
+
+		logger.Warnf("unknown error on getting product snapshot: %s", err)
+
+		return dto.Product{}, r.errInternalGetProduct(err)
+	}
+
+	return product, nil
+}
@@ -9,6 +9,7 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	pgerr "github.com/jackc/pgerrcode"
 	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/repository/audit"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
@@ -88,7 +89,7 @@ func (s *CreateTestSuite) setupMock(
 }
 
 func (s *CreateTestSuite) setupRepository() {
-	s.repository = New(s.db, s.logger)
+	s.repository = New(s.db, audit.New(s.db, s.logger), s.logger)
 }
 
 func (s *CreateTestSuite) setupCreate(
@@ -96,7 +97,12 @@ func (s *CreateTestSuite) setupCreate(
 ) *CreateTestSuite {
 
 	s.create = dto.CreateProduct{
-		Name: name,
+		Name:        name,
+		Description: "Описание",
+		Price:       10000,
+		Currency:    "RUB",
+		SKU:         "SKU-1",
+		Stock:       10,
 	}
 
 	return s
@@ -136,8 +142,12 @@ func (s *CreateTestSuite) TestSuccessful() {
 	{
 		query, args, err := sq.
 			Insert("product").
-			Columns("name").
-			Values(s.create.Name).
+			Columns("name", "description", "price", "currency", "sku", "stock").
+			Values(
+				s.create.Name, s.create.Description,
+				s.create.Price, s.create.Currency,
+				s.create.SKU, s.create.Stock,
+			).
 			Suffix("RETURNING id").
 			PlaceholderFormat(sq.Dollar).
 			ToSql()
@@ -179,7 +189,74 @@ func (s *CreateTestSuite) TestSuccessful() {
 		s.mock.ExpectCommit().WillReturnError(nil)
 	}
 
-	productId, err := s.repository.Create(s.ctx, s.create, s.category)
+	productId, err := s.repository.Create(s.ctx, s.create, []dto.Category{s.category})
+
+	s.NoError(err)
+	s.Equal(1, productId)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *CreateTestSuite) TestWithSeveralCategoriesSuccessful() {
+	categories := []dto.Category{
+		s.category,
+		{ID: 2, Name: "Другая категория"},
+	}
+
+	{
+		s.mock.ExpectBegin().WillReturnError(nil)
+	}
+
+	{
+		query, args, err := sq.
+			Insert("product").
+			Columns("name", "description", "price", "currency", "sku", "stock").
+			Values(
+				s.create.Name, s.create.Description,
+				s.create.Price, s.create.Currency,
+				s.create.SKU, s.create.Stock,
+			).
+			Suffix("RETURNING id").
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnRows(
+				s.mock.
+					NewRows([]string{"id"}).
+					AddRow(s.product.ID),
+			)
+	}
+
+	for _, category := range categories {
+		query, args, err := sq.
+			Insert("product_of_category").
+			Columns("product_id", "category_id").
+			Values(s.product.ID, category.ID).
+			Suffix("RETURNING product_id").
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnRows(
+				s.mock.
+					NewRows([]string{"id"}).
+					AddRow(s.product.ID),
+			)
+	}
+
+	{
+		s.mock.ExpectCommit().WillReturnError(nil)
+	}
+
+	productId, err := s.repository.Create(s.ctx, s.create, categories)
 
 	s.NoError(err)
 	s.Equal(1, productId)
@@ -200,7 +277,7 @@ func (s *CreateTestSuite) TestBeginFailed() {
 			)
 	}
 
-	productId, err := s.repository.Create(s.ctx, s.create, s.category)
+	productId, err := s.repository.Create(s.ctx, s.create, []dto.Category{s.category})
 
 	s.NotNil(err)
 	s.Equal(err.Error(), expectedErrorMsg)
@@ -223,8 +300,12 @@ func (s *CreateTestSuite) TestCommitFailed() {
 	{
 		query, args, err := sq.
 			Insert("product").
-			Columns("name").
-			Values(s.create.Name).
+			Columns("name", "description", "price", "currency", "sku", "stock").
+			Values(
+				s.create.Name, s.create.Description,
+				s.create.Price, s.create.Currency,
+				s.create.SKU, s.create.Stock,
+			).
 			Suffix("RETURNING id").
 			PlaceholderFormat(sq.Dollar).
 			ToSql()
@@ -268,7 +349,7 @@ func (s *CreateTestSuite) TestCommitFailed() {
 		)
 	}
 
-	productId, err := s.repository.Create(s.ctx, s.create, s.category)
+	productId, err := s.repository.Create(s.ctx, s.create, []dto.Category{s.category})
 
 	s.NotNil(err)
 	s.Equal(err.Error(), expectedErrorMsg)
@@ -293,8 +374,12 @@ func (s *CreateTestSuite) TestRollbackFailed() {
 	{
 		query, args, err := sq.
 			Insert("product").
-			Columns("name").
-			Values(s.create.Name).
+			Columns("name", "description", "price", "currency", "sku", "stock").
+			Values(
+				s.create.Name, s.create.Description,
+				s.create.Price, s.create.Currency,
+				s.create.SKU, s.create.Stock,
+			).
 			Suffix("RETURNING id").
 			PlaceholderFormat(sq.Dollar).
 			ToSql()
@@ -314,7 +399,7 @@ func (s *CreateTestSuite) TestRollbackFailed() {
 			)
 	}
 
-	productId, err := s.repository.Create(s.ctx, s.create, s.category)
+	productId, err := s.repository.Create(s.ctx, s.create, []dto.Category{s.category})
 
 	s.NotNil(err)
 	s.Equal(err.Error(), expectedErrorMsg)
@@ -326,8 +411,10 @@ func (s *CreateTestSuite) TestCreateProductFailed() {
 	s.product.ID = 0
 
 	const (
-		expectedErrorMsg              = "unknown error on creating product"
-		expectedAlreadyExistsErrorMsg = "product already exists"
+		expectedErrorMsg                 = "unknown error on creating product"
+		expectedAlreadyExistsErrorMsg    = "product already exists"
+		expectedSkuAlreadyExistsErrorMsg = "product with sku already exists"
+		expectedInvalidErrorMsg          = "invalid product data"
 	)
 
 	testCases := []struct {
@@ -340,6 +427,16 @@ func (s *CreateTestSuite) TestCreateProductFailed() {
 			expectedQueryError:    &pq.Error{Code: pgerr.UniqueViolation},
 			expectedQueryErrorMsg: expectedAlreadyExistsErrorMsg,
 		},
+		{
+			testName:              "Sku already exists",
+			expectedQueryError:    &pq.Error{Code: pgerr.UniqueViolation, Constraint: productSkuConstraint},
+			expectedQueryErrorMsg: expectedSkuAlreadyExistsErrorMsg,
+		},
+		{
+			testName:              "Check violation",
+			expectedQueryError:    &pq.Error{Code: pgerr.CheckViolation},
+			expectedQueryErrorMsg: expectedInvalidErrorMsg,
+		},
 		{
 			testName:              "Unknown database error",
 			expectedQueryError:    &pq.Error{Code: expectedErrorMsg},
@@ -362,8 +459,12 @@ func (s *CreateTestSuite) TestCreateProductFailed() {
 			{
 				query, args, err := sq.
 					Insert("product").
-					Columns("name").
-					Values(s.create.Name).
+					Columns("name", "description", "price", "currency", "sku", "stock").
+					Values(
+						s.create.Name, s.create.Description,
+						s.create.Price, s.create.Currency,
+						s.create.SKU, s.create.Stock,
+					).
 					Suffix("RETURNING id").
 					PlaceholderFormat(sq.Dollar).
 					ToSql()
@@ -382,7 +483,7 @@ func (s *CreateTestSuite) TestCreateProductFailed() {
 				s.mock.ExpectRollback().WillReturnError(nil)
 			}
 
-			productId, err := s.repository.Create(s.ctx, s.create, s.category)
+			productId, err := s.repository.Create(s.ctx, s.create, []dto.Category{s.category})
 
 			s.NotNil(err)
 			s.Equal(err.Error(), testCase.expectedQueryErrorMsg)
@@ -443,8 +544,12 @@ func (s *CreateTestSuite) TestFailedToAttachProductToCategory() {
 			{
 				query, args, err := sq.
 					Insert("product").
-					Columns("name").
-					Values(s.create.Name).
+					Columns("name", "description", "price", "currency", "sku", "stock").
+					Values(
+						s.create.Name, s.create.Description,
+						s.create.Price, s.create.Currency,
+						s.create.SKU, s.create.Stock,
+					).
 					Suffix("RETURNING id").
 					PlaceholderFormat(sq.Dollar).
 					ToSql()
@@ -483,7 +588,7 @@ func (s *CreateTestSuite) TestFailedToAttachProductToCategory() {
 				s.mock.ExpectRollback().WillReturnError(nil)
 			}
 
-			productId, err := s.repository.Create(s.ctx, s.create, s.category)
+			productId, err := s.repository.Create(s.ctx, s.create, []dto.Category{s.category})
 
 			s.NotNil(err)
 			s.Equal(err.Error(), testCase.expectedErrorMsg)
@@ -508,8 +613,12 @@ func (s *CreateTestSuite) TestFailedRollbackAfterAttachProductToCategory() {
 	{
 		query, args, err := sq.
 			Insert("product").
-			Columns("name").
-			Values(s.create.Name).
+			Columns("name", "description", "price", "currency", "sku", "stock").
+			Values(
+				s.create.Name, s.create.Description,
+				s.create.Price, s.create.Currency,
+				s.create.SKU, s.create.Stock,
+			).
 			Suffix("RETURNING id").
 			PlaceholderFormat(sq.Dollar).
 			ToSql()
@@ -552,7 +661,7 @@ func (s *CreateTestSuite) TestFailedRollbackAfterAttachProductToCategory() {
 			)
 	}
 
-	productId, err := s.repository.Create(s.ctx, s.create, s.category)
+	productId, err := s.repository.Create(s.ctx, s.create, []dto.Category{s.category})
 
 	s.NotNil(err)
 	s.Equal(err.Error(), errUnknownMsg)
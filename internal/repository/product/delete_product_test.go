@@ -7,6 +7,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/repository/audit"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/suite"
@@ -71,7 +72,7 @@ func (s *DeleteTestSuite) setupMock(
 }
 
 func (s *DeleteTestSuite) setupRepository() {
-	s.repository = New(s.db, s.logger)
+	s.repository = New(s.db, audit.New(s.db, s.logger), s.logger)
 }
 
 func (s *DeleteTestSuite) setupProduct(
@@ -86,10 +87,17 @@ func (s *DeleteTestSuite) setupProduct(
 }
 
 func (s *DeleteTestSuite) TestSuccessful() {
+	{
+		s.mock.ExpectBegin().WillReturnError(nil)
+	}
+
 	{
 		query, args, err := sq.
-			Delete("product").
-			Where(sq.Eq{"id": s.product.ID}).
+			Update("product").
+			Set("deleted_at", sq.Expr("now()")).
+			Set("version", sq.Expr("version + 1")).
+			Set("updated_at", sq.Expr("now()")).
+			Where(sq.Eq{"id": s.product.ID, "deleted_at": nil}).
 			Suffix("RETURNING id").
 			PlaceholderFormat(sq.Dollar).
 			ToSql()
@@ -106,7 +114,11 @@ func (s *DeleteTestSuite) TestSuccessful() {
 			)
 	}
 
-	productId, err := s.repository.Delete(s.ctx, s.product)
+	{
+		s.mock.ExpectCommit().WillReturnError(nil)
+	}
+
+	productId, err := s.repository.Delete(s.ctx, s.product, 0)
 
 	s.NoError(err)
 	s.Equal(1, productId)
@@ -139,9 +151,14 @@ func (s *DeleteTestSuite) TestFailed() {
 
 	for _, testCase := range testCases {
 		s.Run(testCase.testName, func() {
+			s.mock.ExpectBegin().WillReturnError(nil)
+
 			query, args, err := sq.
-				Delete("product").
-				Where(sq.Eq{"id": s.product.ID}).
+				Update("product").
+				Set("deleted_at", sq.Expr("now()")).
+				Set("version", sq.Expr("version + 1")).
+				Set("updated_at", sq.Expr("now()")).
+				Where(sq.Eq{"id": s.product.ID, "deleted_at": nil}).
 				Suffix("RETURNING id").
 				PlaceholderFormat(sq.Dollar).
 				ToSql()
@@ -153,7 +170,9 @@ func (s *DeleteTestSuite) TestFailed() {
 				WithArgs(convertArgs(args)...).
 				WillReturnError(testCase.expectedError)
 
-			productId, err := s.repository.Delete(s.ctx, s.product)
+			s.mock.ExpectRollback().WillReturnError(nil)
+
+			productId, err := s.repository.Delete(s.ctx, s.product, 0)
 
 			s.NotNil(err)
 			s.Equal(err.Error(), testCase.expectedErrorMsg)
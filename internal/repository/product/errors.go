@@ -19,6 +19,20 @@ func (r Repository) errInternalGetProducts(
 	return errors.ErrInternal("getting", "products", err)
 }
 
+func (r Repository) errInternalCountProducts(
+	err error,
+) error {
+
+	return errors.ErrInternal("counting", "products", err)
+}
+
+func (r Repository) errInternalSearchProducts(
+	err error,
+) error {
+
+	return errors.ErrInternal("searching", "products", err)
+}
+
 func (r Repository) errInternalGetProduct(
 	err error,
 ) error {
@@ -33,6 +47,13 @@ func (r Repository) errInternalAttachProductToCategory(
 	return errors.ErrInternal("attaching", "product to category", err)
 }
 
+func (r Repository) errInternalDetachProductFromCategory(
+	err error,
+) error {
+
+	return errors.ErrInternal("detaching", "product from category", err)
+}
+
 func (r Repository) errInternalBuildSql(
 	err error,
 ) error {
@@ -61,6 +82,41 @@ func (r Repository) errInternalDeleteProduct(
 	return errors.ErrInternal("deleting", "product", err)
 }
 
+func (r Repository) errInternalRestoreProduct(
+	err error,
+) error {
+
+	return errors.ErrInternal("restoring", "product", err)
+}
+
+func (r Repository) errInternalPurgeProducts(
+	err error,
+) error {
+
+	return errors.ErrInternal("purging", "products", err)
+}
+
+func (r Repository) errInternalImportProducts(
+	err error,
+) error {
+
+	return errors.ErrInternal("importing", "products", err)
+}
+
+func (r Repository) errInternalExportProducts(
+	err error,
+) error {
+
+	return errors.ErrInternal("exporting", "products", err)
+}
+
+func (r Repository) errCategoryAlreadyExists(
+	err error,
+) error {
+
+	return errors.ErrAlreadyExists("category", err)
+}
+
 func (r Repository) errProductAlreadyExists(
 	err error,
 ) error {
@@ -68,6 +124,38 @@ func (r Repository) errProductAlreadyExists(
 	return errors.ErrAlreadyExists("product", err)
 }
 
+func (r Repository) errProductSkuAlreadyExists(
+	err error,
+) error {
+
+	return errors.ErrAlreadyExists("product with sku", err)
+}
+
+func (r Repository) errProductModified(
+	err error,
+) error {
+
+	return errors.ErrConflict("product", err)
+}
+
+func (r Repository) errInvalidProduct(
+	err error,
+) error {
+
+	return errors.ErrInvalid("product", err)
+}
+
+func (r Repository) errUnknownSortField(
+	field string,
+) error {
+
+	return errors.ErrUnknown("sort field", field)
+}
+
+func (r Repository) errInvalidCursor() error {
+	return errors.ErrInvalid("cursor", nil)
+}
+
 func (r Repository) errProductInCategoryAlreadyExists(
 	err error,
 ) error {
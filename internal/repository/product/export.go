@@ -0,0 +1,160 @@
+package product
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	exportCursor    = "product_export"
+	exportFetchSize = 500
+)
+
+type exportRow struct {
+	dto.Product
+
+	Categories pq.StringArray `db:"categories"`
+}
+
+// Export читает товары через серверный курсор порциями по exportFetchSize
+// и передаёт их в write по одному, не загружая выборку в память целиком
+func (r Repository) Export(
+	ctx context.Context,
+	data dto.GetProduct,
+	write func(dto.ExportProduct) error,
+) error {
+
+	rollback := func(tx *sqlx.Tx) error {
+		if err := tx.Rollback(); err != nil {
+			r.logger.Warnf("unknown error on rollback: %s", err)
+
+			return r.errInternalExportProducts(err)
+		}
+
+		return nil
+	}
+
+	commit := func(tx *sqlx.Tx) error {
+		if err := tx.Commit(); err != nil {
+			r.logger.Warnf("unknown error on commit: %s", err)
+
+			return r.errInternalExportProducts(err)
+		}
+
+		return nil
+	}
+
+	sortFields, err := r.sortFields(data.Sort)
+	if err != nil {
+		r.logger.Warn(err)
+
+		return err
+	}
+
+	filter := r.filter(data.Filter)
+	if !data.IncludeDeleted {
+		filter = append(filter, sq.Eq{"deleted_at": nil})
+	}
+
+	categories := sq.
+		Select("category.name").
+		From("product_of_category").
+		Join("category ON category.id = product_of_category.category_id").
+		Where("product_of_category.product_id = product.id").
+		Where(sq.Eq{"category.deleted_at": nil}).
+		OrderBy("category.name")
+
+	builder := sq.
+		Select(productColumns...).
+		Column(sq.Alias(sq.Expr("ARRAY(?)", categories), "categories")).
+		From("product").
+		OrderBy(r.orderBy(sortFields)...).
+		Prefix(fmt.Sprintf("DECLARE %s NO SCROLL CURSOR FOR", exportCursor))
+
+	if len(filter) > 0 {
+		builder = builder.Where(filter)
+	}
+
+	query, args, err := builder.
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"sort":            data.Sort,
+			"filter":          data.Filter,
+			"include_deleted": data.IncludeDeleted,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return r.errInternalBuildSql(err)
+	}
+
+	// Снимок на время всей выгрузки, чтобы порции были согласованы между собой
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  true,
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on starting transaction: %s", err)
+
+		return r.errInternalExportProducts(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		logger.Warnf("unknown error on declaring cursor: %s", err)
+
+		if rErr := rollback(tx); rErr != nil {
+			return rErr
+		}
+
+		return r.errInternalExportProducts(err)
+	}
+
+	fetch := fmt.Sprintf("FETCH %d FROM %s", exportFetchSize, exportCursor)
+
+	for {
+		rows := make([]exportRow, 0, exportFetchSize)
+
+		if err := tx.SelectContext(ctx, &rows, fetch); err != nil {
+			logger.Warnf("unknown error on fetching products: %s", err)
+
+			if rErr := rollback(tx); rErr != nil {
+				return rErr
+			}
+
+			return r.errInternalExportProducts(err)
+		}
+
+		for _, row := range rows {
+			product := dto.ExportProduct{
+				Product:    row.Product,
+				Categories: row.Categories,
+			}
+
+			if err := write(product); err != nil {
+				if rErr := rollback(tx); rErr != nil {
+					return rErr
+				}
+
+				return err
+			}
+		}
+
+		if len(rows) < exportFetchSize {
+			break
+		}
+	}
+
+	return commit(tx)
+}
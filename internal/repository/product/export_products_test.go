@@ -0,0 +1,212 @@
+package product
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/repository/audit"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type ExportTestSuite struct {
+	suite.Suite
+
+	// Вспомогательные параметры
+	ctx        context.Context
+	logger     log.Logger
+	repository Repository
+
+	// Входные параметры
+	get dto.GetProduct
+
+	// Служебные параметры
+	db   *sqlx.DB
+	mock sqlmock.Sqlmock
+}
+
+func TestSuiteExport(t *testing.T) {
+	suite.Run(t, &ExportTestSuite{})
+}
+
+func (s *ExportTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.logger = log.NewNullLogger()
+}
+
+func (s *ExportTestSuite) BeforeTest(_, _ string) {
+	db, mock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+	)
+	s.NoError(err)
+
+	s.setupDatabase(db).setupMock(mock).setupRepository()
+
+	// Входные значения по-умолчанию
+	s.setupGetProduct("Про")
+}
+
+func (s *ExportTestSuite) setupDatabase(
+	db *sql.DB,
+) *ExportTestSuite {
+
+	wrappedDb := sqlx.NewDb(db, "sqlmock")
+
+	s.db = wrappedDb
+
+	return s
+}
+
+func (s *ExportTestSuite) setupMock(
+	mock sqlmock.Sqlmock,
+) *ExportTestSuite {
+
+	s.mock = mock
+
+	return s
+}
+
+func (s *ExportTestSuite) setupRepository() {
+	s.repository = New(s.db, audit.New(s.db, s.logger), s.logger)
+}
+
+func (s *ExportTestSuite) setupGetProduct(
+	namePrefix string,
+) {
+
+	s.get = dto.GetProduct{
+		Sort:   []dto.SortField{{Field: "name", Desc: true}},
+		Filter: dto.ProductFilter{NamePrefix: namePrefix},
+	}
+}
+
+func (s *ExportTestSuite) expectDeclare() {
+	categories := sq.
+		Select("category.name").
+		From("product_of_category").
+		Join("category ON category.id = product_of_category.category_id").
+		Where("product_of_category.product_id = product.id").
+		Where(sq.Eq{"category.deleted_at": nil}).
+		OrderBy("category.name")
+
+	query, args, err := sq.
+		Select(productColumns...).
+		Column(sq.Alias(sq.Expr("ARRAY(?)", categories), "categories")).
+		From("product").
+		OrderBy("name DESC", "id ASC").
+		Prefix("DECLARE product_export NO SCROLL CURSOR FOR").
+		Where(sq.And{
+			sq.Like{"name": s.get.Filter.NamePrefix + "%"},
+			sq.Eq{"deleted_at": nil},
+		}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	s.NoError(err)
+
+	s.mock.
+		ExpectExec(query).
+		WithArgs(convertArgs(args)...).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func (s *ExportTestSuite) TestSuccessful() {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	{
+		s.mock.ExpectBegin().WillReturnError(nil)
+	}
+
+	{
+		s.expectDeclare()
+	}
+
+	{
+		s.mock.
+			ExpectQuery(fmt.Sprintf("FETCH %d FROM product_export", exportFetchSize)).
+			WillReturnRows(
+				s.mock.
+					NewRows(append(productColumns, "categories")).
+					AddRow(2, "Продукт 2", "", 200, "RUB", "SKU-2", 1, createdAt, createdAt, nil, 1, "{}").
+					AddRow(1, "Продукт 1", "", 100, "RUB", "SKU-1", 1, createdAt, createdAt, nil, 1, `{"Категория А","Категория Б"}`),
+			)
+	}
+
+	{
+		s.mock.ExpectCommit().WillReturnError(nil)
+	}
+
+	exported := make([]dto.ExportProduct, 0)
+
+	err := s.repository.Export(s.ctx, s.get, func(product dto.ExportProduct) error {
+		exported = append(exported, product)
+
+		return nil
+	})
+
+	s.NoError(err)
+	s.Len(exported, 2)
+	s.Equal("Продукт 2", exported[0].Name)
+	s.Equal([]string{}, exported[0].Categories)
+	s.Equal([]string{"Категория А", "Категория Б"}, exported[1].Categories)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExportTestSuite) TestWriteFailed() {
+	const (
+		expectedErrorMsg = "broken pipe"
+	)
+
+	{
+		s.mock.ExpectBegin().WillReturnError(nil)
+	}
+
+	{
+		s.expectDeclare()
+	}
+
+	{
+		s.mock.
+			ExpectQuery(fmt.Sprintf("FETCH %d FROM product_export", exportFetchSize)).
+			WillReturnRows(
+				s.mock.
+					NewRows(append(productColumns, "categories")).
+					AddRow(1, "Продукт 1", "", 100, "RUB", "SKU-1", 1, time.Now(), time.Now(), nil, 1, "{}"),
+			)
+	}
+
+	{
+		s.mock.ExpectRollback().WillReturnError(nil)
+	}
+
+	err := s.repository.Export(s.ctx, s.get, func(dto.ExportProduct) error {
+		return errors.New(expectedErrorMsg)
+	})
+
+	s.NotNil(err)
+	s.Equal(expectedErrorMsg, err.Error())
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExportTestSuite) TestUnknownSortFieldFailed() {
+	const (
+		expectedErrorMsg = `unknown sort field "description"`
+	)
+
+	s.get.Sort = []dto.SortField{{Field: "description"}}
+
+	err := s.repository.Export(s.ctx, s.get, func(dto.ExportProduct) error {
+		return nil
+	})
+
+	s.NotNil(err)
+	s.Equal(expectedErrorMsg, err.Error())
+	s.NoError(s.mock.ExpectationsWereMet())
+}
@@ -0,0 +1,176 @@
+package product
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"strings"
+)
+
+var (
+	sortableColumns = map[string]string{
+		"id":         "id",
+		"name":       "name",
+		"price":      "price",
+		"stock":      "stock",
+		"sku":        "sku",
+		"created_at": "created_at",
+	}
+
+	likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+)
+
+func (r Repository) sortFields(
+	fields []dto.SortField,
+) ([]dto.SortField, error) {
+
+	normalized := make([]dto.SortField, 0, len(fields)+1)
+	seen := make(map[string]struct{}, len(fields))
+
+	for _, field := range fields {
+		if _, ok := sortableColumns[field.Field]; !ok {
+			return nil, r.errUnknownSortField(field.Field)
+		}
+
+		if _, ok := seen[field.Field]; ok {
+			continue
+		}
+
+		seen[field.Field] = struct{}{}
+		normalized = append(normalized, field)
+	}
+
+	if _, ok := seen["id"]; !ok {
+		normalized = append(normalized, dto.SortField{Field: "id"})
+	}
+
+	return normalized, nil
+}
+
+func (r Repository) orderBy(
+	fields []dto.SortField,
+) []string {
+
+	orderBy := make([]string, 0, len(fields))
+
+	for _, field := range fields {
+		direction := "ASC"
+		if field.Desc {
+			direction = "DESC"
+		}
+
+		orderBy = append(orderBy, sortableColumns[field.Field]+" "+direction)
+	}
+
+	return orderBy
+}
+
+func (r Repository) keyset(
+	fields []dto.SortField,
+	values []any,
+) (sq.Sqlizer, error) {
+
+	if len(fields) != len(values) {
+		return nil, r.errInvalidCursor()
+	}
+
+	keyset := sq.Or{}
+
+	for i, field := range fields {
+		condition := sq.And{}
+
+		for j := 0; j < i; j++ {
+			condition = append(condition, sq.Eq{sortableColumns[fields[j].Field]: values[j]})
+		}
+
+		column := sortableColumns[field.Field]
+
+		if field.Desc {
+			condition = append(condition, sq.Lt{column: values[i]})
+		} else {
+			condition = append(condition, sq.Gt{column: values[i]})
+		}
+
+		keyset = append(keyset, condition)
+	}
+
+	return keyset, nil
+}
+
+func (r Repository) filter(
+	filter dto.ProductFilter,
+) sq.And {
+
+	conditions := sq.And{}
+
+	if filter.NamePrefix != "" {
+		conditions = append(
+			conditions,
+			sq.Like{"name": likeEscaper.Replace(filter.NamePrefix) + "%"},
+		)
+	}
+
+	if len(filter.CategoryIds) > 0 {
+		categories := sq.
+			Select("product_id").
+			From("product_of_category").
+			Where(sq.Eq{"category_id": filter.CategoryIds})
+
+		if filter.IncludeDescendants {
+			descendants := sq.Expr(
+				"WITH RECURSIVE descendants AS ("+
+					"? "+
+					"UNION "+
+					"SELECT c.id FROM category c JOIN descendants d ON c.parent_id = d.id "+
+					"WHERE c.deleted_at IS NULL"+
+					") SELECT id FROM descendants",
+				sq.Select("id").From("category").Where(sq.Eq{"id": filter.CategoryIds}),
+			)
+
+			categories = sq.
+				Select("product_id").
+				From("product_of_category").
+				Where(sq.Expr("category_id IN (?)", descendants))
+		}
+
+		conditions = append(conditions, sq.Expr("id IN (?)", categories))
+	}
+
+	if !filter.CreatedAfter.IsZero() {
+		conditions = append(conditions, sq.Gt{"created_at": filter.CreatedAfter})
+	}
+
+	return conditions
+}
+
+func (r Repository) sortValues(
+	product dto.Product,
+	fields []dto.SortField,
+) []any {
+
+	values := make([]any, 0, len(fields))
+
+	for _, field := range fields {
+		switch field.Field {
+
+		case "name":
+			values = append(values, product.Name)
+
+		case "price":
+			values = append(values, product.Price)
+
+		case "stock":
+			values = append(values, product.Stock)
+
+		case "sku":
+			values = append(values, product.SKU)
+
+		case "created_at":
+			values = append(values, product.CreatedAt)
+
+		default:
+			values = append(values, product.ID)
+		}
+	}
+
+	return values
+}
@@ -8,10 +8,16 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/repository/audit"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/suite"
 	"testing"
+	"time"
+)
+
+var (
+	notDeleted = sq.And{sq.Eq{"deleted_at": nil}}
 )
 
 type IntArrayConverter struct{}
@@ -35,7 +41,6 @@ type GetTestSuite struct {
 
 	// Входные параметры
 	get      dto.GetProduct
-	category dto.Category
 	product  dto.Product
 	products []dto.Product
 
@@ -63,8 +68,7 @@ func (s *GetTestSuite) BeforeTest(_, _ string) {
 	s.setupDatabase(db).setupMock(mock).setupRepository()
 
 	// Входные значения по-умолчанию
-	s.setupCategory(1, "Категория").
-		setupGet(0, 10).
+	s.setupGet(0, 10).
 		setupProduct(1, "Продукт").
 		setupProducts(1, "Продукт")
 }
@@ -90,7 +94,7 @@ func (s *GetTestSuite) setupMock(
 }
 
 func (s *GetTestSuite) setupRepository() {
-	s.repository = New(s.db, s.logger)
+	s.repository = New(s.db, audit.New(s.db, s.logger), s.logger)
 }
 
 func (s *GetTestSuite) setupGet(
@@ -133,24 +137,13 @@ func (s *GetTestSuite) setupProducts(
 	return s
 }
 
-func (s *GetTestSuite) setupCategory(
-	id int,
-	name string,
-) *GetTestSuite {
-
-	s.category = dto.Category{
-		ID:   1,
-		Name: "Категория",
-	}
-
-	return s
-}
-
 func (s *GetTestSuite) TestGetSuccessful() {
 	{
 		query, args, err := sq.
-			Select("*").
+			Select(productColumns...).
+			Column(sq.Alias(sq.Select("COUNT(*)").From("product").Where(notDeleted), "total")).
 			From("product").
+			Where(notDeleted).
 			OrderBy("id ASC").
 			Offset(uint64(s.get.Offset)).
 			Limit(uint64(s.get.Limit)).
@@ -164,18 +157,19 @@ func (s *GetTestSuite) TestGetSuccessful() {
 			WithArgs(convertArgs(args)...).
 			WillReturnRows(
 				s.mock.
-					NewRows([]string{"id", "name"}).
+					NewRows([]string{"id", "name", "total"}).
 					AddRow(
 						s.product.ID,
 						s.product.Name,
+						1,
 					),
 			)
 	}
 
-	products, err := s.repository.Get(s.ctx, s.get)
+	page, err := s.repository.Get(s.ctx, s.get)
 
 	s.NoError(err)
-	s.Equal(products, s.products)
+	s.Equal(dto.ProductPage{Items: s.products, Total: 1}, page)
 	s.NoError(s.mock.ExpectationsWereMet())
 }
 
@@ -201,8 +195,10 @@ func (s *GetTestSuite) TestGetFailure() {
 		s.Run(testCase.testName, func() {
 			{
 				query, args, err := sq.
-					Select("*").
+					Select(productColumns...).
+					Column(sq.Alias(sq.Select("COUNT(*)").From("product").Where(notDeleted), "total")).
 					From("product").
+					Where(notDeleted).
 					OrderBy("id ASC").
 					Offset(uint64(s.get.Offset)).
 					Limit(uint64(s.get.Limit)).
@@ -217,27 +213,22 @@ func (s *GetTestSuite) TestGetFailure() {
 					WillReturnError(testCase.expectedError)
 			}
 
-			products, err := s.repository.Get(s.ctx, s.get)
+			page, err := s.repository.Get(s.ctx, s.get)
 
 			s.NotNil(err)
 			s.Equal(err.Error(), testCase.expectedErrorMsg)
-			s.Equal(products, []dto.Product{})
+			s.Equal(dto.ProductPage{}, page)
 			s.NoError(s.mock.ExpectationsWereMet())
 		})
 	}
 }
 
-func (s *GetTestSuite) TestGetByCategoryIdSuccessful() {
+func (s *GetTestSuite) TestGetByIdSuccessful() {
 	{
 		query, args, err := sq.
-			Select("p.*").
-			LeftJoin("product_of_category pc ON pc.product_id = p.id").
-			LeftJoin("category c ON c.id = pc.category_id").
-			From("product p").
-			Where(sq.Eq{"pc.category_id": 1}).
-			OrderBy("id ASC").
-			Offset(uint64(s.get.Offset)).
-			Limit(uint64(s.get.Limit)).
+			Select(productColumns...).
+			From("product").
+			Where(sq.Eq{"id": s.product.ID, "deleted_at": nil}).
 			PlaceholderFormat(sq.Dollar).
 			ToSql()
 
@@ -256,14 +247,14 @@ func (s *GetTestSuite) TestGetByCategoryIdSuccessful() {
 			)
 	}
 
-	products, err := s.repository.GetByCategoryId(s.ctx, s.get, s.category)
+	product, err := s.repository.GetById(s.ctx, s.product.ID)
 
 	s.NoError(err)
-	s.Equal(products, s.products)
+	s.Equal(product, s.product)
 	s.NoError(s.mock.ExpectationsWereMet())
 }
 
-func (s *GetTestSuite) TestGetByCategoryIdFailure() {
+func (s *GetTestSuite) TestGetByIdFailure() {
 	testCases := []struct {
 		testName         string
 		expectedError    error
@@ -271,13 +262,13 @@ func (s *GetTestSuite) TestGetByCategoryIdFailure() {
 	}{
 		{
 			testName:         "Unknown database error",
-			expectedError:    errors.New("unknown error on getting products"),
-			expectedErrorMsg: "unknown error on getting products",
+			expectedError:    errors.New("unknown error on getting product"),
+			expectedErrorMsg: "unknown error on getting product",
 		},
 		{
 			testName:         "Not found",
 			expectedError:    sql.ErrNoRows,
-			expectedErrorMsg: "products not found",
+			expectedErrorMsg: "product not found",
 		},
 	}
 
@@ -285,14 +276,9 @@ func (s *GetTestSuite) TestGetByCategoryIdFailure() {
 		s.Run(testCase.testName, func() {
 			{
 				query, args, err := sq.
-					Select("p.*").
-					LeftJoin("product_of_category pc ON pc.product_id = p.id").
-					LeftJoin("category c ON c.id = pc.category_id").
-					From("product p").
-					Where(sq.Eq{"pc.category_id": 1}).
-					OrderBy("id ASC").
-					Offset(uint64(s.get.Offset)).
-					Limit(uint64(s.get.Limit)).
+					Select(productColumns...).
+					From("product").
+					Where(sq.Eq{"id": s.product.ID, "deleted_at": nil}).
 					PlaceholderFormat(sq.Dollar).
 					ToSql()
 
@@ -304,21 +290,179 @@ func (s *GetTestSuite) TestGetByCategoryIdFailure() {
 					WillReturnError(testCase.expectedError)
 			}
 
-			products, err := s.repository.GetByCategoryId(s.ctx, s.get, s.category)
+			product, err := s.repository.GetById(s.ctx, s.product.ID)
 
 			s.NotNil(err)
 			s.Equal(err.Error(), testCase.expectedErrorMsg)
-			s.Equal(products, []dto.Product{})
+			s.Equal(product, dto.Product{})
 			s.NoError(s.mock.ExpectationsWereMet())
 		})
 	}
 }
-func (s *GetTestSuite) TestGetByIdSuccessful() {
+
+func (s *GetTestSuite) searchQuery(
+	search dto.SearchProduct,
+) (string, []any, error) {
+
+	columns := append(
+		s.repository.columnsWithAlias("p"),
+		"ts_rank(p.search_vector, q.query) AS rank",
+	)
+
+	return sq.
+		Select(columns...).
+		Column(
+			"ts_headline('russian', p.name || ' ' || p.description, q.query, ?) AS snippet",
+			searchHeadlineOptions,
+		).
+		From("product p").
+		Join(
+			"(SELECT websearch_to_tsquery('russian', ?) || websearch_to_tsquery('english', ?) AS query) q "+
+				"ON p.search_vector @@ q.query",
+			search.Query, search.Query,
+		).
+		Where(sq.Eq{"p.deleted_at": nil}).
+		OrderBy("rank DESC", "p.id ASC").
+		Offset(uint64(search.Offset)).
+		Limit(uint64(search.Limit)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+}
+
+func (s *GetTestSuite) TestSearchSuccessful() {
+	search := dto.SearchProduct{
+		Query:  "корм",
+		Limit:  s.get.Limit,
+		Offset: s.get.Offset,
+	}
+
+	expected := []dto.FoundProduct{
+		{
+			Product: s.product,
+			Rank:    0.5,
+			Snippet: "Сухой <b>корм</b> для собак",
+		},
+	}
+
+	{
+		query, args, err := s.searchQuery(search)
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnRows(
+				s.mock.
+					NewRows([]string{"id", "name", "rank", "snippet"}).
+					AddRow(
+						expected[0].ID,
+						expected[0].Name,
+						expected[0].Rank,
+						"Сухой \x02корм\x03 для собак",
+					),
+			)
+	}
+
+	products, err := s.repository.Search(s.ctx, search)
+
+	s.NoError(err)
+	s.Equal(expected, products)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *GetTestSuite) TestSearchEscapeSuccessful() {
+	search := dto.SearchProduct{
+		Query:  "корм",
+		Limit:  s.get.Limit,
+		Offset: s.get.Offset,
+	}
+
+	s.product.Name = `<script>alert("корм")</script>`
+
+	expected := []dto.FoundProduct{
+		{
+			Product: s.product,
+			Rank:    0.5,
+			Snippet: `&lt;script&gt;alert(&#34;<b>корм</b>&#34;)&lt;/script&gt; <b>&lt;b&gt;</b>`,
+		},
+	}
+
+	{
+		query, args, err := s.searchQuery(search)
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnRows(
+				s.mock.
+					NewRows([]string{"id", "name", "rank", "snippet"}).
+					AddRow(
+						expected[0].ID,
+						expected[0].Name,
+						expected[0].Rank,
+						"<script>alert(\"\x02корм\x03\")</script> \x02<b>",
+					),
+			)
+	}
+
+	products, err := s.repository.Search(s.ctx, search)
+
+	s.NoError(err)
+	s.Equal(expected, products)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *GetTestSuite) TestSearchFailure() {
+	const (
+		expectedInternalErrorMsg = "unknown database error"
+		expectedErrorMsg         = "unknown error on searching products"
+	)
+
+	search := dto.SearchProduct{
+		Query:  "корм",
+		Limit:  s.get.Limit,
+		Offset: s.get.Offset,
+	}
+
+	{
+		query, args, err := s.searchQuery(search)
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnError(errors.New(expectedInternalErrorMsg))
+	}
+
+	products, err := s.repository.Search(s.ctx, search)
+
+	s.NotNil(err)
+	s.Equal(err.Error(), expectedErrorMsg)
+	s.Equal(products, []dto.FoundProduct{})
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *GetTestSuite) TestGetAfterSuccessful() {
+	s.get.Limit = 1
+	s.get.Sort = []dto.SortField{{Field: "name"}}
+	s.get.After = []any{"Кофе", 5}
+
 	{
 		query, args, err := sq.
-			Select("*").
+			Select(productColumns...).
+			Column(sq.Alias(sq.Select("COUNT(*)").From("product").Where(notDeleted), "total")).
 			From("product").
-			Where(sq.Eq{"id": s.product.ID}).
+			Where(notDeleted).
+			Where(sq.Or{
+				sq.And{sq.Gt{"name": "Кофе"}},
+				sq.And{sq.Eq{"name": "Кофе"}, sq.Gt{"id": 5}},
+			}).
+			OrderBy("name ASC", "id ASC").
+			Limit(uint64(s.get.Limit)).
 			PlaceholderFormat(sq.Dollar).
 			ToSql()
 
@@ -329,63 +473,238 @@ func (s *GetTestSuite) TestGetByIdSuccessful() {
 			WithArgs(convertArgs(args)...).
 			WillReturnRows(
 				s.mock.
-					NewRows([]string{"id", "name"}).
+					NewRows([]string{"id", "name", "total"}).
 					AddRow(
 						s.product.ID,
 						s.product.Name,
+						1,
 					),
 			)
 	}
 
-	product, err := s.repository.GetById(s.ctx, s.product.ID)
+	page, err := s.repository.Get(s.ctx, s.get)
 
 	s.NoError(err)
-	s.Equal(product, s.product)
+	s.Equal(
+		dto.ProductPage{
+			Items: s.products,
+			Total: 1,
+			Last:  []any{s.product.Name, s.product.ID},
+		},
+		page,
+	)
 	s.NoError(s.mock.ExpectationsWereMet())
 }
 
-func (s *GetTestSuite) TestGetByIdFailure() {
+func (s *GetTestSuite) TestGetSortAndFilterSuccessful() {
+	createdAfter := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	s.get.Sort = []dto.SortField{
+		{Field: "price", Desc: true},
+		{Field: "id", Desc: true},
+	}
+
+	s.get.Filter = dto.ProductFilter{
+		NamePrefix:   "100%_",
+		CategoryIds:  []int{1, 2},
+		CreatedAfter: createdAfter,
+	}
+
+	filter := sq.And{
+		sq.Like{"name": `100\%\_%`},
+		sq.Expr(
+			"id IN (?)",
+			sq.
+				Select("product_id").
+				From("product_of_category").
+				Where(sq.Eq{"category_id": []int{1, 2}}),
+		),
+		sq.Gt{"created_at": createdAfter},
+		sq.Eq{"deleted_at": nil},
+	}
+
+	{
+		query, args, err := sq.
+			Select(productColumns...).
+			Column(sq.Alias(sq.Select("COUNT(*)").From("product").Where(filter), "total")).
+			From("product").
+			Where(filter).
+			OrderBy("price DESC", "id DESC").
+			Offset(uint64(s.get.Offset)).
+			Limit(uint64(s.get.Limit)).
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnRows(
+				s.mock.
+					NewRows([]string{"id", "name", "total"}).
+					AddRow(
+						s.product.ID,
+						s.product.Name,
+						1,
+					),
+			)
+	}
+
+	page, err := s.repository.Get(s.ctx, s.get)
+
+	s.NoError(err)
+	s.Equal(dto.ProductPage{Items: s.products, Total: 1}, page)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *GetTestSuite) TestGetFilterWithDescendantsSuccessful() {
+	s.get.Filter = dto.ProductFilter{
+		CategoryIds:        []int{1},
+		IncludeDescendants: true,
+	}
+
+	descendants := sq.Expr(
+		"WITH RECURSIVE descendants AS ("+
+			"? "+
+			"UNION "+
+			"SELECT c.id FROM category c JOIN descendants d ON c.parent_id = d.id "+
+			"WHERE c.deleted_at IS NULL"+
+			") SELECT id FROM descendants",
+		sq.Select("id").From("category").Where(sq.Eq{"id": []int{1}}),
+	)
+
+	filter := sq.And{
+		sq.Expr(
+			"id IN (?)",
+			sq.
+				Select("product_id").
+				From("product_of_category").
+				Where(sq.Expr("category_id IN (?)", descendants)),
+		),
+		sq.Eq{"deleted_at": nil},
+	}
+
+	{
+		query, args, err := sq.
+			Select(productColumns...).
+			Column(sq.Alias(sq.Select("COUNT(*)").From("product").Where(filter), "total")).
+			From("product").
+			Where(filter).
+			OrderBy("id ASC").
+			Offset(uint64(s.get.Offset)).
+			Limit(uint64(s.get.Limit)).
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnRows(
+				s.mock.
+					NewRows([]string{"id", "name", "total"}).
+					AddRow(
+						s.product.ID,
+						s.product.Name,
+						1,
+					),
+			)
+	}
+
+	page, err := s.repository.Get(s.ctx, s.get)
+
+	s.NoError(err)
+	s.Equal(dto.ProductPage{Items: s.products, Total: 1}, page)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *GetTestSuite) TestGetSortAndFilterFailed() {
 	testCases := []struct {
 		testName         string
-		expectedError    error
+		sort             []dto.SortField
+		after            []any
 		expectedErrorMsg string
 	}{
 		{
-			testName:         "Unknown database error",
-			expectedError:    errors.New("unknown error on getting product"),
-			expectedErrorMsg: "unknown error on getting product",
+			testName:         "Unknown sort field",
+			sort:             []dto.SortField{{Field: "password"}},
+			expectedErrorMsg: `unknown sort field "password"`,
 		},
 		{
-			testName:         "Not found",
-			expectedError:    sql.ErrNoRows,
-			expectedErrorMsg: "product not found",
+			testName:         "Cursor doesn't match sort",
+			sort:             []dto.SortField{{Field: "name"}},
+			after:            []any{5},
+			expectedErrorMsg: "invalid cursor data",
 		},
 	}
 
 	for _, testCase := range testCases {
 		s.Run(testCase.testName, func() {
-			{
-				query, args, err := sq.
-					Select("*").
-					From("product").
-					Where(sq.Eq{"id": s.product.ID}).
-					PlaceholderFormat(sq.Dollar).
-					ToSql()
+			s.get.Sort = testCase.sort
+			s.get.After = testCase.after
 
-				s.NoError(err)
-
-				s.mock.
-					ExpectQuery(query).
-					WithArgs(convertArgs(args)...).
-					WillReturnError(testCase.expectedError)
-			}
-
-			product, err := s.repository.GetById(s.ctx, s.product.ID)
+			page, err := s.repository.Get(s.ctx, s.get)
 
 			s.NotNil(err)
-			s.Equal(err.Error(), testCase.expectedErrorMsg)
-			s.Equal(product, dto.Product{})
+			s.Equal(testCase.expectedErrorMsg, err.Error())
+			s.Equal(dto.ProductPage{}, page)
 			s.NoError(s.mock.ExpectationsWereMet())
 		})
 	}
 }
+
+func (s *GetTestSuite) TestGetOutOfRangeSuccessful() {
+	s.get.Offset = 10
+
+	count := sq.
+		Select("COUNT(*)").
+		From("product").
+		Where(notDeleted)
+
+	{
+		query, args, err := sq.
+			Select(productColumns...).
+			Column(sq.Alias(count, "total")).
+			From("product").
+			Where(notDeleted).
+			OrderBy("id ASC").
+			Offset(uint64(s.get.Offset)).
+			Limit(uint64(s.get.Limit)).
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnRows(
+				s.mock.NewRows([]string{"id", "name", "total"}),
+			)
+	}
+
+	{
+		query, args, err := count.
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnRows(
+				s.mock.
+					NewRows([]string{"count"}).
+					AddRow(3),
+			)
+	}
+
+	page, err := s.repository.Get(s.ctx, s.get)
+
+	s.NoError(err)
+	s.Equal(dto.ProductPage{Items: []dto.Product{}, Total: 3}, page)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
@@ -0,0 +1,52 @@
+package product
+
+import (
+	"html"
+	"strings"
+)
+
+const (
+	// Управляющие символы вместо <b> и </b>: ts_headline не экранирует
+	// текст, поэтому разметка добавляется только после экранирования
+	headlineStartSel = '\x02'
+	headlineStopSel  = '\x03'
+
+	searchHeadlineOptions = "StartSel=\x02, StopSel=\x03, MaxFragments=2, MaxWords=20, MinWords=5"
+)
+
+// highlight превращает фрагмент ts_headline в HTML: название и описание
+// товара экранируются, единственная разметка в результате — парные <b>
+func highlight(
+	snippet string,
+) string {
+
+	var (
+		builder strings.Builder
+		opened  bool
+	)
+
+	for _, char := range html.EscapeString(snippet) {
+		switch char {
+		case headlineStartSel:
+			if !opened {
+				builder.WriteString("<b>")
+				opened = true
+			}
+
+		case headlineStopSel:
+			if opened {
+				builder.WriteString("</b>")
+				opened = false
+			}
+
+		default:
+			builder.WriteRune(char)
+		}
+	}
+
+	if opened {
+		builder.WriteString("</b>")
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,459 @@
+package product
+
+import (
+	"context"
+	sq "github.com/Masterminds/squirrel"
+	pgerr "github.com/jackc/pgerrcode"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	importChunkSize = 500
+)
+
+// namedRow — строка, возвращаемая RETURNING id, name
+type namedRow struct {
+	ID   int    `db:"id"`
+	Name string `db:"name"`
+}
+
+// Import создаёт товары одной транзакцией. Строки, конфликтующие с
+// существующими товарами или друг с другом, попадают в отчёт, отсутствующие
+// категории создаются по имени. При dryRun транзакция откатывается, поэтому
+// импорт всегда открывает собственную транзакцию, а не присоединяется к внешней
+func (r Repository) Import(
+	ctx context.Context,
+	rows []dto.ImportProduct,
+	dryRun bool,
+) (dto.ImportResult, error) {
+
+	rollback := func(tx *sqlx.Tx) error {
+		if err := tx.Rollback(); err != nil {
+			r.logger.Warnf("unknown error on rollback: %s", err)
+
+			return r.errInternalImportProducts(err)
+		}
+
+		return nil
+	}
+
+	commit := func(tx *sqlx.Tx) error {
+		if err := tx.Commit(); err != nil {
+			r.logger.Warnf("unknown error on commit: %s", err)
+
+			return r.errInternalImportProducts(err)
+		}
+
+		return nil
+	}
+
+	result := dto.ImportResult{
+		CreatedCategories: []string{},
+		DryRun:            dryRun,
+		Errors:            []dto.ImportError{},
+	}
+
+	if len(rows) == 0 {
+		return result, nil
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		r.logger.Warnf("unknown error on starting transaction: %s", err)
+
+		return dto.ImportResult{}, r.errInternalImportProducts(err)
+	}
+
+	fail := func(err error) (dto.ImportResult, error) {
+		if rErr := rollback(tx); rErr != nil {
+			return dto.ImportResult{}, rErr
+		}
+
+		return dto.ImportResult{}, err
+	}
+
+	accepted, errs, err := r.importConflicts(ctx, tx, rows)
+	if err != nil {
+		return fail(err)
+	}
+
+	result.Errors = append(result.Errors, errs...)
+
+	if len(accepted) > 0 {
+		categories, created, err := r.importCategories(ctx, tx, accepted)
+		if err != nil {
+			return fail(err)
+		}
+
+		result.CreatedCategories = created
+
+		products, err := r.importProducts(ctx, tx, accepted)
+		if err != nil {
+			return fail(err)
+		}
+
+		if err := r.importProductsOfCategory(ctx, tx, accepted, products, categories); err != nil {
+			return fail(err)
+		}
+
+		for _, row := range accepted {
+			if err := r.auditAfter(ctx, tx, products[row.Name], nil); err != nil {
+				return fail(err)
+			}
+		}
+
+		result.Created = len(accepted)
+	}
+
+	if dryRun {
+		if err := rollback(tx); err != nil {
+			return dto.ImportResult{}, err
+		}
+
+		return result, nil
+	}
+
+	return result, commit(tx)
+}
+
+// importConflicts отбрасывает строки, чьё имя или артикул уже заняты
+// существующим товаром или предыдущей строкой файла
+func (r Repository) importConflicts(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	rows []dto.ImportProduct,
+) ([]dto.ImportProduct, []dto.ImportError, error) {
+
+	names := make([]string, len(rows))
+	skus := make([]string, len(rows))
+
+	for i, row := range rows {
+		names[i] = row.Name
+		skus[i] = row.SKU
+	}
+
+	query, args, err := sq.
+		Select("name", "sku").
+		From("product").
+		Where(sq.Or{
+			sq.Expr("name = ANY(?)", pq.Array(names)),
+			sq.Expr("sku = ANY(?)", pq.Array(skus)),
+		}).
+		Where(sq.Eq{"deleted_at": nil}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"rows": len(rows),
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return nil, nil, r.errInternalBuildSql(err)
+	}
+
+	existing := make([]dto.Product, 0)
+
+	if err := tx.SelectContext(ctx, &existing, query, args...); err != nil {
+		logger.Warnf("unknown error on getting existing products: %s", err)
+
+		return nil, nil, r.errInternalImportProducts(err)
+	}
+
+	takenNames := make(map[string]struct{}, len(rows))
+	takenSkus := make(map[string]struct{}, len(rows))
+
+	for _, product := range existing {
+		takenNames[product.Name] = struct{}{}
+		takenSkus[product.SKU] = struct{}{}
+	}
+
+	accepted := make([]dto.ImportProduct, 0, len(rows))
+	errs := make([]dto.ImportError, 0)
+
+	for _, row := range rows {
+		if _, ok := takenNames[row.Name]; ok {
+			errs = append(errs, dto.ImportError{Row: row.Row, Reason: "product already exists"})
+
+			continue
+		}
+
+		if _, ok := takenSkus[row.SKU]; ok {
+			errs = append(errs, dto.ImportError{Row: row.Row, Reason: "product with sku already exists"})
+
+			continue
+		}
+
+		takenNames[row.Name] = struct{}{}
+		takenSkus[row.SKU] = struct{}{}
+
+		accepted = append(accepted, row)
+	}
+
+	return accepted, errs, nil
+}
+
+// importCategories возвращает идентификаторы категорий по имени,
+// создавая отсутствующие
+func (r Repository) importCategories(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	rows []dto.ImportProduct,
+) (map[string]int, []string, error) {
+
+	names := make([]string, 0)
+	seen := make(map[string]struct{})
+
+	for _, row := range rows {
+		for _, name := range row.Categories {
+			if _, ok := seen[name]; ok {
+				continue
+			}
+
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+
+	query, args, err := sq.
+		Select("id", "name").
+		From("category").
+		Where(sq.Expr("name = ANY(?)", pq.Array(names))).
+		Where(sq.Eq{"deleted_at": nil}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"names": names,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return nil, nil, r.errInternalBuildSql(err)
+	}
+
+	existing := make([]namedRow, 0)
+
+	if err := tx.SelectContext(ctx, &existing, query, args...); err != nil {
+		logger.Warnf("unknown error on getting categories: %s", err)
+
+		return nil, nil, r.errInternalImportProducts(err)
+	}
+
+	categories := make(map[string]int, len(names))
+
+	for _, category := range existing {
+		categories[category.Name] = category.ID
+	}
+
+	missing := make([]string, 0)
+
+	for _, name := range names {
+		if _, ok := categories[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	for start := 0; start < len(missing); start += importChunkSize {
+		chunk := missing[start:min(start+importChunkSize, len(missing))]
+
+		builder := sq.
+			Insert("category").
+			Columns("name").
+			Suffix("RETURNING id, name").
+			PlaceholderFormat(sq.Dollar)
+
+		for _, name := range chunk {
+			builder = builder.Values(name)
+		}
+
+		query, args, err := builder.ToSql()
+
+		logger := r.logger.WithFields(map[string]any{
+			"query": query,
+			"args": map[string]any{
+				"names": chunk,
+			},
+		})
+
+		if err != nil {
+			logger.Warnf("unknown error on building sql query: %s", err)
+
+			return nil, nil, r.errInternalBuildSql(err)
+		}
+
+		created := make([]namedRow, 0, len(chunk))
+
+		if err := tx.SelectContext(ctx, &created, query, args...); err != nil {
+			if e, ok := err.(*pq.Error); ok && e.Code == pgerr.UniqueViolation {
+				logger.Warnf("category already exists: %s", err)
+
+				return nil, nil, r.errCategoryAlreadyExists(err)
+			}
+
+			logger.Warnf("unknown error on creating categories: %s", err)
+
+			return nil, nil, r.errInternalImportProducts(err)
+		}
+
+		for _, category := range created {
+			categories[category.Name] = category.ID
+		}
+	}
+
+	return categories, missing, nil
+}
+
+func (r Repository) importProducts(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	rows []dto.ImportProduct,
+) (map[string]int, error) {
+
+	products := make(map[string]int, len(rows))
+
+	for start := 0; start < len(rows); start += importChunkSize {
+		chunk := rows[start:min(start+importChunkSize, len(rows))]
+
+		builder := sq.
+			Insert("product").
+			Columns("name", "description", "price", "currency", "sku", "stock").
+			Suffix("RETURNING id, name").
+			PlaceholderFormat(sq.Dollar)
+
+		for _, row := range chunk {
+			builder = builder.Values(
+				row.Name, row.Description,
+				row.Price, row.Currency,
+				row.SKU, row.Stock,
+			)
+		}
+
+		query, args, err := builder.ToSql()
+
+		logger := r.logger.WithFields(map[string]any{
+			"query": query,
+			"args": map[string]any{
+				"rows": len(chunk),
+			},
+		})
+
+		if err != nil {
+			logger.Warnf("unknown error on building sql query: %s", err)
+
+			return nil, r.errInternalBuildSql(err)
+		}
+
+		created := make([]namedRow, 0, len(chunk))
+
+		if err := tx.SelectContext(ctx, &created, query, args...); err != nil {
+			if e, ok := err.(*pq.Error); ok {
+				switch e.Code {
+
+				case pgerr.UniqueViolation:
+					if e.Constraint == productSkuConstraint {
+						logger.Warnf("product with sku already exists: %s", err)
+
+						return nil, r.errProductSkuAlreadyExists(err)
+					}
+
+					logger.Warnf("product already exists: %s", err)
+
+					return nil, r.errProductAlreadyExists(err)
+
+				case pgerr.CheckViolation:
+					logger.Warnf("invalid product data: %s", err)
+
+					return nil, r.errInvalidProduct(err)
+				}
+			}
+
+			logger.Warnf("unknown error on importing products: %s", err)
+
+			return nil, r.errInternalImportProducts(err)
+		}
+
+		for _, product := range created {
+			products[product.Name] = product.ID
+		}
+	}
+
+	return products, nil
+}
+
+func (r Repository) importProductsOfCategory(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	rows []dto.ImportProduct,
+	products map[string]int,
+	categories map[string]int,
+) error {
+
+	type pair struct {
+		productId  int
+		categoryId int
+	}
+
+	pairs := make([]pair, 0, len(rows))
+
+	for _, row := range rows {
+		seen := make(map[int]struct{}, len(row.Categories))
+
+		for _, name := range row.Categories {
+			categoryId := categories[name]
+
+			if _, ok := seen[categoryId]; ok {
+				continue
+			}
+
+			seen[categoryId] = struct{}{}
+			pairs = append(pairs, pair{products[row.Name], categoryId})
+		}
+	}
+
+	for start := 0; start < len(pairs); start += importChunkSize {
+		chunk := pairs[start:min(start+importChunkSize, len(pairs))]
+
+		builder := sq.
+			Insert("product_of_category").
+			Columns("product_id", "category_id").
+			PlaceholderFormat(sq.Dollar)
+
+		for _, p := range chunk {
+			builder = builder.Values(p.productId, p.categoryId)
+		}
+
+		query, args, err := builder.ToSql()
+
+		logger := r.logger.WithFields(map[string]any{
+			"query": query,
+			"args": map[string]any{
+				"rows": len(chunk),
+			},
+		})
+
+		if err != nil {
+			logger.Warnf("unknown error on building sql query: %s", err)
+
+			return r.errInternalBuildSql(err)
+		}
+
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			logger.Warnf("unknown error on attaching products to categories: %s", err)
+
+			return r.errInternalAttachProductToCategory(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,306 @@
+package product
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/repository/audit"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type ImportTestSuite struct {
+	suite.Suite
+
+	// Вспомогательные параметры
+	ctx        context.Context
+	logger     log.Logger
+	repository Repository
+
+	// Входные параметры
+	rows []dto.ImportProduct
+
+	// Служебные параметры
+	db   *sqlx.DB
+	mock sqlmock.Sqlmock
+}
+
+func TestSuiteImport(t *testing.T) {
+	suite.Run(t, &ImportTestSuite{})
+}
+
+func (s *ImportTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.logger = log.NewNullLogger()
+}
+
+func (s *ImportTestSuite) BeforeTest(_, _ string) {
+	db, mock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+	)
+	s.NoError(err)
+
+	s.setupDatabase(db).setupMock(mock).setupRepository()
+
+	// Входные значения по-умолчанию
+	s.setupRows()
+}
+
+func (s *ImportTestSuite) setupDatabase(
+	db *sql.DB,
+) *ImportTestSuite {
+
+	wrappedDb := sqlx.NewDb(db, "sqlmock")
+
+	s.db = wrappedDb
+
+	return s
+}
+
+func (s *ImportTestSuite) setupMock(
+	mock sqlmock.Sqlmock,
+) *ImportTestSuite {
+
+	s.mock = mock
+
+	return s
+}
+
+func (s *ImportTestSuite) setupRepository() {
+	s.repository = New(s.db, audit.New(s.db, s.logger), s.logger)
+}
+
+func (s *ImportTestSuite) setupRows() {
+	s.rows = []dto.ImportProduct{
+		{
+			Row:        2,
+			Name:       "Продукт",
+			Price:      10000,
+			Currency:   "RUB",
+			SKU:        "SKU-1",
+			Categories: []string{"Категория", "Новая категория"},
+		},
+		{
+			Row:        3,
+			Name:       "Существующий",
+			Price:      100,
+			Currency:   "RUB",
+			SKU:        "SKU-2",
+			Categories: []string{"Категория"},
+		},
+		{
+			Row:        4,
+			Name:       "Дубликат",
+			Price:      100,
+			Currency:   "RUB",
+			SKU:        "SKU-1",
+			Categories: []string{"Категория"},
+		},
+	}
+}
+
+func (s *ImportTestSuite) expectImport() {
+	{
+		s.mock.ExpectBegin().WillReturnError(nil)
+	}
+
+	{
+		query, args, err := sq.
+			Select("name", "sku").
+			From("product").
+			Where(sq.Or{
+				sq.Expr("name = ANY(?)", pq.Array([]string{"Продукт", "Существующий", "Дубликат"})),
+				sq.Expr("sku = ANY(?)", pq.Array([]string{"SKU-1", "SKU-2", "SKU-1"})),
+			}).
+			Where(sq.Eq{"deleted_at": nil}).
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnRows(
+				s.mock.
+					NewRows([]string{"name", "sku"}).
+					AddRow("Существующий", "SKU-0"),
+			)
+	}
+
+	{
+		query, args, err := sq.
+			Select("id", "name").
+			From("category").
+			Where(sq.Expr("name = ANY(?)", pq.Array([]string{"Категория", "Новая категория"}))).
+			Where(sq.Eq{"deleted_at": nil}).
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnRows(
+				s.mock.
+					NewRows([]string{"id", "name"}).
+					AddRow(1, "Категория"),
+			)
+	}
+
+	{
+		query, args, err := sq.
+			Insert("category").
+			Columns("name").
+			Values("Новая категория").
+			Suffix("RETURNING id, name").
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnRows(
+				s.mock.
+					NewRows([]string{"id", "name"}).
+					AddRow(2, "Новая категория"),
+			)
+	}
+
+	{
+		row := s.rows[0]
+
+		query, args, err := sq.
+			Insert("product").
+			Columns("name", "description", "price", "currency", "sku", "stock").
+			Values(
+				row.Name, row.Description,
+				row.Price, row.Currency,
+				row.SKU, row.Stock,
+			).
+			Suffix("RETURNING id, name").
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnRows(
+				s.mock.
+					NewRows([]string{"id", "name"}).
+					AddRow(1, row.Name),
+			)
+	}
+
+	{
+		query, args, err := sq.
+			Insert("product_of_category").
+			Columns("product_id", "category_id").
+			Values(1, 1).
+			Values(1, 2).
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectExec(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+	}
+}
+
+func (s *ImportTestSuite) TestSuccessful() {
+	s.expectImport()
+
+	{
+		s.mock.ExpectCommit().WillReturnError(nil)
+	}
+
+	result, err := s.repository.Import(s.ctx, s.rows, false)
+
+	s.NoError(err)
+	s.Equal(dto.ImportResult{
+		Created:           1,
+		CreatedCategories: []string{"Новая категория"},
+		Errors: []dto.ImportError{
+			{Row: 3, Reason: "product already exists"},
+			{Row: 4, Reason: "product with sku already exists"},
+		},
+	}, result)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ImportTestSuite) TestDryRunSuccessful() {
+	s.expectImport()
+
+	{
+		s.mock.ExpectRollback().WillReturnError(nil)
+	}
+
+	result, err := s.repository.Import(s.ctx, s.rows, true)
+
+	s.NoError(err)
+	s.True(result.DryRun)
+	s.Equal(1, result.Created)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ImportTestSuite) TestEmptySuccessful() {
+	result, err := s.repository.Import(s.ctx, []dto.ImportProduct{}, false)
+
+	s.NoError(err)
+	s.Equal(0, result.Created)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ImportTestSuite) TestGetExistingFailed() {
+	const (
+		expectedErrorMsg = "unknown error on importing products"
+	)
+
+	{
+		s.mock.ExpectBegin().WillReturnError(nil)
+	}
+
+	{
+		query, args, err := sq.
+			Select("name", "sku").
+			From("product").
+			Where(sq.Or{
+				sq.Expr("name = ANY(?)", pq.Array([]string{"Продукт"})),
+				sq.Expr("sku = ANY(?)", pq.Array([]string{"SKU-1"})),
+			}).
+			Where(sq.Eq{"deleted_at": nil}).
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnError(errors.New("connection reset"))
+	}
+
+	{
+		s.mock.ExpectRollback().WillReturnError(nil)
+	}
+
+	_, err := s.repository.Import(s.ctx, s.rows[:1], false)
+
+	s.NotNil(err)
+	s.Equal(expectedErrorMsg, err.Error())
+	s.NoError(s.mock.ExpectationsWereMet())
+}
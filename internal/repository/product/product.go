@@ -6,80 +6,83 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	pgerr "github.com/jackc/pgerrcode"
 	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/transaction"
 	errpkg "github.com/jackvonhouse/product-catalog/pkg/errors"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
+	"time"
 )
 
+const (
+	productSkuConstraint = "product_sku_unique"
+)
+
+var (
+	productColumns = []string{
+		"id", "name", "description", "price", "currency", "sku", "stock",
+		"created_at", "updated_at", "deleted_at", "version",
+	}
+)
+
+type productRow struct {
+	dto.Product
+
+	Total int `db:"total"`
+}
+
 type Repository struct {
 	logger log.Logger
 
-	db *sqlx.DB
+	db          *sqlx.DB
+	transaction transaction.Manager
+	audit       auditRepository
 }
 
 func New(
 	db *sqlx.DB,
+	audit auditRepository,
 	logger log.Logger,
 ) Repository {
 
 	return Repository{
-		logger: logger.WithField("unit", "product"),
-		db:     db,
+		logger:      logger.WithField("unit", "product"),
+		db:          db,
+		transaction: transaction.New(db, logger),
+		audit:       audit,
 	}
 }
 
 func (r Repository) Create(
 	ctx context.Context,
 	data dto.CreateProduct,
-	category dto.Category,
+	categories []dto.Category,
 ) (int, error) {
 
-	rollback := func(tx *sqlx.Tx) error {
-		if err := tx.Rollback(); err != nil {
-			r.logger.Warnf("unknown error on rollback: %s", err)
+	var productId int
 
-			return r.errInternalCreateProduct(err)
+	err := r.transaction.Run(ctx, r.errInternalCreateProduct, func(ctx context.Context, tx *sqlx.Tx) error {
+		id, err := r.createProduct(ctx, tx, data)
+		if err != nil {
+			return err
 		}
 
-		return nil
-	}
-
-	commit := func(tx *sqlx.Tx) error {
-		if err := tx.Commit(); err != nil {
-			r.logger.Warnf("unknown error on commit: %s", err)
-
-			return r.errInternalCreateProduct(err)
+		for _, category := range categories {
+			if err := r.attachProductToCategory(ctx, tx, id, category); err != nil {
+				return err
+			}
 		}
 
-		return nil
-	}
-
-	tx, err := r.db.BeginTxx(ctx, nil)
-	if err != nil {
-		r.logger.Warnf("unknown error on starting transaction: %s", err)
-
-		return 0, r.errInternalCreateProduct(err)
-	}
-
-	productId, err := r.createProduct(ctx, tx, data)
-	if err != nil {
-		if rErr := rollback(tx); rErr != nil {
-			return 0, rErr
+		if err := r.auditAfter(ctx, tx, id, nil); err != nil {
+			return err
 		}
 
-		return 0, err
-	}
-
-	if err := r.attachProductToCategory(ctx, tx, productId, category); err != nil {
-		if rErr := rollback(tx); rErr != nil {
-			return 0, rErr
-		}
+		productId = id
 
-		return 0, err
-	}
+		return nil
+	})
 
-	return productId, commit(tx)
+	return productId, err
 }
 
 func (r Repository) createProduct(
@@ -90,8 +93,12 @@ func (r Repository) createProduct(
 
 	query, args, err := sq.
 		Insert("product").
-		Columns("name").
-		Values(data.Name).
+		Columns("name", "description", "price", "currency", "sku", "stock").
+		Values(
+			data.Name, data.Description,
+			data.Price, data.Currency,
+			data.SKU, data.Stock,
+		).
 		Suffix("RETURNING id").
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
@@ -99,7 +106,11 @@ func (r Repository) createProduct(
 	logger := r.logger.WithFields(map[string]any{
 		"query": query,
 		"args": map[string]any{
-			"name": data.Name,
+			"name":     data.Name,
+			"price":    data.Price,
+			"currency": data.Currency,
+			"sku":      data.SKU,
+			"stock":    data.Stock,
 		},
 	})
 
@@ -116,10 +127,21 @@ func (r Repository) createProduct(
 			switch e.Code {
 
 			case pgerr.UniqueViolation:
+				if e.Constraint == productSkuConstraint {
+					logger.Warnf("product with sku already exists: %s", err)
+
+					return 0, r.errProductSkuAlreadyExists(err)
+				}
+
 				logger.Warnf("product already exists: %s", err)
 
 				return 0, r.errProductAlreadyExists(err)
 
+			case pgerr.CheckViolation:
+				logger.Warnf("invalid product data: %s", err)
+
+				return 0, r.errInvalidProduct(err)
+
 			default:
 				logger.Warnf("unknown error on creating product: %s", err)
 
@@ -197,54 +219,181 @@ func (r Repository) attachProductToCategory(
 	return nil
 }
 
+func (r Repository) AttachToCategory(
+	ctx context.Context,
+	product dto.Product,
+	category dto.Category,
+) error {
+
+	return r.transaction.Run(ctx, r.errInternalAttachProductToCategory, func(ctx context.Context, tx *sqlx.Tx) error {
+		return r.attachProductToCategory(ctx, tx, product.ID, category)
+	})
+}
+
+func (r Repository) DetachFromCategory(
+	ctx context.Context,
+	product dto.Product,
+	category dto.Category,
+) error {
+
+	return r.transaction.Run(ctx, r.errInternalDetachProductFromCategory, func(ctx context.Context, tx *sqlx.Tx) error {
+		return r.detachProductFromCategory(ctx, tx, product.ID, category)
+	})
+}
+
+func (r Repository) detachProductFromCategory(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	productId int,
+	category dto.Category,
+) error {
+
+	query, args, err := sq.
+		Delete("product_of_category").
+		Where(sq.And{
+			sq.Eq{"product_id": productId},
+			sq.Eq{"category_id": category.ID},
+		}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"product_id":  productId,
+			"category_id": category.ID,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return r.errInternalBuildSql(err)
+	}
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		logger.Warnf("unknown error on detaching product from category: %s", err)
+
+		return r.errInternalDetachProductFromCategory(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.Warnf("unknown error on detaching product from category: %s", err)
+
+		return r.errInternalDetachProductFromCategory(err)
+	}
+
+	if rowsAffected == 0 {
+		logger.Warnf("product in category not found")
+
+		return r.errNotFound("product in category", nil)
+	}
+
+	return nil
+}
+
 func (r Repository) Get(
 	ctx context.Context,
 	data dto.GetProduct,
-) ([]dto.Product, error) {
+) (dto.ProductPage, error) {
 
 	var (
 		offset = uint64(data.Offset)
 		limit  = uint64(data.Limit)
 	)
 
-	query, args, err := sq.
-		Select("*").
+	sortFields, err := r.sortFields(data.Sort)
+	if err != nil {
+		r.logger.Warn(err)
+
+		return dto.ProductPage{}, err
+	}
+
+	count := sq.
+		Select("COUNT(*)").
+		From("product")
+
+	filter := r.filter(data.Filter)
+	if !data.IncludeDeleted {
+		filter = append(filter, sq.Eq{"deleted_at": nil})
+	}
+
+	if len(filter) > 0 {
+		count = count.Where(filter)
+	}
+
+	builder := sq.
+		Select(productColumns...).
+		Column(sq.Alias(count, "total")).
 		From("product").
-		OrderBy("id ASC").
-		Offset(offset).
-		Limit(limit).
+		OrderBy(r.orderBy(sortFields)...).
+		Limit(limit)
+
+	if len(filter) > 0 {
+		builder = builder.Where(filter)
+	}
+
+	if len(data.After) > 0 {
+		keyset, err := r.keyset(sortFields, data.After)
+		if err != nil {
+			r.logger.Warn(err)
+
+			return dto.ProductPage{}, err
+		}
+
+		builder = builder.Where(keyset)
+	} else {
+		builder = builder.Offset(offset)
+	}
+
+	query, args, err := builder.
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 
 	logger := r.logger.WithFields(map[string]any{
 		"query": query,
 		"args": map[string]any{
-			"limit":  data.Limit,
-			"offset": data.Offset,
+			"limit":           data.Limit,
+			"offset":          data.Offset,
+			"after":           data.After,
+			"sort":            data.Sort,
+			"filter":          data.Filter,
+			"include_deleted": data.IncludeDeleted,
 		},
 	})
 
 	if err != nil {
 		logger.Warnf("unknown error on building sql query: %s", err)
 
-		return []dto.Product{}, r.errInternalBuildSql(err)
+		return dto.ProductPage{}, r.errInternalBuildSql(err)
 	}
 
-	products := make([]dto.Product, 0)
+	rows := make([]productRow, 0)
 
-	if err := r.db.SelectContext(ctx, &products, query, args...); err != nil {
+	if err := sqlx.SelectContext(ctx, r.transaction.Executor(ctx), &rows, query, args...); err != nil {
 		if !errpkg.Is(err, sql.ErrNoRows) {
 			logger.Warnf("unknown error on getting products: %s", err)
 
-			return []dto.Product{}, r.errInternalGetProducts(err)
+			return dto.ProductPage{}, r.errInternalGetProducts(err)
 		}
 
 		logger.Warnf("no products: %s", err)
 
-		return []dto.Product{}, r.errNotFound("products", err)
+		return dto.ProductPage{}, r.errNotFound("products", err)
 	}
 
-	return products, nil
+	page, err := r.toPage(ctx, rows, count, data)
+	if err != nil {
+		return dto.ProductPage{}, err
+	}
+
+	if len(page.Items) > 0 && len(page.Items) == data.Limit {
+		page.Last = r.sortValues(page.Items[len(page.Items)-1], sortFields)
+	}
+
+	return page, nil
 }
 
 func (r Repository) GetById(
@@ -253,9 +402,9 @@ func (r Repository) GetById(
 ) (dto.Product, error) {
 
 	query, args, err := sq.
-		Select("*").
+		Select(productColumns...).
 		From("product").
-		Where(sq.Eq{"id": id}).
+		Where(sq.Eq{"id": id, "deleted_at": nil}).
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 
@@ -274,7 +423,7 @@ func (r Repository) GetById(
 
 	product := dto.Product{}
 
-	if err := r.db.GetContext(ctx, &product, query, args...); err != nil {
+	if err := sqlx.GetContext(ctx, r.transaction.Executor(ctx), &product, query, args...); err != nil {
 		if !errpkg.Is(err, sql.ErrNoRows) {
 			logger.Warnf("unknown error on getting product: %s", err)
 
@@ -289,24 +438,35 @@ func (r Repository) GetById(
 	return product, nil
 }
 
-func (r Repository) GetByCategoryId(
+func (r Repository) Search(
 	ctx context.Context,
-	data dto.GetProduct,
-	category dto.Category,
-) ([]dto.Product, error) {
+	data dto.SearchProduct,
+) ([]dto.FoundProduct, error) {
 
 	var (
 		offset = uint64(data.Offset)
 		limit  = uint64(data.Limit)
 	)
 
+	columns := append(
+		r.columnsWithAlias("p"),
+		"ts_rank(p.search_vector, q.query) AS rank",
+	)
+
 	query, args, err := sq.
-		Select("p.*").
-		LeftJoin("product_of_category pc ON pc.product_id = p.id").
-		LeftJoin("category c ON c.id = pc.category_id").
+		Select(columns...).
+		Column(
+			"ts_headline('russian', p.name || ' ' || p.description, q.query, ?) AS snippet",
+			searchHeadlineOptions,
+		).
 		From("product p").
-		Where(sq.Eq{"pc.category_id": category.ID}).
-		OrderBy("id ASC").
+		Join(
+			"(SELECT websearch_to_tsquery('russian', ?) || websearch_to_tsquery('english', ?) AS query) q "+
+				"ON p.search_vector @@ q.query",
+			data.Query, data.Query,
+		).
+		Where(sq.Eq{"p.deleted_at": nil}).
+		OrderBy("rank DESC", "p.id ASC").
 		Offset(offset).
 		Limit(limit).
 		PlaceholderFormat(sq.Dollar).
@@ -315,30 +475,28 @@ func (r Repository) GetByCategoryId(
 	logger := r.logger.WithFields(map[string]any{
 		"query": query,
 		"args": map[string]any{
-			"limit":       data.Limit,
-			"offset":      data.Offset,
-			"category_id": category.ID,
+			"limit":        data.Limit,
+			"offset":       data.Offset,
+			"search_query": data.Query,
 		},
 	})
 
 	if err != nil {
 		logger.Warnf("unknown error on building sql query: %s", err)
 
-		return []dto.Product{}, r.errInternalBuildSql(err)
+		return []dto.FoundProduct{}, r.errInternalBuildSql(err)
 	}
 
-	products := make([]dto.Product, 0)
+	products := make([]dto.FoundProduct, 0)
 
-	if err := r.db.SelectContext(ctx, &products, query, args...); err != nil {
-		if !errpkg.Is(err, sql.ErrNoRows) {
-			logger.Warnf("unknown error on getting products: %s", err)
-
-			return []dto.Product{}, r.errInternalGetProducts(err)
-		}
+	if err := sqlx.SelectContext(ctx, r.transaction.Executor(ctx), &products, query, args...); err != nil {
+		logger.Warnf("unknown error on searching products: %s", err)
 
-		logger.Warnf("no products: %s", err)
+		return []dto.FoundProduct{}, r.errInternalSearchProducts(err)
+	}
 
-		return []dto.Product{}, r.errNotFound("products", err)
+	for i := range products {
+		products[i].Snippet = highlight(products[i].Snippet)
 	}
 
 	return products, nil
@@ -351,55 +509,35 @@ func (r Repository) Update(
 	category dto.Category,
 ) (int, error) {
 
-	rollback := func(tx *sqlx.Tx) error {
-		if err := tx.Rollback(); err != nil {
-			r.logger.Warnf("unknown error on rollback: %s", err)
+	var productId int
 
-			return r.errInternalUpdateProduct(err)
+	err := r.transaction.Run(ctx, r.errInternalUpdateProduct, func(ctx context.Context, tx *sqlx.Tx) error {
+		before, err := r.auditBefore(ctx, tx, product.ID)
+		if err != nil {
+			return err
 		}
 
-		return nil
-	}
-
-	commit := func(tx *sqlx.Tx) error {
-		if err := tx.Commit(); err != nil {
-			r.logger.Warnf("unknown error on commit: %s", err)
-
-			return r.errInternalUpdateProduct(err)
+		id, err := r.updateProduct(ctx, tx, data, product)
+		if err != nil {
+			return err
 		}
 
-		return nil
-	}
-
-	tx, err := r.db.BeginTxx(ctx, nil)
-	if err != nil {
-		r.logger.Warnf("unknown error on starting transaction: %s", err)
-
-		return 0, r.errInternalUpdateProduct(err)
-	}
-
-	productId, err := r.updateProduct(ctx, tx, data, product)
-	if err != nil {
-		if rErr := rollback(tx); rErr != nil {
-			return 0, rErr
+		if data.OldCategoryId != data.NewCategoryId {
+			if err := r.updateProductCategory(ctx, tx, data, category); err != nil {
+				return err
+			}
 		}
 
-		return productId, err
-	}
-
-	if data.OldCategoryId == data.NewCategoryId {
-		return productId, commit(tx)
-	}
-
-	if err := r.updateProductCategory(ctx, tx, data, category); err != nil {
-		if rErr := rollback(tx); rErr != nil {
-			return 0, rErr
+		if err := r.auditAfter(ctx, tx, id, before); err != nil {
+			return err
 		}
 
-		return 0, err
-	}
+		productId = id
+
+		return nil
+	})
 
-	return productId, commit(tx)
+	return productId, err
 }
 
 func (r Repository) updateProduct(
@@ -409,12 +547,24 @@ func (r Repository) updateProduct(
 	product dto.Product,
 ) (int, error) {
 
+	where := sq.Eq{"id": product.ID}
+	if data.Version > 0 {
+		where["version"] = data.Version
+	}
+
 	query, args, err := sq.
 		Update("product").
 		SetMap(map[string]any{
-			"name": data.Name,
+			"name":        data.Name,
+			"description": data.Description,
+			"price":       data.Price,
+			"currency":    data.Currency,
+			"sku":         data.SKU,
+			"stock":       data.Stock,
+			"version":     sq.Expr("version + 1"),
+			"updated_at":  sq.Expr("now()"),
 		}).
-		Where(sq.Eq{"id": product.ID}).
+		Where(where).
 		Suffix("RETURNING id").
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
@@ -422,11 +572,28 @@ func (r Repository) updateProduct(
 	logger := r.logger.WithFields(map[string]any{
 		"query": query,
 		"args": map[string]any{
-			"id": product.ID,
+			"id":      product.ID,
+			"version": data.Version,
 			"name": map[string]any{
 				"before": product.Name,
 				"after":  data.Name,
 			},
+			"price": map[string]any{
+				"before": product.Price,
+				"after":  data.Price,
+			},
+			"currency": map[string]any{
+				"before": product.Currency,
+				"after":  data.Currency,
+			},
+			"sku": map[string]any{
+				"before": product.SKU,
+				"after":  data.SKU,
+			},
+			"stock": map[string]any{
+				"before": product.Stock,
+				"after":  data.Stock,
+			},
 		},
 	})
 
@@ -440,6 +607,12 @@ func (r Repository) updateProduct(
 
 	if err := tx.GetContext(ctx, &productId, query, args...); err != nil {
 		if errpkg.Is(err, sql.ErrNoRows) {
+			if data.Version > 0 {
+				logger.Warnf("product has been modified: %s", err)
+
+				return 0, r.errProductModified(err)
+			}
+
 			logger.Warnf("product not found: %s", err)
 
 			return 0, r.errNotFound("product", err)
@@ -449,10 +622,21 @@ func (r Repository) updateProduct(
 			switch e.Code {
 
 			case pgerr.UniqueViolation:
+				if e.Constraint == productSkuConstraint {
+					logger.Warnf("product with sku already exists: %s", err)
+
+					return 0, r.errProductSkuAlreadyExists(err)
+				}
+
 				logger.Warnf("product already exists: %s", err)
 
 				return 0, r.errProductAlreadyExists(err)
 
+			case pgerr.CheckViolation:
+				logger.Warnf("invalid product data: %s", err)
+
+				return 0, r.errInvalidProduct(err)
+
 			case pgerr.ForeignKeyViolation:
 				table := r.extractTable(e.Detail)
 
@@ -559,11 +743,52 @@ func (r Repository) updateProductCategory(
 func (r Repository) Delete(
 	ctx context.Context,
 	product dto.Product,
+	version int,
 ) (int, error) {
 
+	var productId int
+
+	err := r.transaction.Run(ctx, r.errInternalDeleteProduct, func(ctx context.Context, tx *sqlx.Tx) error {
+		before, err := r.auditBefore(ctx, tx, product.ID)
+		if err != nil {
+			return err
+		}
+
+		id, err := r.deleteProduct(ctx, tx, product, version)
+		if err != nil {
+			return err
+		}
+
+		if err := r.auditAfter(ctx, tx, id, before); err != nil {
+			return err
+		}
+
+		productId = id
+
+		return nil
+	})
+
+	return productId, err
+}
+
+func (r Repository) deleteProduct(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	product dto.Product,
+	version int,
+) (int, error) {
+
+	where := sq.Eq{"id": product.ID, "deleted_at": nil}
+	if version > 0 {
+		where["version"] = version
+	}
+
 	query, args, err := sq.
-		Delete("product").
-		Where(sq.Eq{"id": product.ID}).
+		Update("product").
+		Set("deleted_at", sq.Expr("now()")).
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", sq.Expr("now()")).
+		Where(where).
 		Suffix("RETURNING id").
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
@@ -572,6 +797,7 @@ func (r Repository) Delete(
 		"query": query,
 		"args": map[string]any{
 			"product_id": product.ID,
+			"version":    version,
 		},
 	})
 
@@ -583,13 +809,19 @@ func (r Repository) Delete(
 
 	var productId int
 
-	if err := r.db.GetContext(ctx, &productId, query, args...); err != nil {
+	if err := tx.GetContext(ctx, &productId, query, args...); err != nil {
 		if !errpkg.Is(err, sql.ErrNoRows) {
 			logger.Warnf("unknown error on deleting product: %s", err)
 
 			return 0, r.errInternalDeleteProduct(err)
 		}
 
+		if version > 0 {
+			logger.Warnf("product has been modified: %s", err)
+
+			return 0, r.errProductModified(err)
+		}
+
 		logger.Warnf("product not found: %s", err)
 
 		return 0, r.errNotFound("product", err)
@@ -597,3 +829,211 @@ func (r Repository) Delete(
 
 	return productId, nil
 }
+
+func (r Repository) Restore(
+	ctx context.Context,
+	id int,
+) (int, error) {
+
+	var productId int
+
+	err := r.transaction.Run(ctx, r.errInternalRestoreProduct, func(ctx context.Context, tx *sqlx.Tx) error {
+		before, err := r.auditBefore(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		restoredId, err := r.restoreProduct(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := r.auditAfter(ctx, tx, restoredId, before); err != nil {
+			return err
+		}
+
+		productId = restoredId
+
+		return nil
+	})
+
+	return productId, err
+}
+
+func (r Repository) restoreProduct(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	id int,
+) (int, error) {
+
+	query, args, err := sq.
+		Update("product").
+		Set("deleted_at", nil).
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": id}).
+		Where(sq.NotEq{"deleted_at": nil}).
+		Suffix("RETURNING id").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"product_id": id,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return 0, r.errInternalBuildSql(err)
+	}
+
+	var productId int
+
+	if err := tx.GetContext(ctx, &productId, query, args...); err != nil {
+		if errpkg.Is(err, sql.ErrNoRows) {
+			logger.Warnf("deleted product not found: %s", err)
+
+			return 0, r.errNotFound("deleted product", err)
+		}
+
+		if e, ok := err.(*pq.Error); ok && e.Code == pgerr.UniqueViolation {
+			if e.Constraint == productSkuConstraint {
+				logger.Warnf("product with sku already exists: %s", err)
+
+				return 0, r.errProductSkuAlreadyExists(err)
+			}
+
+			logger.Warnf("product already exists: %s", err)
+
+			return 0, r.errProductAlreadyExists(err)
+		}
+
+		logger.Warnf("unknown error on restoring product: %s", err)
+
+		return 0, r.errInternalRestoreProduct(err)
+	}
+
+	return productId, nil
+}
+
+func (r Repository) Purge(
+	ctx context.Context,
+	deletedBefore time.Time,
+) (int64, error) {
+
+	query, args, err := sq.
+		Delete("product").
+		Where(sq.Lt{"deleted_at": deletedBefore}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"deleted_before": deletedBefore,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return 0, r.errInternalBuildSql(err)
+	}
+
+	result, err := r.transaction.Executor(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		logger.Warnf("unknown error on purging products: %s", err)
+
+		return 0, r.errInternalPurgeProducts(err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		logger.Warnf("unknown error on purging products: %s", err)
+
+		return 0, r.errInternalPurgeProducts(err)
+	}
+
+	return purged, nil
+}
+
+func (r Repository) columnsWithAlias(
+	alias string,
+) []string {
+
+	columns := make([]string, len(productColumns))
+
+	for i, column := range productColumns {
+		columns[i] = alias + "." + column
+	}
+
+	return columns
+}
+
+func (r Repository) toPage(
+	ctx context.Context,
+	rows []productRow,
+	count sq.SelectBuilder,
+	data dto.GetProduct,
+) (dto.ProductPage, error) {
+
+	page := dto.ProductPage{
+		Items: make([]dto.Product, 0, len(rows)),
+	}
+
+	for _, row := range rows {
+		page.Items = append(page.Items, row.Product)
+	}
+
+	if len(rows) > 0 {
+		page.Total = rows[0].Total
+
+		return page, nil
+	}
+
+	if data.Offset == 0 && data.AfterId == 0 && len(data.After) == 0 {
+		return page, nil
+	}
+
+	total, err := r.count(ctx, count)
+	if err != nil {
+		return dto.ProductPage{}, err
+	}
+
+	page.Total = total
+
+	return page, nil
+}
+
+func (r Repository) count(
+	ctx context.Context,
+	builder sq.SelectBuilder,
+) (int, error) {
+
+	query, args, err := builder.
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return 0, r.errInternalBuildSql(err)
+	}
+
+	var total int
+
+	if err := sqlx.GetContext(ctx, r.transaction.Executor(ctx), &total, query, args...); err != nil {
+		logger.Warnf("unknown error on counting products: %s", err)
+
+		return 0, r.errInternalCountProducts(err)
+	}
+
+	return total, nil
+}
@@ -0,0 +1,294 @@
+package product
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	sq "github.com/Masterminds/squirrel"
+	pgerr "github.com/jackc/pgerrcode"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/repository/audit"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type ProductOfCategoryTestSuite struct {
+	suite.Suite
+
+	// Вспомогательные параметры
+	ctx        context.Context
+	logger     log.Logger
+	repository Repository
+
+	// Входные параметры
+	product  dto.Product
+	category dto.Category
+
+	// Служебные параметры
+	db   *sqlx.DB
+	mock sqlmock.Sqlmock
+}
+
+func TestSuiteProductOfCategory(t *testing.T) {
+	suite.Run(t, &ProductOfCategoryTestSuite{})
+}
+
+func (s *ProductOfCategoryTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.logger = log.NewNullLogger()
+}
+
+func (s *ProductOfCategoryTestSuite) BeforeTest(_, _ string) {
+	db, mock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+	)
+	s.NoError(err)
+
+	s.setupDatabase(db).setupMock(mock).setupRepository()
+
+	// Входные значения по-умолчанию
+	s.setupCategory(1, "Категория").
+		setupProduct(1, "Продукт")
+}
+
+func (s *ProductOfCategoryTestSuite) setupDatabase(
+	db *sql.DB,
+) *ProductOfCategoryTestSuite {
+
+	wrappedDb := sqlx.NewDb(db, "sqlmock")
+
+	s.db = wrappedDb
+
+	return s
+}
+
+func (s *ProductOfCategoryTestSuite) setupMock(
+	mock sqlmock.Sqlmock,
+) *ProductOfCategoryTestSuite {
+
+	s.mock = mock
+
+	return s
+}
+
+func (s *ProductOfCategoryTestSuite) setupRepository() {
+	s.repository = New(s.db, audit.New(s.db, s.logger), s.logger)
+}
+
+func (s *ProductOfCategoryTestSuite) setupCategory(
+	id int,
+	name string,
+) *ProductOfCategoryTestSuite {
+
+	s.category = dto.Category{
+		ID:   id,
+		Name: name,
+	}
+
+	return s
+}
+
+func (s *ProductOfCategoryTestSuite) setupProduct(
+	id int,
+	name string,
+) *ProductOfCategoryTestSuite {
+
+	s.product = dto.Product{
+		ID:   id,
+		Name: name,
+	}
+
+	return s
+}
+
+func (s *ProductOfCategoryTestSuite) TestAttachSuccessful() {
+	{
+		s.mock.ExpectBegin().WillReturnError(nil)
+	}
+
+	{
+		query, args, err := sq.
+			Insert("product_of_category").
+			Columns("product_id", "category_id").
+			Values(s.product.ID, s.category.ID).
+			Suffix("RETURNING product_id").
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnRows(
+				s.mock.
+					NewRows([]string{"product_id"}).
+					AddRow(s.product.ID),
+			)
+	}
+
+	{
+		s.mock.ExpectCommit().WillReturnError(nil)
+	}
+
+	err := s.repository.AttachToCategory(s.ctx, s.product, s.category)
+
+	s.NoError(err)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ProductOfCategoryTestSuite) TestAttachFailed() {
+	testCases := []struct {
+		testName         string
+		expectedError    error
+		expectedErrorMsg string
+	}{
+		{
+			testName:         "Already exists",
+			expectedError:    &pq.Error{Code: pgerr.UniqueViolation},
+			expectedErrorMsg: "product in category already exists",
+		},
+		{
+			testName:         "Category not found",
+			expectedError:    &pq.Error{Code: pgerr.ForeignKeyViolation, Detail: `"category"`},
+			expectedErrorMsg: "category not found",
+		},
+		{
+			testName:         "Unknown error",
+			expectedError:    errors.New("unknown error"),
+			expectedErrorMsg: "unknown error on attaching product to category",
+		},
+	}
+
+	for _, testCase := range testCases {
+		s.Run(testCase.testName, func() {
+			{
+				s.mock.ExpectBegin().WillReturnError(nil)
+			}
+
+			{
+				query, args, err := sq.
+					Insert("product_of_category").
+					Columns("product_id", "category_id").
+					Values(s.product.ID, s.category.ID).
+					Suffix("RETURNING product_id").
+					PlaceholderFormat(sq.Dollar).
+					ToSql()
+
+				s.NoError(err)
+
+				s.mock.
+					ExpectQuery(query).
+					WithArgs(convertArgs(args)...).
+					WillReturnError(testCase.expectedError)
+			}
+
+			{
+				s.mock.ExpectRollback().WillReturnError(nil)
+			}
+
+			err := s.repository.AttachToCategory(s.ctx, s.product, s.category)
+
+			s.NotNil(err)
+			s.Equal(testCase.expectedErrorMsg, err.Error())
+			s.NoError(s.mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *ProductOfCategoryTestSuite) TestDetachSuccessful() {
+	{
+		s.mock.ExpectBegin().WillReturnError(nil)
+	}
+
+	{
+		query, args, err := sq.
+			Delete("product_of_category").
+			Where(sq.And{
+				sq.Eq{"product_id": s.product.ID},
+				sq.Eq{"category_id": s.category.ID},
+			}).
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectExec(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	{
+		s.mock.ExpectCommit().WillReturnError(nil)
+	}
+
+	err := s.repository.DetachFromCategory(s.ctx, s.product, s.category)
+
+	s.NoError(err)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ProductOfCategoryTestSuite) TestDetachFailed() {
+	testCases := []struct {
+		testName         string
+		expectedError    error
+		expectedErrorMsg string
+	}{
+		{
+			testName:         "Product in category not found",
+			expectedError:    nil,
+			expectedErrorMsg: "product in category not found",
+		},
+		{
+			testName:         "Unknown error",
+			expectedError:    errors.New("unknown error"),
+			expectedErrorMsg: "unknown error on detaching product from category",
+		},
+	}
+
+	for _, testCase := range testCases {
+		s.Run(testCase.testName, func() {
+			{
+				s.mock.ExpectBegin().WillReturnError(nil)
+			}
+
+			{
+				query, args, err := sq.
+					Delete("product_of_category").
+					Where(sq.And{
+						sq.Eq{"product_id": s.product.ID},
+						sq.Eq{"category_id": s.category.ID},
+					}).
+					PlaceholderFormat(sq.Dollar).
+					ToSql()
+
+				s.NoError(err)
+
+				expectation := s.mock.
+					ExpectExec(query).
+					WithArgs(convertArgs(args)...)
+
+				if testCase.expectedError != nil {
+					expectation.WillReturnError(testCase.expectedError)
+				} else {
+					expectation.WillReturnResult(sqlmock.NewResult(0, 0))
+				}
+			}
+
+			{
+				s.mock.ExpectRollback().WillReturnError(nil)
+			}
+
+			err := s.repository.DetachFromCategory(s.ctx, s.product, s.category)
+
+			s.NotNil(err)
+			s.Equal(testCase.expectedErrorMsg, err.Error())
+			s.NoError(s.mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package product
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	sq "github.com/Masterminds/squirrel"
+	pgerr "github.com/jackc/pgerrcode"
+	"github.com/jackvonhouse/product-catalog/internal/repository/audit"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type RestoreTestSuite struct {
+	suite.Suite
+
+	// Вспомогательные параметры
+	ctx        context.Context
+	logger     log.Logger
+	repository Repository
+
+	// Входные параметры
+	productId     int
+	deletedBefore time.Time
+
+	// Служебные параметры
+	db   *sqlx.DB
+	mock sqlmock.Sqlmock
+}
+
+func TestSuiteRestore(t *testing.T) {
+	suite.Run(t, &RestoreTestSuite{})
+}
+
+func (s *RestoreTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.logger = log.NewNullLogger()
+}
+
+func (s *RestoreTestSuite) BeforeTest(_, _ string) {
+	db, mock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+	)
+	s.NoError(err)
+
+	s.setupDatabase(db).setupMock(mock).setupRepository()
+
+	// Входные значения по-умолчанию
+	s.setupproductId(1).
+		setupDeletedBefore(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func (s *RestoreTestSuite) setupDatabase(
+	db *sql.DB,
+) *RestoreTestSuite {
+
+	wrappedDb := sqlx.NewDb(db, "sqlmock")
+
+	s.db = wrappedDb
+
+	return s
+}
+
+func (s *RestoreTestSuite) setupMock(
+	mock sqlmock.Sqlmock,
+) *RestoreTestSuite {
+
+	s.mock = mock
+
+	return s
+}
+
+func (s *RestoreTestSuite) setupRepository() {
+	s.repository = New(s.db, audit.New(s.db, s.logger), s.logger)
+}
+
+func (s *RestoreTestSuite) setupproductId(
+	id int,
+) *RestoreTestSuite {
+
+	s.productId = id
+
+	return s
+}
+
+func (s *RestoreTestSuite) setupDeletedBefore(
+	deletedBefore time.Time,
+) *RestoreTestSuite {
+
+	s.deletedBefore = deletedBefore
+
+	return s
+}
+
+func (s *RestoreTestSuite) restoreQuery() (string, []any) {
+	query, args, err := sq.
+		Update("product").
+		Set("deleted_at", nil).
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": s.productId}).
+		Where(sq.NotEq{"deleted_at": nil}).
+		Suffix("RETURNING id").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	s.NoError(err)
+
+	return query, args
+}
+
+func (s *RestoreTestSuite) TestRestoreSuccessful() {
+	{
+		s.mock.ExpectBegin().WillReturnError(nil)
+	}
+
+	{
+		query, args := s.restoreQuery()
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnRows(
+				s.mock.
+					NewRows([]string{"id"}).
+					AddRow(s.productId),
+			)
+	}
+
+	{
+		s.mock.ExpectCommit().WillReturnError(nil)
+	}
+
+	productId, err := s.repository.Restore(s.ctx, s.productId)
+
+	s.NoError(err)
+	s.Equal(s.productId, productId)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *RestoreTestSuite) TestRestoreFailed() {
+	const (
+		expectedErrorMsg         = "unknown error on restoring product"
+		expectedInternalErrorMsg = "unknown error"
+		expectedNotFoundErrorMsg = "deleted product not found"
+		expectedExistsErrorMsg   = "product already exists"
+	)
+
+	testCases := []struct {
+		testName         string
+		expectedError    error
+		expectedErrorMsg string
+	}{
+		{
+			testName:         "Not found",
+			expectedError:    sql.ErrNoRows,
+			expectedErrorMsg: expectedNotFoundErrorMsg,
+		},
+		{
+			testName:         "Already exists",
+			expectedError:    &pq.Error{Code: pgerr.UniqueViolation},
+			expectedErrorMsg: expectedExistsErrorMsg,
+		},
+		{
+			testName:         "Unknown error",
+			expectedError:    errors.New(expectedInternalErrorMsg),
+			expectedErrorMsg: expectedErrorMsg,
+		},
+	}
+
+	for _, testCase := range testCases {
+		s.Run(testCase.testName, func() {
+			s.mock.ExpectBegin().WillReturnError(nil)
+
+			query, args := s.restoreQuery()
+
+			s.mock.
+				ExpectQuery(query).
+				WithArgs(convertArgs(args)...).
+				WillReturnError(testCase.expectedError)
+
+			s.mock.ExpectRollback().WillReturnError(nil)
+
+			productId, err := s.repository.Restore(s.ctx, s.productId)
+
+			s.NotNil(err)
+			s.Equal(testCase.expectedErrorMsg, err.Error())
+			s.Equal(0, productId)
+			s.NoError(s.mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *RestoreTestSuite) TestPurgeSuccessful() {
+	query, args, err := sq.
+		Delete("product").
+		Where(sq.Lt{"deleted_at": s.deletedBefore}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	s.NoError(err)
+
+	s.mock.
+		ExpectExec(query).
+		WithArgs(convertArgs(args)...).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	purged, err := s.repository.Purge(s.ctx, s.deletedBefore)
+
+	s.NoError(err)
+	s.Equal(int64(2), purged)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *RestoreTestSuite) TestPurgeFailed() {
+	const (
+		expectedErrorMsg = "unknown error on purging products"
+	)
+
+	query, args, err := sq.
+		Delete("product").
+		Where(sq.Lt{"deleted_at": s.deletedBefore}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	s.NoError(err)
+
+	s.mock.
+		ExpectExec(query).
+		WithArgs(convertArgs(args)...).
+		WillReturnError(errors.New("unknown error"))
+
+	purged, err := s.repository.Purge(s.ctx, s.deletedBefore)
+
+	s.NotNil(err)
+	s.Equal(expectedErrorMsg, err.Error())
+	s.Equal(int64(0), purged)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
@@ -8,6 +8,7 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	pgerr "github.com/jackc/pgerrcode"
 	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/repository/audit"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
@@ -77,7 +78,7 @@ func (s *UpdateTestSuite) setupMock(
 }
 
 func (s *UpdateTestSuite) setupRepository() {
-	s.repository = New(s.db, s.logger)
+	s.repository = New(s.db, audit.New(s.db, s.logger), s.logger)
 }
 
 func (s *UpdateTestSuite) setupUpdate(
@@ -87,6 +88,11 @@ func (s *UpdateTestSuite) setupUpdate(
 	s.update = dto.UpdateProduct{
 		ID:            1,
 		Name:          name,
+		Description:   "Описание",
+		Price:         10000,
+		Currency:      "RUB",
+		SKU:           "SKU-1",
+		Stock:         10,
 		OldCategoryId: 1,
 		NewCategoryId: 2,
 	}
@@ -129,7 +135,14 @@ func (s *UpdateTestSuite) TestSuccessful() {
 		query, args, err := sq.
 			Update("product").
 			SetMap(map[string]any{
-				"name": s.update.Name,
+				"name":        s.update.Name,
+				"description": s.update.Description,
+				"price":       s.update.Price,
+				"currency":    s.update.Currency,
+				"sku":         s.update.SKU,
+				"stock":       s.update.Stock,
+				"version":     sq.Expr("version + 1"),
+				"updated_at":  sq.Expr("now()"),
 			}).
 			Where(sq.Eq{"id": s.update.OldCategoryId}).
 			Suffix("RETURNING id").
@@ -192,7 +205,14 @@ func (s *UpdateTestSuite) TestWithoutCategoryUpdateSuccessful() {
 		query, args, err := sq.
 			Update("product").
 			SetMap(map[string]any{
-				"name": s.update.Name,
+				"name":        s.update.Name,
+				"description": s.update.Description,
+				"price":       s.update.Price,
+				"currency":    s.update.Currency,
+				"sku":         s.update.SKU,
+				"stock":       s.update.Stock,
+				"version":     sq.Expr("version + 1"),
+				"updated_at":  sq.Expr("now()"),
 			}).
 			Where(sq.Eq{"id": s.product.ID}).
 			Suffix("RETURNING id").
@@ -222,6 +242,55 @@ func (s *UpdateTestSuite) TestWithoutCategoryUpdateSuccessful() {
 	s.NoError(s.mock.ExpectationsWereMet())
 }
 
+func (s *UpdateTestSuite) TestVersionConflictFailed() {
+	const (
+		expectedErrorMsg = "product has been modified"
+	)
+
+	s.update.Version = 2
+
+	{
+		s.mock.ExpectBegin().WillReturnError(nil)
+	}
+
+	{
+		query, args, err := sq.
+			Update("product").
+			SetMap(map[string]any{
+				"name":        s.update.Name,
+				"description": s.update.Description,
+				"price":       s.update.Price,
+				"currency":    s.update.Currency,
+				"sku":         s.update.SKU,
+				"stock":       s.update.Stock,
+				"version":     sq.Expr("version + 1"),
+				"updated_at":  sq.Expr("now()"),
+			}).
+			Where(sq.Eq{"id": s.product.ID, "version": s.update.Version}).
+			Suffix("RETURNING id").
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+
+		s.NoError(err)
+
+		s.mock.
+			ExpectQuery(query).
+			WithArgs(convertArgs(args)...).
+			WillReturnError(sql.ErrNoRows)
+	}
+
+	{
+		s.mock.ExpectRollback().WillReturnError(nil)
+	}
+
+	productId, err := s.repository.Update(s.ctx, s.update, s.product, s.category)
+
+	s.NotNil(err)
+	s.Equal(expectedErrorMsg, err.Error())
+	s.Equal(0, productId)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
 func (s *UpdateTestSuite) TestBeginFailed() {
 	const (
 		expectedBeginErrorMsg = "unknown error on begin"
@@ -298,7 +367,14 @@ func (s *UpdateTestSuite) TestUpdateProductFailed() {
 				query, args, err := sq.
 					Update("product").
 					SetMap(map[string]any{
-						"name": s.update.Name,
+						"name":        s.update.Name,
+						"description": s.update.Description,
+						"price":       s.update.Price,
+						"currency":    s.update.Currency,
+						"sku":         s.update.SKU,
+						"stock":       s.update.Stock,
+						"version":     sq.Expr("version + 1"),
+						"updated_at":  sq.Expr("now()"),
 					}).
 					Where(sq.Eq{"id": s.product.ID}).
 					Suffix("RETURNING id").
@@ -392,7 +468,14 @@ func (s *UpdateTestSuite) TestUpdateProductInCategoryFailed() {
 				query, args, err := sq.
 					Update("product").
 					SetMap(map[string]any{
-						"name": s.update.Name,
+						"name":        s.update.Name,
+						"description": s.update.Description,
+						"price":       s.update.Price,
+						"currency":    s.update.Currency,
+						"sku":         s.update.SKU,
+						"stock":       s.update.Stock,
+						"version":     sq.Expr("version + 1"),
+						"updated_at":  sq.Expr("now()"),
 					}).
 					Where(sq.Eq{"id": s.product.ID}).
 					Suffix("RETURNING id").
@@ -460,7 +543,14 @@ func (s *UpdateTestSuite) TestUpdateProductRollbackFailed() {
 		query, args, err := sq.
 			Update("product").
 			SetMap(map[string]any{
-				"name": s.update.Name,
+				"name":        s.update.Name,
+				"description": s.update.Description,
+				"price":       s.update.Price,
+				"currency":    s.update.Currency,
+				"sku":         s.update.SKU,
+				"stock":       s.update.Stock,
+				"version":     sq.Expr("version + 1"),
+				"updated_at":  sq.Expr("now()"),
 			}).
 			Where(sq.Eq{"id": s.product.ID}).
 			Suffix("RETURNING id").
@@ -504,7 +594,14 @@ func (s *UpdateTestSuite) TestUpdateProductInCategoryRollbackFailed() {
 		query, args, err := sq.
 			Update("product").
 			SetMap(map[string]any{
-				"name": s.update.Name,
+				"name":        s.update.Name,
+				"description": s.update.Description,
+				"price":       s.update.Price,
+				"currency":    s.update.Currency,
+				"sku":         s.update.SKU,
+				"stock":       s.update.Stock,
+				"version":     sq.Expr("version + 1"),
+				"updated_at":  sq.Expr("now()"),
 			}).
 			Where(sq.Eq{"id": s.product.ID}).
 			Suffix("RETURNING id").
@@ -575,7 +672,14 @@ func (s *UpdateTestSuite) TestWithoutCategoryUpdateCommitFailed() {
 		query, args, err := sq.
 			Update("product").
 			SetMap(map[string]any{
-				"name": s.update.Name,
+				"name":        s.update.Name,
+				"description": s.update.Description,
+				"price":       s.update.Price,
+				"currency":    s.update.Currency,
+				"sku":         s.update.SKU,
+				"stock":       s.update.Stock,
+				"version":     sq.Expr("version + 1"),
+				"updated_at":  sq.Expr("now()"),
 			}).
 			Where(sq.Eq{"id": s.product.ID}).
 			Suffix("RETURNING id").
@@ -19,6 +19,20 @@ func (r Repository) errInternalGetUser(
 	return errors.ErrInternal("getting", "user", err)
 }
 
+func (r Repository) errInternalCountUsers(
+	err error,
+) error {
+
+	return errors.ErrInternal("counting", "users", err)
+}
+
+func (r Repository) errInternalUpdateUser(
+	err error,
+) error {
+
+	return errors.ErrInternal("updating", "user", err)
+}
+
 func (r Repository) errInternalBuildSql(
 	err error,
 ) error {
@@ -40,6 +54,13 @@ func (r Repository) errUserAlreadyExists(
 	return errors.ErrAlreadyExists("user", err)
 }
 
+func (r Repository) errInvalidUser(
+	err error,
+) error {
+
+	return errors.ErrInvalid("user", err)
+}
+
 func (r Repository) errProductInCategoryAlreadyExists(
 	err error,
 ) error {
@@ -6,6 +6,7 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	pgerr "github.com/jackc/pgerrcode"
 	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/transaction"
 	errpkg "github.com/jackvonhouse/product-catalog/pkg/errors"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 	"github.com/jmoiron/sqlx"
@@ -15,7 +16,8 @@ import (
 type Repository struct {
 	logger log.Logger
 
-	db *sqlx.DB
+	db          *sqlx.DB
+	transaction transaction.Manager
 }
 
 func New(
@@ -24,8 +26,9 @@ func New(
 ) Repository {
 
 	return Repository{
-		logger: logger.WithField("unit", "user"),
-		db:     db,
+		logger:      logger.WithField("unit", "user"),
+		db:          db,
+		transaction: transaction.New(db, logger),
 	}
 }
 
@@ -36,8 +39,8 @@ func (r Repository) Create(
 
 	query, args, err := sq.
 		Insert(`"user"`).
-		Columns("username", "password").
-		Values(credentials.Username, credentials.Password).
+		Columns("username", "password", "role").
+		Values(credentials.Username, credentials.Password, credentials.Role).
 		Suffix("RETURNING id").
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
@@ -47,6 +50,7 @@ func (r Repository) Create(
 		"args": map[string]any{
 			"username": credentials.Username,
 			"password": "***",
+			"role":     credentials.Role,
 		},
 	})
 
@@ -58,7 +62,7 @@ func (r Repository) Create(
 
 	var userId int
 
-	if err := r.db.GetContext(ctx, &userId, query, args...); err != nil {
+	if err := sqlx.GetContext(ctx, r.transaction.Executor(ctx), &userId, query, args...); err != nil {
 		if e, ok := err.(*pq.Error); ok {
 			switch e.Code {
 
@@ -109,7 +113,7 @@ func (r Repository) GetByUsername(
 
 	user := dto.User{}
 
-	if err := r.db.GetContext(ctx, &user, query, args...); err != nil {
+	if err := sqlx.GetContext(ctx, r.transaction.Executor(ctx), &user, query, args...); err != nil {
 		if !errpkg.Is(err, sql.ErrNoRows) {
 			logger.Warnf("unknown error on getting user: %s", err)
 
@@ -123,3 +127,125 @@ func (r Repository) GetByUsername(
 
 	return user, nil
 }
+
+func (r Repository) CountByRole(
+	ctx context.Context,
+	role string,
+) (int, error) {
+
+	query, args, err := sq.
+		Select("COUNT(*)").
+		From(`"user"`).
+		Where(sq.Eq{"role": role}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"role": role,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return 0, r.errInternalBuildSql(err)
+	}
+
+	var count int
+
+	if err := sqlx.GetContext(ctx, r.transaction.Executor(ctx), &count, query, args...); err != nil {
+		logger.Warnf("unknown error on counting users: %s", err)
+
+		return 0, r.errInternalCountUsers(err)
+	}
+
+	return count, nil
+}
+
+// LockRole блокирует создание пользователей с ролью до конца транзакции.
+// Под блокировкой проверка на существование и вставка не пересекаются
+// с параллельным вызовом
+func (r Repository) LockRole(
+	ctx context.Context,
+	role string,
+) error {
+
+	query, args, err := sq.
+		Select().
+		Column("pg_advisory_xact_lock(hashtext(?))", "user_role:"+role).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"role": role,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return r.errInternalBuildSql(err)
+	}
+
+	if _, err := r.transaction.Executor(ctx).ExecContext(ctx, query, args...); err != nil {
+		logger.Warnf("unknown error on locking user role: %s", err)
+
+		return r.errInternalUpdateUser(err)
+	}
+
+	return nil
+}
+
+func (r Repository) UpdateRole(
+	ctx context.Context,
+	data dto.UpdateRole,
+) error {
+
+	query, args, err := sq.
+		Update(`"user"`).
+		Set("role", data.Role).
+		Where(sq.Eq{"id": data.UserId}).
+		Suffix("RETURNING id").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	logger := r.logger.WithFields(map[string]any{
+		"query": query,
+		"args": map[string]any{
+			"user_id": data.UserId,
+			"role":    data.Role,
+		},
+	})
+
+	if err != nil {
+		logger.Warnf("unknown error on building sql query: %s", err)
+
+		return r.errInternalBuildSql(err)
+	}
+
+	var userId int
+
+	if err := sqlx.GetContext(ctx, r.transaction.Executor(ctx), &userId, query, args...); err != nil {
+		if errpkg.Is(err, sql.ErrNoRows) {
+			logger.Warnf("user not found: %s", err)
+
+			return r.errNotFound("user", err)
+		}
+
+		if e, ok := err.(*pq.Error); ok && e.Code == pgerr.CheckViolation {
+			logger.Warnf("invalid user role: %s", err)
+
+			return r.errInvalidUser(err)
+		}
+
+		logger.Warnf("unknown error on updating user role: %s", err)
+
+		return r.errInternalUpdateUser(err)
+	}
+
+	return nil
+}
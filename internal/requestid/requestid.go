@@ -0,0 +1,36 @@
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+type contextKey struct{}
+
+func New() string {
+	id := make([]byte, 16)
+
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(id)
+}
+
+func WithContext(
+	ctx context.Context,
+	requestId string,
+) context.Context {
+
+	return context.WithValue(ctx, contextKey{}, requestId)
+}
+
+func FromContext(
+	ctx context.Context,
+) string {
+
+	requestId, _ := ctx.Value(contextKey{}).(string)
+
+	return requestId
+}
@@ -0,0 +1,36 @@
+package audit
+
+import (
+	"context"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+)
+
+type repository interface {
+	Get(context.Context, dto.GetAudit) (dto.AuditPage, error)
+}
+
+type Service struct {
+	repository repository
+
+	logger log.Logger
+}
+
+func New(
+	repository repository,
+	logger log.Logger,
+) Service {
+
+	return Service{
+		repository: repository,
+		logger:     logger.WithField("unit", "audit"),
+	}
+}
+
+func (s Service) Get(
+	ctx context.Context,
+	data dto.GetAudit,
+) (dto.AuditPage, error) {
+
+	return s.repository.Get(ctx, data)
+}
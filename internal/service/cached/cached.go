@@ -0,0 +1,188 @@
+package cached
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/jackvonhouse/product-catalog/config"
+	"github.com/jackvonhouse/product-catalog/internal/transaction"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	productIdPrefix   = "product:id:"
+	productListPrefix = "product:list:"
+
+	categoryIdPrefix   = "category:id:"
+	categoryListPrefix = "category:list:"
+	categoryTreeKey    = "category:tree"
+)
+
+type storage interface {
+	Get(context.Context, string) ([]byte, bool, error)
+	Set(context.Context, string, []byte, time.Duration) error
+	Delete(context.Context, ...string) error
+	DeleteByPrefix(context.Context, string) error
+}
+
+type Stats struct {
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+}
+
+type counters struct {
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+type cache struct {
+	storage     storage
+	ttl         time.Duration
+	notFoundTTL time.Duration
+	counters    *counters
+
+	logger log.Logger
+}
+
+func newCache(
+	storage storage,
+	config config.Cache,
+	logger log.Logger,
+) cache {
+
+	return cache{
+		storage:     storage,
+		ttl:         time.Duration(config.TTL) * time.Second,
+		notFoundTTL: time.Duration(config.NotFoundTTL) * time.Second,
+		counters:    &counters{},
+		logger:      logger,
+	}
+}
+
+func (c cache) enabled() bool {
+	return c.ttl > 0
+}
+
+func (c cache) stats() Stats {
+	return Stats{
+		Hits:   c.counters.hits.Load(),
+		Misses: c.counters.misses.Load(),
+	}
+}
+
+// get возвращает found = true и для закэшированного отсутствия записи,
+// тогда notFound = true, а value не заполняется. Внутри транзакции кэш
+// не используется: он не видит её изменений до фиксации
+func (c cache) get(
+	ctx context.Context,
+	key string,
+	value any,
+) (found, notFound bool) {
+
+	if transaction.InProgress(ctx) {
+		return false, false
+	}
+
+	data, ok, err := c.storage.Get(ctx, key)
+	if err != nil {
+		c.logger.Warnf("can't get %s from cache: %s", key, err)
+	}
+
+	if err != nil || !ok {
+		c.counters.misses.Add(1)
+
+		return false, false
+	}
+
+	if len(data) == 0 {
+		c.counters.hits.Add(1)
+
+		return true, true
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(value); err != nil {
+		c.logger.Warnf("can't decode %s from cache: %s", key, err)
+		c.counters.misses.Add(1)
+
+		return false, false
+	}
+
+	c.counters.hits.Add(1)
+
+	return true, false
+}
+
+func (c cache) set(
+	ctx context.Context,
+	key string,
+	value any,
+) {
+
+	// Незафиксированные данные могут быть отменены
+	if transaction.InProgress(ctx) {
+		return
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		c.logger.Warnf("can't encode %s for cache: %s", key, err)
+
+		return
+	}
+
+	if err := c.storage.Set(ctx, key, data, c.ttl); err != nil {
+		c.logger.Warnf("can't set %s to cache: %s", key, err)
+	}
+}
+
+func (c cache) setNotFound(
+	ctx context.Context,
+	key string,
+) {
+
+	if c.notFoundTTL <= 0 || transaction.InProgress(ctx) {
+		return
+	}
+
+	if err := c.storage.Set(ctx, key, []byte{}, c.notFoundTTL); err != nil {
+		c.logger.Warnf("can't set %s to cache: %s", key, err)
+	}
+}
+
+func (c cache) invalidate(
+	ctx context.Context,
+	keys []string,
+	prefixes ...string,
+) {
+
+	// Иначе между сбросом и фиксацией кэш снова заполнится старыми данными
+	transaction.AfterCommit(ctx, func() {
+		if err := c.storage.Delete(ctx, keys...); err != nil {
+			c.logger.Warnf("can't delete %v from cache: %s", keys, err)
+		}
+
+		for _, prefix := range prefixes {
+			if err := c.storage.DeleteByPrefix(ctx, prefix); err != nil {
+				c.logger.Warnf("can't delete %s* from cache: %s", prefix, err)
+			}
+		}
+	})
+}
+
+func paramsKey(
+	prefix string,
+	params any,
+) string {
+
+	data, _ := json.Marshal(params)
+	hash := sha256.Sum256(data)
+
+	return prefix + hex.EncodeToString(hash[:16])
+}
@@ -0,0 +1,215 @@
+package cached
+
+import (
+	"context"
+	"github.com/jackvonhouse/product-catalog/config"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/errors"
+	errpkg "github.com/jackvonhouse/product-catalog/pkg/errors"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"strconv"
+	"time"
+)
+
+type categoryService interface {
+	Create(context.Context, dto.CreateCategory) (int, error)
+
+	Get(context.Context, dto.GetCategory) (dto.CategoryPage, error)
+	GetById(context.Context, int) (dto.Category, error)
+	GetChildren(context.Context, dto.Category) ([]dto.Category, error)
+	GetAncestors(context.Context, dto.Category) ([]dto.Category, error)
+	GetTree(context.Context) ([]dto.CategoryTree, error)
+	GetByProductId(context.Context, dto.Product) ([]dto.Category, error)
+
+	Update(context.Context, dto.UpdateCategory) (int, error)
+
+	Delete(context.Context, int, int) (int, error)
+	Restore(context.Context, int) (int, error)
+	Purge(context.Context, time.Time) (int64, error)
+}
+
+type Category struct {
+	categoryService
+
+	cache cache
+}
+
+func NewCategory(
+	service categoryService,
+	storage storage,
+	config config.Cache,
+	logger log.Logger,
+) Category {
+
+	return Category{
+		categoryService: service,
+		cache:           newCache(storage, config, logger.WithField("unit", "category")),
+	}
+}
+
+func (c Category) Stats() Stats { return c.cache.stats() }
+
+func (c Category) Create(
+	ctx context.Context,
+	data dto.CreateCategory,
+) (int, error) {
+
+	categoryId, err := c.categoryService.Create(ctx, data)
+	if err != nil {
+		return 0, err
+	}
+
+	c.invalidate(ctx, categoryId)
+
+	return categoryId, nil
+}
+
+func (c Category) Get(
+	ctx context.Context,
+	data dto.GetCategory,
+) (dto.CategoryPage, error) {
+
+	if !c.cache.enabled() || data.IncludeDeleted {
+		return c.categoryService.Get(ctx, data)
+	}
+
+	key := paramsKey(categoryListPrefix, data)
+
+	page := dto.CategoryPage{}
+
+	if found, notFound := c.cache.get(ctx, key, &page); found && !notFound {
+		return page, nil
+	}
+
+	page, err := c.categoryService.Get(ctx, data)
+	if err != nil {
+		return dto.CategoryPage{}, err
+	}
+
+	c.cache.set(ctx, key, page)
+
+	return page, nil
+}
+
+func (c Category) GetById(
+	ctx context.Context,
+	id int,
+) (dto.Category, error) {
+
+	if !c.cache.enabled() {
+		return c.categoryService.GetById(ctx, id)
+	}
+
+	key := categoryIdPrefix + strconv.Itoa(id)
+
+	category := dto.Category{}
+
+	if found, notFound := c.cache.get(ctx, key, &category); found {
+		if notFound {
+			return dto.Category{}, errors.ErrNotFound.New("category not found")
+		}
+
+		return category, nil
+	}
+
+	category, err := c.categoryService.GetById(ctx, id)
+	if err != nil {
+		if errpkg.Has(err, errors.ErrNotFound) {
+			c.cache.setNotFound(ctx, key)
+		}
+
+		return dto.Category{}, err
+	}
+
+	c.cache.set(ctx, key, category)
+
+	return category, nil
+}
+
+func (c Category) GetTree(
+	ctx context.Context,
+) ([]dto.CategoryTree, error) {
+
+	if !c.cache.enabled() {
+		return c.categoryService.GetTree(ctx)
+	}
+
+	tree := make([]dto.CategoryTree, 0)
+
+	if found, notFound := c.cache.get(ctx, categoryTreeKey, &tree); found && !notFound {
+		return tree, nil
+	}
+
+	tree, err := c.categoryService.GetTree(ctx)
+	if err != nil {
+		return []dto.CategoryTree{}, err
+	}
+
+	c.cache.set(ctx, categoryTreeKey, tree)
+
+	return tree, nil
+}
+
+func (c Category) Update(
+	ctx context.Context,
+	data dto.UpdateCategory,
+) (int, error) {
+
+	categoryId, err := c.categoryService.Update(ctx, data)
+	if err != nil {
+		return 0, err
+	}
+
+	c.invalidate(ctx, data.ID)
+
+	return categoryId, nil
+}
+
+func (c Category) Delete(
+	ctx context.Context,
+	id int,
+	version int,
+) (int, error) {
+
+	categoryId, err := c.categoryService.Delete(ctx, id, version)
+	if err != nil {
+		return 0, err
+	}
+
+	c.invalidate(ctx, id)
+
+	return categoryId, nil
+}
+
+func (c Category) Restore(
+	ctx context.Context,
+	id int,
+) (int, error) {
+
+	categoryId, err := c.categoryService.Restore(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	c.invalidate(ctx, id)
+
+	return categoryId, nil
+}
+
+// invalidate также сбрасывает списки товаров: фильтр по категории
+// с потомками зависит от дерева категорий и удалённых категорий
+func (c Category) invalidate(
+	ctx context.Context,
+	categoryId int,
+) {
+
+	if !c.cache.enabled() {
+		return
+	}
+
+	c.cache.invalidate(
+		ctx,
+		[]string{categoryIdPrefix + strconv.Itoa(categoryId), categoryTreeKey},
+		categoryListPrefix, productListPrefix,
+	)
+}
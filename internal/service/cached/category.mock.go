@@ -0,0 +1,207 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/cached/category.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/cached/category.go -destination=internal/service/cached/category.mock.go -package=cached
+//
+
+// Package cached is a generated GoMock package.
+package cached
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	dto "github.com/jackvonhouse/product-catalog/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockcategoryService is a mock of categoryService interface.
+type MockcategoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockcategoryServiceMockRecorder
+}
+
+// MockcategoryServiceMockRecorder is the mock recorder for MockcategoryService.
+type MockcategoryServiceMockRecorder struct {
+	mock *MockcategoryService
+}
+
+// NewMockcategoryService creates a new mock instance.
+func NewMockcategoryService(ctrl *gomock.Controller) *MockcategoryService {
+	mock := &MockcategoryService{ctrl: ctrl}
+	mock.recorder = &MockcategoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcategoryService) EXPECT() *MockcategoryServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockcategoryService) Create(arg0 context.Context, arg1 dto.CreateCategory) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockcategoryServiceMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockcategoryService)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockcategoryService) Delete(arg0 context.Context, arg1, arg2 int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockcategoryServiceMockRecorder) Delete(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockcategoryService)(nil).Delete), arg0, arg1, arg2)
+}
+
+// Get mocks base method.
+func (m *MockcategoryService) Get(arg0 context.Context, arg1 dto.GetCategory) (dto.CategoryPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(dto.CategoryPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockcategoryServiceMockRecorder) Get(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockcategoryService)(nil).Get), arg0, arg1)
+}
+
+// GetAncestors mocks base method.
+func (m *MockcategoryService) GetAncestors(arg0 context.Context, arg1 dto.Category) ([]dto.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAncestors", arg0, arg1)
+	ret0, _ := ret[0].([]dto.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAncestors indicates an expected call of GetAncestors.
+func (mr *MockcategoryServiceMockRecorder) GetAncestors(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAncestors", reflect.TypeOf((*MockcategoryService)(nil).GetAncestors), arg0, arg1)
+}
+
+// GetById mocks base method.
+func (m *MockcategoryService) GetById(arg0 context.Context, arg1 int) (dto.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", arg0, arg1)
+	ret0, _ := ret[0].(dto.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockcategoryServiceMockRecorder) GetById(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockcategoryService)(nil).GetById), arg0, arg1)
+}
+
+// GetByProductId mocks base method.
+func (m *MockcategoryService) GetByProductId(arg0 context.Context, arg1 dto.Product) ([]dto.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProductId", arg0, arg1)
+	ret0, _ := ret[0].([]dto.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProductId indicates an expected call of GetByProductId.
+func (mr *MockcategoryServiceMockRecorder) GetByProductId(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProductId", reflect.TypeOf((*MockcategoryService)(nil).GetByProductId), arg0, arg1)
+}
+
+// GetChildren mocks base method.
+func (m *MockcategoryService) GetChildren(arg0 context.Context, arg1 dto.Category) ([]dto.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChildren", arg0, arg1)
+	ret0, _ := ret[0].([]dto.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChildren indicates an expected call of GetChildren.
+func (mr *MockcategoryServiceMockRecorder) GetChildren(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChildren", reflect.TypeOf((*MockcategoryService)(nil).GetChildren), arg0, arg1)
+}
+
+// GetTree mocks base method.
+func (m *MockcategoryService) GetTree(arg0 context.Context) ([]dto.CategoryTree, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTree", arg0)
+	ret0, _ := ret[0].([]dto.CategoryTree)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTree indicates an expected call of GetTree.
+func (mr *MockcategoryServiceMockRecorder) GetTree(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTree", reflect.TypeOf((*MockcategoryService)(nil).GetTree), arg0)
+}
+
+// Purge mocks base method.
+func (m *MockcategoryService) Purge(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockcategoryServiceMockRecorder) Purge(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockcategoryService)(nil).Purge), arg0, arg1)
+}
+
+// Restore mocks base method.
+func (m *MockcategoryService) Restore(arg0 context.Context, arg1 int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockcategoryServiceMockRecorder) Restore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockcategoryService)(nil).Restore), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockcategoryService) Update(arg0 context.Context, arg1 dto.UpdateCategory) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockcategoryServiceMockRecorder) Update(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockcategoryService)(nil).Update), arg0, arg1)
+}
@@ -0,0 +1,197 @@
+package cached
+
+import (
+	"context"
+	"github.com/jackvonhouse/product-catalog/config"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	cachedb "github.com/jackvonhouse/product-catalog/internal/infrastructure/cache"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+type CategoryTestSuite struct {
+	suite.Suite
+
+	// Вспомогательные параметры
+	ctx     context.Context
+	logger  log.Logger
+	config  config.Cache
+	storage cachedb.Database
+	service Category
+	product Product
+
+	// Входные параметры
+	get      dto.GetProduct
+	category dto.Category
+
+	// Служебные параметры
+	mock        *MockcategoryService
+	productMock *MockproductService
+}
+
+func TestSuiteCategory(t *testing.T) {
+	suite.Run(t, &CategoryTestSuite{})
+}
+
+func (s *CategoryTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.logger = log.NewNullLogger()
+	s.config = config.Cache{
+		ExpireDuration:  1,
+		CleanupInterval: 1,
+		TTL:             60,
+		NotFoundTTL:     10,
+	}
+}
+
+func (s *CategoryTestSuite) BeforeTest(_, _ string) {
+	controller := gomock.NewController(s.T())
+
+	s.setupMock(controller).setupStorage().setupService()
+
+	// Входные значения по-умолчанию
+	s.setupCategory(1, "Категория")
+	s.get = dto.GetProduct{Limit: 10}
+}
+
+func (s *CategoryTestSuite) setupMock(
+	controller *gomock.Controller,
+) *CategoryTestSuite {
+
+	s.mock = NewMockcategoryService(controller)
+	s.productMock = NewMockproductService(controller)
+
+	return s
+}
+
+func (s *CategoryTestSuite) setupStorage() *CategoryTestSuite {
+	storage, err := cachedb.New(s.ctx, s.config, s.logger)
+	s.NoError(err)
+
+	s.storage = storage
+
+	return s
+}
+
+func (s *CategoryTestSuite) setupService() {
+	s.service = NewCategory(s.mock, s.storage, s.config, s.logger)
+	s.product = NewProduct(s.productMock, s.storage, s.config, s.logger)
+}
+
+func (s *CategoryTestSuite) setupCategory(
+	id int,
+	name string,
+) *CategoryTestSuite {
+
+	s.category = dto.Category{
+		ID:   id,
+		Name: name,
+	}
+
+	return s
+}
+
+func (s *CategoryTestSuite) TestGetTreeSuccessful() {
+	tree := []dto.CategoryTree{
+		{Category: s.category, Children: []dto.CategoryTree{}},
+	}
+
+	s.mock.
+		EXPECT().
+		GetTree(s.ctx).
+		Return(tree, nil).
+		Times(1)
+
+	for range 2 {
+		result, err := s.service.GetTree(s.ctx)
+
+		s.NoError(err)
+		s.Equal(tree, result)
+	}
+
+	s.Equal(Stats{Hits: 1, Misses: 1}, s.service.Stats())
+}
+
+func (s *CategoryTestSuite) TestDeleteInvalidatesProducts() {
+	get := s.get
+	get.Filter = dto.ProductFilter{
+		CategoryIds:        []int{s.category.ID},
+		IncludeDescendants: true,
+	}
+
+	page := dto.ProductPage{
+		Items: []dto.Product{{ID: 1, Name: "Продукт"}},
+		Total: 1,
+	}
+
+	s.productMock.
+		EXPECT().
+		Get(s.ctx, get).
+		Return(page, nil).
+		Times(2)
+
+	s.mock.
+		EXPECT().
+		Delete(s.ctx, s.category.ID, 0).
+		Return(s.category.ID, nil).
+		Times(1)
+
+	_, err := s.product.Get(s.ctx, get)
+	s.NoError(err)
+
+	categoryId, err := s.service.Delete(s.ctx, s.category.ID, 0)
+	s.NoError(err)
+	s.Equal(s.category.ID, categoryId)
+
+	_, err = s.product.Get(s.ctx, get)
+	s.NoError(err)
+
+	s.Equal(Stats{Misses: 2}, s.product.Stats())
+}
+
+func (s *CategoryTestSuite) TestUpdateInvalidatesProductList() {
+	parentId := 2
+
+	get := s.get
+	get.Filter = dto.ProductFilter{
+		CategoryIds:        []int{parentId},
+		IncludeDescendants: true,
+	}
+
+	update := dto.UpdateCategory{
+		ID:       s.category.ID,
+		Name:     s.category.Name,
+		ParentId: &parentId,
+	}
+
+	page := dto.ProductPage{
+		Items: []dto.Product{{ID: 1, Name: "Продукт"}},
+		Total: 1,
+	}
+
+	s.productMock.
+		EXPECT().
+		Get(s.ctx, get).
+		Return(page, nil).
+		Times(2)
+
+	s.mock.
+		EXPECT().
+		Update(s.ctx, update).
+		Return(s.category.ID, nil).
+		Times(1)
+
+	_, err := s.product.Get(s.ctx, get)
+	s.NoError(err)
+
+	categoryId, err := s.service.Update(s.ctx, update)
+	s.NoError(err)
+	s.Equal(s.category.ID, categoryId)
+
+	_, err = s.product.Get(s.ctx, get)
+	s.NoError(err)
+
+	s.Equal(Stats{Misses: 2}, s.product.Stats())
+}
@@ -0,0 +1,273 @@
+package cached
+
+import (
+	"context"
+	"github.com/jackvonhouse/product-catalog/config"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/errors"
+	errpkg "github.com/jackvonhouse/product-catalog/pkg/errors"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"strconv"
+	"time"
+)
+
+type productService interface {
+	Create(context.Context, dto.CreateProduct, []dto.Category) (int, error)
+	AttachToCategory(context.Context, dto.Product, dto.Category) error
+	Import(context.Context, []dto.ImportProduct, bool) (dto.ImportResult, error)
+
+	Get(context.Context, dto.GetProduct) (dto.ProductPage, error)
+	GetById(context.Context, int) (dto.Product, error)
+	Search(context.Context, dto.SearchProduct) ([]dto.FoundProduct, error)
+	Export(context.Context, dto.GetProduct, func(dto.ExportProduct) error) error
+
+	Update(context.Context, dto.UpdateProduct, dto.Category) (int, error)
+
+	DetachFromCategory(context.Context, dto.Product, dto.Category) error
+	Delete(context.Context, int, int) (int, error)
+	Restore(context.Context, int) (int, error)
+	Purge(context.Context, time.Time) (int64, error)
+}
+
+// productPage сохраняет значения курсора, которые не попадают в JSON dto.ProductPage
+type productPage struct {
+	Items []dto.Product `json:"items"`
+	Total int           `json:"total"`
+	Last  []any         `json:"last"`
+}
+
+type Product struct {
+	productService
+
+	cache cache
+}
+
+func NewProduct(
+	service productService,
+	storage storage,
+	config config.Cache,
+	logger log.Logger,
+) Product {
+
+	return Product{
+		productService: service,
+		cache:          newCache(storage, config, logger.WithField("unit", "product")),
+	}
+}
+
+func (p Product) Stats() Stats { return p.cache.stats() }
+
+func (p Product) Create(
+	ctx context.Context,
+	data dto.CreateProduct,
+	categories []dto.Category,
+) (int, error) {
+
+	productId, err := p.productService.Create(ctx, data, categories)
+	if err != nil {
+		return 0, err
+	}
+
+	p.invalidate(ctx, productId)
+
+	return productId, nil
+}
+
+func (p Product) AttachToCategory(
+	ctx context.Context,
+	product dto.Product,
+	category dto.Category,
+) error {
+
+	if err := p.productService.AttachToCategory(ctx, product, category); err != nil {
+		return err
+	}
+
+	p.invalidate(ctx, product.ID)
+
+	return nil
+}
+
+func (p Product) Import(
+	ctx context.Context,
+	rows []dto.ImportProduct,
+	dryRun bool,
+) (dto.ImportResult, error) {
+
+	result, err := p.productService.Import(ctx, rows, dryRun)
+	if err != nil {
+		return dto.ImportResult{}, err
+	}
+
+	if dryRun || result.Created == 0 || !p.cache.enabled() {
+		return result, nil
+	}
+
+	// Идентификаторы созданных товаров неизвестны, поэтому сбрасываются все
+	// товары (включая закэшированные "не найден"), а также списки категорий,
+	// которые импорт мог создать
+	p.cache.invalidate(
+		ctx,
+		[]string{categoryTreeKey},
+		productIdPrefix, productListPrefix, categoryListPrefix,
+	)
+
+	return result, nil
+}
+
+func (p Product) Get(
+	ctx context.Context,
+	data dto.GetProduct,
+) (dto.ProductPage, error) {
+
+	if !p.cache.enabled() || data.IncludeDeleted {
+		return p.productService.Get(ctx, data)
+	}
+
+	return p.page(ctx, paramsKey(productListPrefix, data), func() (dto.ProductPage, error) {
+		return p.productService.Get(ctx, data)
+	})
+}
+
+func (p Product) GetById(
+	ctx context.Context,
+	id int,
+) (dto.Product, error) {
+
+	if !p.cache.enabled() {
+		return p.productService.GetById(ctx, id)
+	}
+
+	key := productIdPrefix + strconv.Itoa(id)
+
+	product := dto.Product{}
+
+	if found, notFound := p.cache.get(ctx, key, &product); found {
+		if notFound {
+			return dto.Product{}, errors.ErrNotFound.New("product not found")
+		}
+
+		return product, nil
+	}
+
+	product, err := p.productService.GetById(ctx, id)
+	if err != nil {
+		if errpkg.Has(err, errors.ErrNotFound) {
+			p.cache.setNotFound(ctx, key)
+		}
+
+		return dto.Product{}, err
+	}
+
+	p.cache.set(ctx, key, product)
+
+	return product, nil
+}
+
+func (p Product) Update(
+	ctx context.Context,
+	data dto.UpdateProduct,
+	category dto.Category,
+) (int, error) {
+
+	productId, err := p.productService.Update(ctx, data, category)
+	if err != nil {
+		return 0, err
+	}
+
+	p.invalidate(ctx, data.ID)
+
+	return productId, nil
+}
+
+func (p Product) DetachFromCategory(
+	ctx context.Context,
+	product dto.Product,
+	category dto.Category,
+) error {
+
+	if err := p.productService.DetachFromCategory(ctx, product, category); err != nil {
+		return err
+	}
+
+	p.invalidate(ctx, product.ID)
+
+	return nil
+}
+
+func (p Product) Delete(
+	ctx context.Context,
+	id int,
+	version int,
+) (int, error) {
+
+	productId, err := p.productService.Delete(ctx, id, version)
+	if err != nil {
+		return 0, err
+	}
+
+	p.invalidate(ctx, id)
+
+	return productId, nil
+}
+
+func (p Product) Restore(
+	ctx context.Context,
+	id int,
+) (int, error) {
+
+	productId, err := p.productService.Restore(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	p.invalidate(ctx, id)
+
+	return productId, nil
+}
+
+func (p Product) page(
+	ctx context.Context,
+	key string,
+	load func() (dto.ProductPage, error),
+) (dto.ProductPage, error) {
+
+	cached := productPage{}
+
+	if found, notFound := p.cache.get(ctx, key, &cached); found && !notFound {
+		return dto.ProductPage{
+			Items: cached.Items,
+			Total: cached.Total,
+			Last:  cached.Last,
+		}, nil
+	}
+
+	page, err := load()
+	if err != nil {
+		return dto.ProductPage{}, err
+	}
+
+	p.cache.set(ctx, key, productPage{
+		Items: page.Items,
+		Total: page.Total,
+		Last:  page.Last,
+	})
+
+	return page, nil
+}
+
+func (p Product) invalidate(
+	ctx context.Context,
+	productId int,
+) {
+
+	if !p.cache.enabled() {
+		return
+	}
+
+	p.cache.invalidate(
+		ctx,
+		[]string{productIdPrefix + strconv.Itoa(productId)},
+		productListPrefix,
+	)
+}
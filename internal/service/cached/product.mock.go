@@ -0,0 +1,219 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/cached/product.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/cached/product.go -destination=internal/service/cached/product.mock.go -package=cached
+//
+
+// Package cached is a generated GoMock package.
+package cached
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	dto "github.com/jackvonhouse/product-catalog/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockproductService is a mock of productService interface.
+type MockproductService struct {
+	ctrl     *gomock.Controller
+	recorder *MockproductServiceMockRecorder
+}
+
+// MockproductServiceMockRecorder is the mock recorder for MockproductService.
+type MockproductServiceMockRecorder struct {
+	mock *MockproductService
+}
+
+// NewMockproductService creates a new mock instance.
+func NewMockproductService(ctrl *gomock.Controller) *MockproductService {
+	mock := &MockproductService{ctrl: ctrl}
+	mock.recorder = &MockproductServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockproductService) EXPECT() *MockproductServiceMockRecorder {
+	return m.recorder
+}
+
+// AttachToCategory mocks base method.
+func (m *MockproductService) AttachToCategory(arg0 context.Context, arg1 dto.Product, arg2 dto.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachToCategory", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachToCategory indicates an expected call of AttachToCategory.
+func (mr *MockproductServiceMockRecorder) AttachToCategory(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachToCategory", reflect.TypeOf((*MockproductService)(nil).AttachToCategory), arg0, arg1, arg2)
+}
+
+// Create mocks base method.
+func (m *MockproductService) Create(arg0 context.Context, arg1 dto.CreateProduct, arg2 []dto.Category) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockproductServiceMockRecorder) Create(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockproductService)(nil).Create), arg0, arg1, arg2)
+}
+
+// Delete mocks base method.
+func (m *MockproductService) Delete(arg0 context.Context, arg1, arg2 int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockproductServiceMockRecorder) Delete(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockproductService)(nil).Delete), arg0, arg1, arg2)
+}
+
+// DetachFromCategory mocks base method.
+func (m *MockproductService) DetachFromCategory(arg0 context.Context, arg1 dto.Product, arg2 dto.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachFromCategory", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachFromCategory indicates an expected call of DetachFromCategory.
+func (mr *MockproductServiceMockRecorder) DetachFromCategory(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachFromCategory", reflect.TypeOf((*MockproductService)(nil).DetachFromCategory), arg0, arg1, arg2)
+}
+
+// Export mocks base method.
+func (m *MockproductService) Export(arg0 context.Context, arg1 dto.GetProduct, arg2 func(dto.ExportProduct) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockproductServiceMockRecorder) Export(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockproductService)(nil).Export), arg0, arg1, arg2)
+}
+
+// Get mocks base method.
+func (m *MockproductService) Get(arg0 context.Context, arg1 dto.GetProduct) (dto.ProductPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(dto.ProductPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockproductServiceMockRecorder) Get(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockproductService)(nil).Get), arg0, arg1)
+}
+
+// GetById mocks base method.
+func (m *MockproductService) GetById(arg0 context.Context, arg1 int) (dto.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", arg0, arg1)
+	ret0, _ := ret[0].(dto.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockproductServiceMockRecorder) GetById(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockproductService)(nil).GetById), arg0, arg1)
+}
+
+// Import mocks base method.
+func (m *MockproductService) Import(arg0 context.Context, arg1 []dto.ImportProduct, arg2 bool) (dto.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", arg0, arg1, arg2)
+	ret0, _ := ret[0].(dto.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockproductServiceMockRecorder) Import(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockproductService)(nil).Import), arg0, arg1, arg2)
+}
+
+// Purge mocks base method.
+func (m *MockproductService) Purge(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockproductServiceMockRecorder) Purge(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockproductService)(nil).Purge), arg0, arg1)
+}
+
+// Restore mocks base method.
+func (m *MockproductService) Restore(arg0 context.Context, arg1 int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockproductServiceMockRecorder) Restore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockproductService)(nil).Restore), arg0, arg1)
+}
+
+// Search mocks base method.
+func (m *MockproductService) Search(arg0 context.Context, arg1 dto.SearchProduct) ([]dto.FoundProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0, arg1)
+	ret0, _ := ret[0].([]dto.FoundProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockproductServiceMockRecorder) Search(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockproductService)(nil).Search), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockproductService) Update(arg0 context.Context, arg1 dto.UpdateProduct, arg2 dto.Category) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockproductServiceMockRecorder) Update(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockproductService)(nil).Update), arg0, arg1, arg2)
+}
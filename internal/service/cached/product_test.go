@@ -0,0 +1,368 @@
+package cached
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackvonhouse/product-catalog/config"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/errors"
+	cachedb "github.com/jackvonhouse/product-catalog/internal/infrastructure/cache"
+	"github.com/jackvonhouse/product-catalog/internal/transaction"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+type ProductTestSuite struct {
+	suite.Suite
+
+	// Вспомогательные параметры
+	ctx     context.Context
+	logger  log.Logger
+	config  config.Cache
+	storage cachedb.Database
+	service Product
+
+	// Входные параметры
+	get     dto.GetProduct
+	update  dto.UpdateProduct
+	product dto.Product
+
+	// Служебные параметры
+	mock *MockproductService
+}
+
+func TestSuiteProduct(t *testing.T) {
+	suite.Run(t, &ProductTestSuite{})
+}
+
+func (s *ProductTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.logger = log.NewNullLogger()
+	s.config = config.Cache{
+		ExpireDuration:  1,
+		CleanupInterval: 1,
+		TTL:             60,
+		NotFoundTTL:     10,
+	}
+}
+
+func (s *ProductTestSuite) BeforeTest(_, _ string) {
+	controller := gomock.NewController(s.T())
+
+	s.setupMock(controller).setupStorage().setupService()
+
+	// Входные значения по-умолчанию
+	s.setupProduct(1, "Продукт").
+		setupGetProduct(0, 10).
+		setupUpdateProduct(1, "Продукт")
+}
+
+func (s *ProductTestSuite) setupMock(
+	controller *gomock.Controller,
+) *ProductTestSuite {
+
+	s.mock = NewMockproductService(controller)
+
+	return s
+}
+
+func (s *ProductTestSuite) setupStorage() *ProductTestSuite {
+	storage, err := cachedb.New(s.ctx, s.config, s.logger)
+	s.NoError(err)
+
+	s.storage = storage
+
+	return s
+}
+
+func (s *ProductTestSuite) setupService() {
+	s.service = NewProduct(s.mock, s.storage, s.config, s.logger)
+}
+
+func (s *ProductTestSuite) setupProduct(
+	id int,
+	name string,
+) *ProductTestSuite {
+
+	s.product = dto.Product{
+		ID:      id,
+		Name:    name,
+		Version: 1,
+	}
+
+	return s
+}
+
+func (s *ProductTestSuite) setupGetProduct(
+	offset, limit int,
+) *ProductTestSuite {
+
+	s.get = dto.GetProduct{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	return s
+}
+
+func (s *ProductTestSuite) setupUpdateProduct(
+	id int,
+	name string,
+) *ProductTestSuite {
+
+	s.update = dto.UpdateProduct{
+		ID:   id,
+		Name: name,
+	}
+
+	return s
+}
+
+func (s *ProductTestSuite) TestGetByIdSuccessful() {
+	s.mock.
+		EXPECT().
+		GetById(s.ctx, s.product.ID).
+		Return(s.product, nil).
+		Times(1)
+
+	for range 2 {
+		product, err := s.service.GetById(s.ctx, s.product.ID)
+
+		s.NoError(err)
+		s.Equal(s.product, product)
+	}
+
+	s.Equal(Stats{Hits: 1, Misses: 1}, s.service.Stats())
+}
+
+func (s *ProductTestSuite) TestGetByIdNotFoundCached() {
+	const (
+		expectedNotFoundErrorMsg = "product not found"
+	)
+
+	s.mock.
+		EXPECT().
+		GetById(s.ctx, s.product.ID).
+		Return(dto.Product{}, errors.ErrNotFound.New(expectedNotFoundErrorMsg)).
+		Times(1)
+
+	for range 2 {
+		product, err := s.service.GetById(s.ctx, s.product.ID)
+
+		s.NotNil(err)
+		s.Equal(expectedNotFoundErrorMsg, err.Error())
+		s.Equal(dto.Product{}, product)
+	}
+
+	s.Equal(Stats{Hits: 1, Misses: 1}, s.service.Stats())
+}
+
+func (s *ProductTestSuite) TestGetSuccessful() {
+	page := dto.ProductPage{
+		Items: []dto.Product{s.product},
+		Total: 1,
+		Last:  []any{json.Number("1")},
+	}
+
+	s.mock.
+		EXPECT().
+		Get(s.ctx, s.get).
+		Return(page, nil).
+		Times(1)
+
+	for range 2 {
+		result, err := s.service.Get(s.ctx, s.get)
+
+		s.NoError(err)
+		s.Equal(page, result)
+	}
+
+	s.Equal(Stats{Hits: 1, Misses: 1}, s.service.Stats())
+}
+
+func (s *ProductTestSuite) TestGetIncludeDeletedNotCached() {
+	s.get.IncludeDeleted = true
+
+	page := dto.ProductPage{
+		Items: []dto.Product{s.product},
+		Total: 1,
+	}
+
+	s.mock.
+		EXPECT().
+		Get(s.ctx, s.get).
+		Return(page, nil).
+		Times(2)
+
+	for range 2 {
+		result, err := s.service.Get(s.ctx, s.get)
+
+		s.NoError(err)
+		s.Equal(page, result)
+	}
+
+	s.Equal(Stats{}, s.service.Stats())
+}
+
+func (s *ProductTestSuite) TestUpdateInvalidates() {
+	updated := s.product
+	updated.Version = 2
+
+	gomock.InOrder(
+		s.mock.
+			EXPECT().
+			GetById(s.ctx, s.product.ID).
+			Return(s.product, nil),
+		s.mock.
+			EXPECT().
+			Get(s.ctx, s.get).
+			Return(dto.ProductPage{Items: []dto.Product{s.product}, Total: 1}, nil),
+		s.mock.
+			EXPECT().
+			Update(s.ctx, s.update, dto.Category{}).
+			Return(s.product.ID, nil),
+		s.mock.
+			EXPECT().
+			GetById(s.ctx, s.product.ID).
+			Return(updated, nil),
+		s.mock.
+			EXPECT().
+			Get(s.ctx, s.get).
+			Return(dto.ProductPage{Items: []dto.Product{updated}, Total: 1}, nil),
+	)
+
+	_, err := s.service.GetById(s.ctx, s.product.ID)
+	s.NoError(err)
+
+	_, err = s.service.Get(s.ctx, s.get)
+	s.NoError(err)
+
+	productId, err := s.service.Update(s.ctx, s.update, dto.Category{})
+	s.NoError(err)
+	s.Equal(s.product.ID, productId)
+
+	product, err := s.service.GetById(s.ctx, s.product.ID)
+	s.NoError(err)
+	s.Equal(updated, product)
+
+	page, err := s.service.Get(s.ctx, s.get)
+	s.NoError(err)
+	s.Equal([]dto.Product{updated}, page.Items)
+}
+
+func (s *ProductTestSuite) TestTransactionInvalidatesAfterCommit() {
+	db, mock, err := sqlmock.New()
+	s.NoError(err)
+
+	manager := transaction.New(sqlx.NewDb(db, "sqlmock"), s.logger)
+
+	updated := s.product
+	updated.Version = 2
+
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	s.mock.
+		EXPECT().
+		GetById(s.ctx, s.product.ID).
+		Return(s.product, nil).
+		Times(1)
+
+	s.mock.
+		EXPECT().
+		Update(gomock.Any(), s.update, dto.Category{}).
+		Return(s.product.ID, nil).
+		Times(1)
+
+	s.mock.
+		EXPECT().
+		GetById(gomock.Any(), s.product.ID).
+		Return(updated, nil).
+		Times(2)
+
+	_, err = s.service.GetById(s.ctx, s.product.ID)
+	s.NoError(err)
+
+	err = manager.Do(s.ctx, func(ctx context.Context) error {
+		if _, err := s.service.Update(ctx, s.update, dto.Category{}); err != nil {
+			return err
+		}
+
+		// Внутри транзакции кэш не используется
+		product, err := s.service.GetById(ctx, s.product.ID)
+		s.Equal(updated, product)
+
+		return err
+	})
+	s.NoError(err)
+
+	product, err := s.service.GetById(s.ctx, s.product.ID)
+	s.NoError(err)
+	s.Equal(updated, product)
+
+	s.NoError(mock.ExpectationsWereMet())
+}
+
+func (s *ProductTestSuite) TestTransactionRollbackKeepsCache() {
+	db, mock, err := sqlmock.New()
+	s.NoError(err)
+
+	manager := transaction.New(sqlx.NewDb(db, "sqlmock"), s.logger)
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	s.mock.
+		EXPECT().
+		GetById(s.ctx, s.product.ID).
+		Return(s.product, nil).
+		Times(1)
+
+	s.mock.
+		EXPECT().
+		Update(gomock.Any(), s.update, dto.Category{}).
+		Return(s.product.ID, nil).
+		Times(1)
+
+	_, err = s.service.GetById(s.ctx, s.product.ID)
+	s.NoError(err)
+
+	err = manager.Do(s.ctx, func(ctx context.Context) error {
+		if _, err := s.service.Update(ctx, s.update, dto.Category{}); err != nil {
+			return err
+		}
+
+		return errors.ErrConflict.New("product version mismatch")
+	})
+	s.Error(err)
+
+	product, err := s.service.GetById(s.ctx, s.product.ID)
+	s.NoError(err)
+	s.Equal(s.product, product)
+
+	s.NoError(mock.ExpectationsWereMet())
+}
+
+func (s *ProductTestSuite) TestDisabledNotCached() {
+	s.config.TTL = 0
+	s.setupService()
+
+	s.mock.
+		EXPECT().
+		GetById(s.ctx, s.product.ID).
+		Return(s.product, nil).
+		Times(2)
+
+	for range 2 {
+		product, err := s.service.GetById(s.ctx, s.product.ID)
+
+		s.NoError(err)
+		s.Equal(s.product, product)
+	}
+
+	s.Equal(Stats{}, s.service.Stats())
+}
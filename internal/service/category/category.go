@@ -3,18 +3,27 @@ package category
 import (
 	"context"
 	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/errors"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"time"
 )
 
 type repository interface {
 	Create(context.Context, dto.CreateCategory) (int, error)
 
-	Get(context.Context, dto.GetCategory) ([]dto.Category, error)
+	Get(context.Context, dto.GetCategory) (dto.CategoryPage, error)
 	GetById(context.Context, int) (dto.Category, error)
+	GetAll(context.Context) ([]dto.Category, error)
+	GetChildren(context.Context, dto.Category) ([]dto.Category, error)
+	GetAncestors(context.Context, dto.Category) ([]dto.Category, error)
+	LockAncestors(context.Context, int, int) error
+	GetByProductId(context.Context, dto.Product) ([]dto.Category, error)
 
 	Update(context.Context, dto.UpdateCategory) (int, error)
 
-	Delete(context.Context, dto.Category) (int, error)
+	Delete(context.Context, dto.Category, int) (int, error)
+	Restore(context.Context, int) (int, error)
+	Purge(context.Context, time.Time) (int64, error)
 }
 
 type Service struct {
@@ -45,7 +54,7 @@ func (s Service) Create(
 func (s Service) Get(
 	ctx context.Context,
 	data dto.GetCategory,
-) ([]dto.Category, error) {
+) (dto.CategoryPage, error) {
 
 	return s.repository.Get(ctx, data)
 }
@@ -58,17 +67,143 @@ func (s Service) GetById(
 	return s.repository.GetById(ctx, id)
 }
 
+func (s Service) GetChildren(
+	ctx context.Context,
+	category dto.Category,
+) ([]dto.Category, error) {
+
+	return s.repository.GetChildren(ctx, category)
+}
+
+func (s Service) GetAncestors(
+	ctx context.Context,
+	category dto.Category,
+) ([]dto.Category, error) {
+
+	return s.repository.GetAncestors(ctx, category)
+}
+
+func (s Service) GetTree(
+	ctx context.Context,
+) ([]dto.CategoryTree, error) {
+
+	categories, err := s.repository.GetAll(ctx)
+	if err != nil {
+		return []dto.CategoryTree{}, err
+	}
+
+	children := make(map[int][]dto.Category)
+	roots := make([]dto.Category, 0)
+	ids := make(map[int]struct{}, len(categories))
+
+	for _, category := range categories {
+		ids[category.ID] = struct{}{}
+	}
+
+	for _, category := range categories {
+		if category.ParentId == nil {
+			roots = append(roots, category)
+
+			continue
+		}
+
+		if _, ok := ids[*category.ParentId]; !ok {
+			roots = append(roots, category)
+
+			continue
+		}
+
+		children[*category.ParentId] = append(children[*category.ParentId], category)
+	}
+
+	return s.buildTree(roots, children), nil
+}
+
+func (s Service) buildTree(
+	categories []dto.Category,
+	children map[int][]dto.Category,
+) []dto.CategoryTree {
+
+	tree := make([]dto.CategoryTree, 0, len(categories))
+
+	for _, category := range categories {
+		tree = append(tree, dto.CategoryTree{
+			Category: category,
+			Children: s.buildTree(children[category.ID], children),
+		})
+	}
+
+	return tree
+}
+
+func (s Service) GetByProductId(
+	ctx context.Context,
+	product dto.Product,
+) ([]dto.Category, error) {
+
+	return s.repository.GetByProductId(ctx, product)
+}
+
 func (s Service) Update(
 	ctx context.Context,
 	data dto.UpdateCategory,
 ) (int, error) {
 
+	if _, err := s.repository.GetById(ctx, data.ID); err != nil {
+		s.logger.Warnf("category not found: %s", err)
+
+		return 0, err
+	}
+
+	if data.ParentId != nil {
+		if err := s.checkCycle(ctx, data.ID, *data.ParentId); err != nil {
+			return 0, err
+		}
+	}
+
 	return s.repository.Update(ctx, data)
 }
 
+// checkCycle должен выполняться в одной транзакции с обновлением: иначе
+// встречные перемещения категорий пройдут проверку и образуют цикл
+func (s Service) checkCycle(
+	ctx context.Context,
+	id, parentId int,
+) error {
+
+	if id == parentId {
+		return errors.ErrInvalid.New("category can't be parent of itself")
+	}
+
+	if err := s.repository.LockAncestors(ctx, id, parentId); err != nil {
+		return err
+	}
+
+	parent, err := s.repository.GetById(ctx, parentId)
+	if err != nil {
+		s.logger.Warnf("parent category not found: %s", err)
+
+		return err
+	}
+
+	ancestors, err := s.repository.GetAncestors(ctx, parent)
+	if err != nil {
+		return err
+	}
+
+	for _, ancestor := range ancestors {
+		if ancestor.ID == id {
+			return errors.ErrInvalid.New("category can't be descendant of itself")
+		}
+	}
+
+	return nil
+}
+
 func (s Service) Delete(
 	ctx context.Context,
 	id int,
+	version int,
 ) (int, error) {
 
 	category, err := s.repository.GetById(ctx, id)
@@ -78,5 +213,21 @@ func (s Service) Delete(
 		return 0, err
 	}
 
-	return s.repository.Delete(ctx, category)
+	return s.repository.Delete(ctx, category, version)
+}
+
+func (s Service) Restore(
+	ctx context.Context,
+	id int,
+) (int, error) {
+
+	return s.repository.Restore(ctx, id)
+}
+
+func (s Service) Purge(
+	ctx context.Context,
+	deletedBefore time.Time,
+) (int64, error) {
+
+	return s.repository.Purge(ctx, deletedBefore)
 }
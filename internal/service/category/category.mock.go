@@ -12,6 +12,7 @@ package category
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	dto "github.com/jackvonhouse/product-catalog/internal/dto"
 	gomock "go.uber.org/mock/gomock"
@@ -56,25 +57,25 @@ func (mr *MockrepositoryMockRecorder) Create(arg0, arg1 any) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *Mockrepository) Delete(arg0 context.Context, arg1 dto.Category) (int, error) {
+func (m *Mockrepository) Delete(arg0 context.Context, arg1 dto.Category, arg2 int) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockrepositoryMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+func (mr *MockrepositoryMockRecorder) Delete(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*Mockrepository)(nil).Delete), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*Mockrepository)(nil).Delete), arg0, arg1, arg2)
 }
 
 // Get mocks base method.
-func (m *Mockrepository) Get(arg0 context.Context, arg1 dto.GetCategory) ([]dto.Category, error) {
+func (m *Mockrepository) Get(arg0 context.Context, arg1 dto.GetCategory) (dto.CategoryPage, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Get", arg0, arg1)
-	ret0, _ := ret[0].([]dto.Category)
+	ret0, _ := ret[0].(dto.CategoryPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -85,6 +86,36 @@ func (mr *MockrepositoryMockRecorder) Get(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*Mockrepository)(nil).Get), arg0, arg1)
 }
 
+// GetAll mocks base method.
+func (m *Mockrepository) GetAll(arg0 context.Context) ([]dto.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0)
+	ret0, _ := ret[0].([]dto.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockrepositoryMockRecorder) GetAll(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*Mockrepository)(nil).GetAll), arg0)
+}
+
+// GetAncestors mocks base method.
+func (m *Mockrepository) GetAncestors(arg0 context.Context, arg1 dto.Category) ([]dto.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAncestors", arg0, arg1)
+	ret0, _ := ret[0].([]dto.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAncestors indicates an expected call of GetAncestors.
+func (mr *MockrepositoryMockRecorder) GetAncestors(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAncestors", reflect.TypeOf((*Mockrepository)(nil).GetAncestors), arg0, arg1)
+}
+
 // GetById mocks base method.
 func (m *Mockrepository) GetById(arg0 context.Context, arg1 int) (dto.Category, error) {
 	m.ctrl.T.Helper()
@@ -100,6 +131,80 @@ func (mr *MockrepositoryMockRecorder) GetById(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*Mockrepository)(nil).GetById), arg0, arg1)
 }
 
+// GetByProductId mocks base method.
+func (m *Mockrepository) GetByProductId(arg0 context.Context, arg1 dto.Product) ([]dto.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProductId", arg0, arg1)
+	ret0, _ := ret[0].([]dto.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProductId indicates an expected call of GetByProductId.
+func (mr *MockrepositoryMockRecorder) GetByProductId(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProductId", reflect.TypeOf((*Mockrepository)(nil).GetByProductId), arg0, arg1)
+}
+
+// GetChildren mocks base method.
+func (m *Mockrepository) GetChildren(arg0 context.Context, arg1 dto.Category) ([]dto.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChildren", arg0, arg1)
+	ret0, _ := ret[0].([]dto.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChildren indicates an expected call of GetChildren.
+func (mr *MockrepositoryMockRecorder) GetChildren(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChildren", reflect.TypeOf((*Mockrepository)(nil).GetChildren), arg0, arg1)
+}
+
+// LockAncestors mocks base method.
+func (m *Mockrepository) LockAncestors(arg0 context.Context, arg1, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockAncestors", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockAncestors indicates an expected call of LockAncestors.
+func (mr *MockrepositoryMockRecorder) LockAncestors(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockAncestors", reflect.TypeOf((*Mockrepository)(nil).LockAncestors), arg0, arg1, arg2)
+}
+
+// Purge mocks base method.
+func (m *Mockrepository) Purge(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockrepositoryMockRecorder) Purge(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*Mockrepository)(nil).Purge), arg0, arg1)
+}
+
+// Restore mocks base method.
+func (m *Mockrepository) Restore(arg0 context.Context, arg1 int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockrepositoryMockRecorder) Restore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*Mockrepository)(nil).Restore), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *Mockrepository) Update(arg0 context.Context, arg1 dto.UpdateCategory) (int, error) {
 	m.ctrl.T.Helper()
@@ -146,12 +146,12 @@ func (s *ProductTestSuite) TestGetSuccessful() {
 	s.mock.
 		EXPECT().
 		Get(s.ctx, s.get).
-		Return(s.categories, nil)
+		Return(dto.CategoryPage{Items: s.categories, Total: 1}, nil)
 
 	categories, err := s.service.Get(s.ctx, s.get)
 
 	s.NoError(err)
-	s.Equal(s.categories, categories)
+	s.Equal(dto.CategoryPage{Items: s.categories, Total: 1}, categories)
 }
 
 func (s *ProductTestSuite) TestGetByIdSuccessful() {
@@ -166,7 +166,25 @@ func (s *ProductTestSuite) TestGetByIdSuccessful() {
 	s.Equal(s.category, category)
 }
 
+func (s *ProductTestSuite) TestGetByProductIdSuccessful() {
+	s.mock.
+		EXPECT().
+		GetByProductId(s.ctx, s.product).
+		Return(s.categories, nil)
+
+	categories, err := s.service.GetByProductId(s.ctx, s.product)
+
+	s.NoError(err)
+	s.Equal(s.categories, categories)
+}
+
 func (s *ProductTestSuite) TestUpdateSuccessful() {
+	s.mock.
+		EXPECT().
+		GetById(s.ctx, s.update.ID).
+		Return(s.category, nil).
+		Times(1)
+
 	s.mock.
 		EXPECT().
 		Update(s.ctx, s.update).
@@ -188,11 +206,11 @@ func (s *ProductTestSuite) TestDeleteSuccessful() {
 
 	s.mock.
 		EXPECT().
-		Delete(s.ctx, s.category).
+		Delete(s.ctx, s.category, 0).
 		Return(s.category.ID, nil).
 		Times(1)
 
-	categoryId, err := s.service.Delete(s.ctx, s.product.ID)
+	categoryId, err := s.service.Delete(s.ctx, s.product.ID, 0)
 
 	s.NoError(err)
 	s.Equal(1, categoryId)
@@ -213,9 +231,195 @@ func (s *ProductTestSuite) TestDeleteFailure() {
 		Return(dto.Category{}, expectedError).
 		Times(1)
 
-	categoryId, err := s.service.Delete(s.ctx, s.category.ID)
+	categoryId, err := s.service.Delete(s.ctx, s.category.ID, 0)
 
 	s.NotNil(err)
 	s.Equal(expectedError.Error(), err.Error())
 	s.Equal(0, categoryId)
 }
+
+func (s *ProductTestSuite) TestGetTreeSuccessful() {
+	parentId := 1
+
+	categories := []dto.Category{
+		{ID: 1, Name: "Питомцы"},
+		{ID: 2, Name: "Собаки", ParentId: &parentId},
+		{ID: 3, Name: "Корма"},
+	}
+
+	s.mock.
+		EXPECT().
+		GetAll(s.ctx).
+		Return(categories, nil).
+		Times(1)
+
+	tree, err := s.service.GetTree(s.ctx)
+
+	s.NoError(err)
+	s.Equal([]dto.CategoryTree{
+		{
+			Category: categories[0],
+			Children: []dto.CategoryTree{
+				{Category: categories[1], Children: []dto.CategoryTree{}},
+			},
+		},
+		{Category: categories[2], Children: []dto.CategoryTree{}},
+	}, tree)
+}
+
+func (s *ProductTestSuite) TestGetTreeDeletedParentSuccessful() {
+	deletedParentId := 4
+
+	categories := []dto.Category{
+		{ID: 1, Name: "Питомцы"},
+		{ID: 2, Name: "Собаки", ParentId: &deletedParentId},
+	}
+
+	s.mock.
+		EXPECT().
+		GetAll(s.ctx).
+		Return(categories, nil).
+		Times(1)
+
+	tree, err := s.service.GetTree(s.ctx)
+
+	s.NoError(err)
+	s.Equal([]dto.CategoryTree{
+		{Category: categories[0], Children: []dto.CategoryTree{}},
+		{Category: categories[1], Children: []dto.CategoryTree{}},
+	}, tree)
+}
+
+func (s *ProductTestSuite) TestUpdateWithParentSuccessful() {
+	parentId := 2
+
+	s.update.ParentId = &parentId
+
+	parent := dto.Category{ID: parentId, Name: "Родитель"}
+
+	s.mock.
+		EXPECT().
+		GetById(s.ctx, s.update.ID).
+		Return(s.category, nil).
+		Times(1)
+
+	s.mock.
+		EXPECT().
+		LockAncestors(s.ctx, s.update.ID, parentId).
+		Return(nil).
+		Times(1)
+
+	s.mock.
+		EXPECT().
+		GetById(s.ctx, parentId).
+		Return(parent, nil).
+		Times(1)
+
+	s.mock.
+		EXPECT().
+		GetAncestors(s.ctx, parent).
+		Return([]dto.Category{{ID: 3, Name: "Корень"}}, nil).
+		Times(1)
+
+	s.mock.
+		EXPECT().
+		Update(s.ctx, s.update).
+		Return(s.category.ID, nil).
+		Times(1)
+
+	categoryId, err := s.service.Update(s.ctx, s.update)
+
+	s.NoError(err)
+	s.Equal(1, categoryId)
+}
+
+func (s *ProductTestSuite) TestUpdateCycleFailure() {
+	const (
+		expectedSelfParentErrorMsg = "category can't be parent of itself"
+		expectedDescendantErrorMsg = "category can't be descendant of itself"
+	)
+
+	s.Run("Parent of itself", func() {
+		parentId := s.update.ID
+
+		s.update.ParentId = &parentId
+
+		s.mock.
+			EXPECT().
+			GetById(s.ctx, s.update.ID).
+			Return(s.category, nil).
+			Times(1)
+
+		categoryId, err := s.service.Update(s.ctx, s.update)
+
+		s.NotNil(err)
+		s.Equal(expectedSelfParentErrorMsg, err.Error())
+		s.Equal(0, categoryId)
+	})
+
+	s.Run("Descendant of itself", func() {
+		parentId := 2
+
+		s.update.ParentId = &parentId
+
+		parent := dto.Category{ID: parentId, Name: "Потомок"}
+
+		s.mock.
+			EXPECT().
+			GetById(s.ctx, s.update.ID).
+			Return(s.category, nil).
+			Times(1)
+
+		s.mock.
+			EXPECT().
+			LockAncestors(s.ctx, s.update.ID, parentId).
+			Return(nil).
+			Times(1)
+
+		s.mock.
+			EXPECT().
+			GetById(s.ctx, parentId).
+			Return(parent, nil).
+			Times(1)
+
+		s.mock.
+			EXPECT().
+			GetAncestors(s.ctx, parent).
+			Return([]dto.Category{s.category}, nil).
+			Times(1)
+
+		categoryId, err := s.service.Update(s.ctx, s.update)
+
+		s.NotNil(err)
+		s.Equal(expectedDescendantErrorMsg, err.Error())
+		s.Equal(0, categoryId)
+	})
+}
+
+func (s *ProductTestSuite) TestUpdateLockFailure() {
+	const (
+		expectedErrorMsg = "unknown error on getting categories"
+	)
+
+	parentId := 2
+
+	s.update.ParentId = &parentId
+
+	s.mock.
+		EXPECT().
+		GetById(s.ctx, s.update.ID).
+		Return(s.category, nil).
+		Times(1)
+
+	s.mock.
+		EXPECT().
+		LockAncestors(s.ctx, s.update.ID, parentId).
+		Return(errors.ErrInternal.New(expectedErrorMsg)).
+		Times(1)
+
+	categoryId, err := s.service.Update(s.ctx, s.update)
+
+	s.NotNil(err)
+	s.Equal(expectedErrorMsg, err.Error())
+	s.Equal(0, categoryId)
+}
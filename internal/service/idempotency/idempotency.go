@@ -0,0 +1,71 @@
+package idempotency
+
+import (
+	"context"
+	"github.com/jackvonhouse/product-catalog/config"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"time"
+)
+
+type repository interface {
+	Begin(context.Context, dto.IdempotencyKey) (dto.IdempotencyKey, bool, error)
+	Complete(context.Context, dto.IdempotencyKey) error
+	Release(context.Context, dto.IdempotencyKey) error
+
+	Purge(context.Context, time.Time) (int64, error)
+}
+
+type Service struct {
+	repository repository
+
+	logger log.Logger
+	ttl    time.Duration
+}
+
+func New(
+	repository repository,
+	config config.Idempotency,
+	logger log.Logger,
+) Service {
+
+	return Service{
+		repository: repository,
+		logger:     logger.WithField("unit", "idempotency_key"),
+		ttl:        time.Duration(config.TTL) * time.Hour,
+	}
+}
+
+func (s Service) Begin(
+	ctx context.Context,
+	data dto.IdempotencyKey,
+) (dto.IdempotencyKey, bool, error) {
+
+	data.ExpiresAt = time.Now().Add(s.ttl)
+
+	return s.repository.Begin(ctx, data)
+}
+
+func (s Service) Complete(
+	ctx context.Context,
+	data dto.IdempotencyKey,
+) error {
+
+	return s.repository.Complete(ctx, data)
+}
+
+func (s Service) Release(
+	ctx context.Context,
+	data dto.IdempotencyKey,
+) error {
+
+	return s.repository.Release(ctx, data)
+}
+
+func (s Service) Purge(
+	ctx context.Context,
+	expiredBefore time.Time,
+) (int64, error) {
+
+	return s.repository.Purge(ctx, expiredBefore)
+}
@@ -0,0 +1,126 @@
+package job
+
+import (
+	"context"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"time"
+)
+
+type repository interface {
+	Create(context.Context, dto.CreateJob) (int, error)
+
+	GetById(context.Context, int) (dto.Job, error)
+	GetFile(context.Context, int) (dto.JobFile, error)
+
+	Dequeue(context.Context, time.Time, int) (dto.Job, error)
+	Heartbeat(context.Context, int, int, int) (bool, error)
+	Finish(context.Context, dto.FinishJob) error
+	Requeue(context.Context, int, int) error
+	FailExhausted(context.Context, time.Time, int) (int64, error)
+
+	Cancel(context.Context, int) (dto.Job, error)
+	Purge(context.Context, time.Time) (int64, error)
+}
+
+type Service struct {
+	repository repository
+
+	logger log.Logger
+}
+
+func New(
+	repository repository,
+	logger log.Logger,
+) Service {
+
+	return Service{
+		repository: repository,
+		logger:     logger.WithField("unit", "job"),
+	}
+}
+
+func (s Service) Create(
+	ctx context.Context,
+	data dto.CreateJob,
+) (int, error) {
+
+	return s.repository.Create(ctx, data)
+}
+
+func (s Service) GetById(
+	ctx context.Context,
+	id int,
+) (dto.Job, error) {
+
+	return s.repository.GetById(ctx, id)
+}
+
+func (s Service) GetFile(
+	ctx context.Context,
+	id int,
+) (dto.JobFile, error) {
+
+	return s.repository.GetFile(ctx, id)
+}
+
+func (s Service) Dequeue(
+	ctx context.Context,
+	staleBefore time.Time,
+	maxAttempts int,
+) (dto.Job, error) {
+
+	return s.repository.Dequeue(ctx, staleBefore, maxAttempts)
+}
+
+func (s Service) Heartbeat(
+	ctx context.Context,
+	id int,
+	attempt int,
+	progress int,
+) (bool, error) {
+
+	return s.repository.Heartbeat(ctx, id, attempt, progress)
+}
+
+func (s Service) Finish(
+	ctx context.Context,
+	data dto.FinishJob,
+) error {
+
+	return s.repository.Finish(ctx, data)
+}
+
+func (s Service) Requeue(
+	ctx context.Context,
+	id int,
+	attempt int,
+) error {
+
+	return s.repository.Requeue(ctx, id, attempt)
+}
+
+func (s Service) FailExhausted(
+	ctx context.Context,
+	staleBefore time.Time,
+	maxAttempts int,
+) (int64, error) {
+
+	return s.repository.FailExhausted(ctx, staleBefore, maxAttempts)
+}
+
+func (s Service) Cancel(
+	ctx context.Context,
+	id int,
+) (dto.Job, error) {
+
+	return s.repository.Cancel(ctx, id)
+}
+
+func (s Service) Purge(
+	ctx context.Context,
+	finishedBefore time.Time,
+) (int64, error) {
+
+	return s.repository.Purge(ctx, finishedBefore)
+}
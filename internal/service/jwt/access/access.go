@@ -35,8 +35,10 @@ func (s Service) Create(
 ) (string, error) {
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS512, claim.AccessTokenClaim{
+		UserId:         data.UserId,
 		Username:       data.Username,
 		RefreshTokenId: data.RefreshTokenId,
+		Role:           data.Role,
 
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(
@@ -70,8 +72,10 @@ func (s Service) Parse(
 	}
 
 	accessToken := dto.AccessToken{
+		UserId:         accessTokenClaim.UserId,
 		Username:       accessTokenClaim.Username,
 		RefreshTokenId: accessTokenClaim.RefreshTokenId,
+		Role:           accessTokenClaim.Role,
 	}
 
 	return accessToken, nil
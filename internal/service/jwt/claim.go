@@ -3,8 +3,10 @@ package jwt
 import "github.com/golang-jwt/jwt/v5"
 
 type AccessTokenClaim struct {
+	UserId         int    `json:"user_id"`
 	Username       string `json:"username"`
 	RefreshTokenId int    `json:"refresh_token_id"`
+	Role           string `json:"role"`
 
 	jwt.RegisteredClaims
 }
@@ -4,23 +4,33 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/base64"
+	"encoding/hex"
 	"github.com/jackvonhouse/product-catalog/config"
 	"github.com/jackvonhouse/product-catalog/internal/dto"
 	"github.com/jackvonhouse/product-catalog/internal/errors"
+	errpkg "github.com/jackvonhouse/product-catalog/pkg/errors"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 	"golang.org/x/crypto/bcrypt"
 )
 
 const (
 	refreshTokenSize = 32
+	familyIdSize     = 16
 )
 
 type repository interface {
 	Create(context.Context, dto.User, dto.RefreshToken) (int, error)
 
 	GetById(context.Context, int) (dto.RefreshToken, error)
+	GetSessions(context.Context, int) ([]dto.Session, error)
+
+	Exists(context.Context, int) (bool, error)
+
+	MarkUsed(context.Context, int) error
 
 	DeleteByUserId(context.Context, int) error
+	DeleteByFamilyId(context.Context, string) (int64, error)
+	DeleteSession(context.Context, int, int) error
 }
 
 type Service struct {
@@ -44,24 +54,59 @@ func New(
 	}
 }
 
+// Create выпускает refresh-токен, который начинает новое семейство
 func (s Service) Create(
 	ctx context.Context,
 	user dto.User,
+	device dto.Device,
 ) (int, string, error) {
 
-	token, hashedToken, err := s.generateRefresh()
+	familyId, err := s.generateFamilyId()
 	if err != nil {
 		return 0, "", err
 	}
 
-	refreshToken := dto.RefreshToken{
-		Token:          hashedToken,
-		ExpireDuration: s.expireDuration,
+	return s.create(ctx, user, dto.RefreshToken{
+		FamilyId: familyId,
+		Device:   device,
+	})
+}
+
+// Rotate помечает refresh-токен использованным и выпускает следующий токен
+// того же семейства; оба изменения должны выполняться в одной транзакции.
+// Повторное предъявление использованного токена означает, что он
+// скомпрометирован: Rotate возвращает ErrRevoked, а семейство отзывается
+// через RevokeFamily вне этой транзакции
+func (s Service) Rotate(
+	ctx context.Context,
+	user dto.User,
+	parent dto.RefreshToken,
+	device dto.Device,
+) (int, string, error) {
+
+	if parent.UsedAt != nil {
+		return 0, "", s.errReused()
 	}
 
-	id, err := s.repository.Create(ctx, user, refreshToken)
+	if err := s.repository.MarkUsed(ctx, parent.ID); err != nil {
+		// Токен успели использовать параллельным запросом
+		if errpkg.Has(err, errors.ErrAlreadyExists) {
+			return 0, "", s.errReused()
+		}
 
-	return id, token, err
+		return 0, "", err
+	}
+
+	// Имя устройства задаётся при входе и сохраняется в семействе
+	if device.Name == "" {
+		device.Name = parent.Device.Name
+	}
+
+	return s.create(ctx, user, dto.RefreshToken{
+		FamilyId: parent.FamilyId,
+		ParentId: &parent.ID,
+		Device:   device,
+	})
 }
 
 func (s Service) GetById(
@@ -72,6 +117,22 @@ func (s Service) GetById(
 	return s.repository.GetById(ctx, id)
 }
 
+func (s Service) Exists(
+	ctx context.Context,
+	id int,
+) (bool, error) {
+
+	return s.repository.Exists(ctx, id)
+}
+
+func (s Service) GetSessions(
+	ctx context.Context,
+	userId int,
+) ([]dto.Session, error) {
+
+	return s.repository.GetSessions(ctx, userId)
+}
+
 func (s Service) DeleteByUserId(
 	ctx context.Context,
 	id int,
@@ -80,6 +141,65 @@ func (s Service) DeleteByUserId(
 	return s.repository.DeleteByUserId(ctx, id)
 }
 
+func (s Service) DeleteSession(
+	ctx context.Context,
+	userId int,
+	id int,
+) error {
+
+	return s.repository.DeleteSession(ctx, userId, id)
+}
+
+func (s Service) create(
+	ctx context.Context,
+	user dto.User,
+	refreshToken dto.RefreshToken,
+) (int, string, error) {
+
+	token, hashedToken, err := s.generateRefresh()
+	if err != nil {
+		return 0, "", err
+	}
+
+	refreshToken.Token = hashedToken
+	refreshToken.ExpireDuration = s.expireDuration
+
+	id, err := s.repository.Create(ctx, user, refreshToken)
+
+	return id, token, err
+}
+
+// RevokeFamily отзывает все токены семейства повторно предъявленного токена
+func (s Service) RevokeFamily(
+	ctx context.Context,
+	token dto.RefreshToken,
+) error {
+
+	revoked, err := s.repository.DeleteByFamilyId(ctx, token.FamilyId)
+
+	s.logger.WithFields(map[string]any{
+		"event":     "refresh_token_reuse",
+		"user_id":   token.UserId,
+		"token_id":  token.ID,
+		"family_id": token.FamilyId,
+		"revoked":   revoked,
+	}).Error("refresh token reuse detected, token family revoked")
+
+	if err != nil {
+		return err
+	}
+
+	return errors.
+		ErrRevoked.
+		New("refresh token has been revoked")
+}
+
+func (s Service) errReused() error {
+	return errors.
+		ErrRevoked.
+		New("refresh token has been reused")
+}
+
 func (s Service) Verify(
 	token, hashedToken string,
 ) error {
@@ -119,6 +239,21 @@ func (s Service) generateRefresh() (string, string, error) {
 	return token, hashedToken, nil
 }
 
+func (s Service) generateFamilyId() (string, error) {
+	random := make([]byte, familyIdSize)
+
+	if _, err := rand.Read(random); err != nil {
+		s.logger.Warnf("can't generate refresh token family: %s", err)
+
+		return "", errors.
+			ErrInternal.
+			New("can't generate refresh token family").
+			Wrap(err)
+	}
+
+	return hex.EncodeToString(random), nil
+}
+
 func (s Service) hashRefresh(
 	token string,
 ) (string, error) {
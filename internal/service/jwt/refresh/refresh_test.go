@@ -0,0 +1,214 @@
+package refresh
+
+import (
+	"context"
+	"github.com/jackvonhouse/product-catalog/config"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/errors"
+	repoerrors "github.com/jackvonhouse/product-catalog/internal/repository/errors"
+	errpkg "github.com/jackvonhouse/product-catalog/pkg/errors"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+type RefreshTestSuite struct {
+	suite.Suite
+
+	// Вспомогательные параметры
+	ctx     context.Context
+	logger  log.Logger
+	service Service
+
+	// Входные параметры
+	user   dto.User
+	device dto.Device
+	parent dto.RefreshToken
+
+	// Служебные параметры
+	mock *Mockrepository
+}
+
+func TestSuiteRefresh(t *testing.T) {
+	suite.Run(t, &RefreshTestSuite{})
+}
+
+func (s *RefreshTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.logger = log.NewNullLogger()
+}
+
+func (s *RefreshTestSuite) BeforeTest(_, _ string) {
+	controller := gomock.NewController(s.T())
+
+	s.setupMock(controller).setupService()
+
+	// Входные значения по-умолчанию
+	s.setupUser(1, "user").
+		setupDevice("Ноутбук").
+		setupParent(1, "family")
+}
+
+func (s *RefreshTestSuite) setupMock(
+	controller *gomock.Controller,
+) *RefreshTestSuite {
+
+	s.mock = NewMockrepository(controller)
+
+	return s
+}
+
+func (s *RefreshTestSuite) setupService() *RefreshTestSuite {
+	s.service = New(s.mock, config.JWT{}, s.logger)
+
+	return s
+}
+
+func (s *RefreshTestSuite) setupUser(
+	id int,
+	username string,
+) *RefreshTestSuite {
+
+	s.user = dto.User{
+		ID:       id,
+		Username: username,
+	}
+
+	return s
+}
+
+func (s *RefreshTestSuite) setupDevice(
+	name string,
+) *RefreshTestSuite {
+
+	s.device = dto.Device{
+		Name:      name,
+		UserAgent: "curl/8.0",
+		IP:        "127.0.0.1",
+	}
+
+	return s
+}
+
+func (s *RefreshTestSuite) setupParent(
+	id int,
+	familyId string,
+) {
+
+	s.parent = dto.RefreshToken{
+		ID:       id,
+		UserId:   s.user.ID,
+		FamilyId: familyId,
+		Device:   s.device,
+	}
+}
+
+func (s *RefreshTestSuite) TestCreateNewFamily() {
+	s.mock.
+		EXPECT().
+		Create(s.ctx, s.user, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ dto.User, refresh dto.RefreshToken) (int, error) {
+			s.Len(refresh.FamilyId, familyIdSize*2)
+			s.Nil(refresh.ParentId)
+			s.Equal(s.device, refresh.Device)
+
+			return 1, nil
+		}).
+		Times(1)
+
+	id, token, err := s.service.Create(s.ctx, s.user, s.device)
+
+	s.NoError(err)
+	s.Equal(1, id)
+	s.NotEmpty(token)
+}
+
+func (s *RefreshTestSuite) TestRotateSuccessful() {
+	gomock.InOrder(
+		s.mock.
+			EXPECT().
+			MarkUsed(s.ctx, s.parent.ID).
+			Return(nil),
+		s.mock.
+			EXPECT().
+			Create(s.ctx, s.user, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ dto.User, refresh dto.RefreshToken) (int, error) {
+				s.Equal(s.parent.FamilyId, refresh.FamilyId)
+				s.Equal(&s.parent.ID, refresh.ParentId)
+				s.Equal(dto.Device{Name: s.device.Name, IP: "10.0.0.1"}, refresh.Device)
+
+				return 2, nil
+			}),
+	)
+
+	id, token, err := s.service.Rotate(s.ctx, s.user, s.parent, dto.Device{IP: "10.0.0.1"})
+
+	s.NoError(err)
+	s.Equal(2, id)
+	s.NotEmpty(token)
+}
+
+func (s *RefreshTestSuite) TestRotateReused() {
+	const (
+		expectedErrorMsg = "refresh token has been reused"
+	)
+
+	usedAt := int64(1)
+	s.parent.UsedAt = &usedAt
+
+	_, _, err := s.service.Rotate(s.ctx, s.user, s.parent, s.device)
+
+	s.EqualError(err, expectedErrorMsg)
+	s.True(errpkg.Has(err, errors.ErrRevoked))
+}
+
+func (s *RefreshTestSuite) TestRotateConcurrentlyReused() {
+	const (
+		expectedErrorMsg = "refresh token has been reused"
+	)
+
+	s.mock.
+		EXPECT().
+		MarkUsed(s.ctx, s.parent.ID).
+		Return(repoerrors.ErrUsed("refresh token")).
+		Times(1)
+
+	_, _, err := s.service.Rotate(s.ctx, s.user, s.parent, s.device)
+
+	s.EqualError(err, expectedErrorMsg)
+	s.True(errpkg.Has(err, errors.ErrRevoked))
+}
+
+func (s *RefreshTestSuite) TestRevokeFamilySuccessful() {
+	const (
+		expectedErrorMsg = "refresh token has been revoked"
+	)
+
+	s.mock.
+		EXPECT().
+		DeleteByFamilyId(s.ctx, s.parent.FamilyId).
+		Return(int64(2), nil).
+		Times(1)
+
+	err := s.service.RevokeFamily(s.ctx, s.parent)
+
+	s.EqualError(err, expectedErrorMsg)
+	s.True(errpkg.Has(err, errors.ErrRevoked))
+}
+
+func (s *RefreshTestSuite) TestRotateFailed() {
+	const (
+		expectedErrorMsg = "unknown error on updating refresh token"
+	)
+
+	s.mock.
+		EXPECT().
+		MarkUsed(s.ctx, s.parent.ID).
+		Return(errors.ErrInternal.New(expectedErrorMsg)).
+		Times(1)
+
+	_, _, err := s.service.Rotate(s.ctx, s.user, s.parent, s.device)
+
+	s.EqualError(err, expectedErrorMsg)
+}
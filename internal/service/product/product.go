@@ -4,18 +4,26 @@ import (
 	"context"
 	"github.com/jackvonhouse/product-catalog/internal/dto"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"time"
 )
 
 type repository interface {
-	Create(context.Context, dto.CreateProduct, dto.Category) (int, error)
+	Create(context.Context, dto.CreateProduct, []dto.Category) (int, error)
+	AttachToCategory(context.Context, dto.Product, dto.Category) error
+	Import(context.Context, []dto.ImportProduct, bool) (dto.ImportResult, error)
 
-	Get(context.Context, dto.GetProduct) ([]dto.Product, error)
+	Get(context.Context, dto.GetProduct) (dto.ProductPage, error)
 	GetById(context.Context, int) (dto.Product, error)
-	GetByCategoryId(context.Context, dto.GetProduct, dto.Category) ([]dto.Product, error)
+	Search(context.Context, dto.SearchProduct) ([]dto.FoundProduct, error)
+	Export(context.Context, dto.GetProduct, func(dto.ExportProduct) error) error
 
 	Update(context.Context, dto.UpdateProduct, dto.Product, dto.Category) (int, error)
 
-	Delete(context.Context, dto.Product) (int, error)
+	DetachFromCategory(context.Context, dto.Product, dto.Category) error
+
+	Delete(context.Context, dto.Product, int) (int, error)
+	Restore(context.Context, int) (int, error)
+	Purge(context.Context, time.Time) (int64, error)
 }
 
 type Service struct {
@@ -38,16 +46,34 @@ func New(
 func (s Service) Create(
 	ctx context.Context,
 	data dto.CreateProduct,
-	category dto.Category,
+	categories []dto.Category,
 ) (int, error) {
 
-	return s.repository.Create(ctx, data, category)
+	return s.repository.Create(ctx, data, categories)
+}
+
+func (s Service) AttachToCategory(
+	ctx context.Context,
+	product dto.Product,
+	category dto.Category,
+) error {
+
+	return s.repository.AttachToCategory(ctx, product, category)
+}
+
+func (s Service) Import(
+	ctx context.Context,
+	rows []dto.ImportProduct,
+	dryRun bool,
+) (dto.ImportResult, error) {
+
+	return s.repository.Import(ctx, rows, dryRun)
 }
 
 func (s Service) Get(
 	ctx context.Context,
 	data dto.GetProduct,
-) ([]dto.Product, error) {
+) (dto.ProductPage, error) {
 
 	return s.repository.Get(ctx, data)
 }
@@ -60,13 +86,21 @@ func (s Service) GetById(
 	return s.repository.GetById(ctx, id)
 }
 
-func (s Service) GetByCategoryId(
+func (s Service) Export(
 	ctx context.Context,
 	data dto.GetProduct,
-	category dto.Category,
-) ([]dto.Product, error) {
+	write func(dto.ExportProduct) error,
+) error {
+
+	return s.repository.Export(ctx, data, write)
+}
+
+func (s Service) Search(
+	ctx context.Context,
+	data dto.SearchProduct,
+) ([]dto.FoundProduct, error) {
 
-	return s.repository.GetByCategoryId(ctx, data, category)
+	return s.repository.Search(ctx, data)
 }
 
 func (s Service) Update(
@@ -85,9 +119,19 @@ func (s Service) Update(
 	return s.repository.Update(ctx, data, product, category)
 }
 
+func (s Service) DetachFromCategory(
+	ctx context.Context,
+	product dto.Product,
+	category dto.Category,
+) error {
+
+	return s.repository.DetachFromCategory(ctx, product, category)
+}
+
 func (s Service) Delete(
 	ctx context.Context,
 	id int,
+	version int,
 ) (int, error) {
 
 	product, err := s.repository.GetById(ctx, id)
@@ -97,5 +141,21 @@ func (s Service) Delete(
 		return 0, err
 	}
 
-	return s.repository.Delete(ctx, product)
+	return s.repository.Delete(ctx, product, version)
+}
+
+func (s Service) Restore(
+	ctx context.Context,
+	id int,
+) (int, error) {
+
+	return s.repository.Restore(ctx, id)
+}
+
+func (s Service) Purge(
+	ctx context.Context,
+	deletedBefore time.Time,
+) (int64, error) {
+
+	return s.repository.Purge(ctx, deletedBefore)
 }
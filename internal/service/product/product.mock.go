@@ -12,6 +12,7 @@ package product
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	dto "github.com/jackvonhouse/product-catalog/internal/dto"
 	gomock "go.uber.org/mock/gomock"
@@ -40,8 +41,22 @@ func (m *Mockrepository) EXPECT() *MockrepositoryMockRecorder {
 	return m.recorder
 }
 
+// AttachToCategory mocks base method.
+func (m *Mockrepository) AttachToCategory(arg0 context.Context, arg1 dto.Product, arg2 dto.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachToCategory", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachToCategory indicates an expected call of AttachToCategory.
+func (mr *MockrepositoryMockRecorder) AttachToCategory(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachToCategory", reflect.TypeOf((*Mockrepository)(nil).AttachToCategory), arg0, arg1, arg2)
+}
+
 // Create mocks base method.
-func (m *Mockrepository) Create(arg0 context.Context, arg1 dto.CreateProduct, arg2 dto.Category) (int, error) {
+func (m *Mockrepository) Create(arg0 context.Context, arg1 dto.CreateProduct, arg2 []dto.Category) (int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
 	ret0, _ := ret[0].(int)
@@ -56,48 +71,61 @@ func (mr *MockrepositoryMockRecorder) Create(arg0, arg1, arg2 any) *gomock.Call
 }
 
 // Delete mocks base method.
-func (m *Mockrepository) Delete(arg0 context.Context, arg1 dto.Product) (int, error) {
+func (m *Mockrepository) Delete(arg0 context.Context, arg1 dto.Product, arg2 int) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockrepositoryMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+func (mr *MockrepositoryMockRecorder) Delete(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*Mockrepository)(nil).Delete), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*Mockrepository)(nil).Delete), arg0, arg1, arg2)
 }
 
-// Get mocks base method.
-func (m *Mockrepository) Get(arg0 context.Context, arg1 dto.GetProduct) ([]dto.Product, error) {
+// DetachFromCategory mocks base method.
+func (m *Mockrepository) DetachFromCategory(arg0 context.Context, arg1 dto.Product, arg2 dto.Category) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", arg0, arg1)
-	ret0, _ := ret[0].([]dto.Product)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "DetachFromCategory", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// Get indicates an expected call of Get.
-func (mr *MockrepositoryMockRecorder) Get(arg0, arg1 any) *gomock.Call {
+// DetachFromCategory indicates an expected call of DetachFromCategory.
+func (mr *MockrepositoryMockRecorder) DetachFromCategory(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*Mockrepository)(nil).Get), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachFromCategory", reflect.TypeOf((*Mockrepository)(nil).DetachFromCategory), arg0, arg1, arg2)
 }
 
-// GetByCategoryId mocks base method.
-func (m *Mockrepository) GetByCategoryId(arg0 context.Context, arg1 dto.GetProduct, arg2 dto.Category) ([]dto.Product, error) {
+// Export mocks base method.
+func (m *Mockrepository) Export(arg0 context.Context, arg1 dto.GetProduct, arg2 func(dto.ExportProduct) error) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByCategoryId", arg0, arg1, arg2)
-	ret0, _ := ret[0].([]dto.Product)
+	ret := m.ctrl.Call(m, "Export", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockrepositoryMockRecorder) Export(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*Mockrepository)(nil).Export), arg0, arg1, arg2)
+}
+
+// Get mocks base method.
+func (m *Mockrepository) Get(arg0 context.Context, arg1 dto.GetProduct) (dto.ProductPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(dto.ProductPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByCategoryId indicates an expected call of GetByCategoryId.
-func (mr *MockrepositoryMockRecorder) GetByCategoryId(arg0, arg1, arg2 any) *gomock.Call {
+// Get indicates an expected call of Get.
+func (mr *MockrepositoryMockRecorder) Get(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCategoryId", reflect.TypeOf((*Mockrepository)(nil).GetByCategoryId), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*Mockrepository)(nil).Get), arg0, arg1)
 }
 
 // GetById mocks base method.
@@ -115,6 +143,66 @@ func (mr *MockrepositoryMockRecorder) GetById(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*Mockrepository)(nil).GetById), arg0, arg1)
 }
 
+// Import mocks base method.
+func (m *Mockrepository) Import(arg0 context.Context, arg1 []dto.ImportProduct, arg2 bool) (dto.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", arg0, arg1, arg2)
+	ret0, _ := ret[0].(dto.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockrepositoryMockRecorder) Import(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*Mockrepository)(nil).Import), arg0, arg1, arg2)
+}
+
+// Purge mocks base method.
+func (m *Mockrepository) Purge(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockrepositoryMockRecorder) Purge(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*Mockrepository)(nil).Purge), arg0, arg1)
+}
+
+// Restore mocks base method.
+func (m *Mockrepository) Restore(arg0 context.Context, arg1 int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockrepositoryMockRecorder) Restore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*Mockrepository)(nil).Restore), arg0, arg1)
+}
+
+// Search mocks base method.
+func (m *Mockrepository) Search(arg0 context.Context, arg1 dto.SearchProduct) ([]dto.FoundProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0, arg1)
+	ret0, _ := ret[0].([]dto.FoundProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockrepositoryMockRecorder) Search(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*Mockrepository)(nil).Search), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *Mockrepository) Update(arg0 context.Context, arg1 dto.UpdateProduct, arg2 dto.Product, arg3 dto.Category) (int, error) {
 	m.ctrl.T.Helper()
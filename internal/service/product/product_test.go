@@ -74,8 +74,8 @@ func (s *ProductTestSuite) setupCreateProduct(
 ) *ProductTestSuite {
 
 	s.create = dto.CreateProduct{
-		Name:       name,
-		CategoryId: categoryId,
+		Name:        name,
+		CategoryIds: []int{categoryId},
 	}
 
 	return s
@@ -138,50 +138,74 @@ func (s *ProductTestSuite) setupCategory(
 func (s *ProductTestSuite) TestCreateSuccessful() {
 	s.mock.
 		EXPECT().
-		Create(s.ctx, s.create, s.category).
+		Create(s.ctx, s.create, []dto.Category{s.category}).
 		Return(1, nil)
 
-	productId, err := s.service.Create(s.ctx, s.create, s.category)
+	productId, err := s.service.Create(s.ctx, s.create, []dto.Category{s.category})
 
 	s.NoError(err)
 	s.Equal(1, productId)
 }
 
+func (s *ProductTestSuite) TestAttachToCategorySuccessful() {
+	s.mock.
+		EXPECT().
+		AttachToCategory(s.ctx, s.product, s.category).
+		Return(nil)
+
+	err := s.service.AttachToCategory(s.ctx, s.product, s.category)
+
+	s.NoError(err)
+}
+
+func (s *ProductTestSuite) TestDetachFromCategorySuccessful() {
+	s.mock.
+		EXPECT().
+		DetachFromCategory(s.ctx, s.product, s.category).
+		Return(nil)
+
+	err := s.service.DetachFromCategory(s.ctx, s.product, s.category)
+
+	s.NoError(err)
+}
+
 func (s *ProductTestSuite) TestGetSuccessful() {
 	s.mock.
 		EXPECT().
 		Get(s.ctx, s.get).
-		Return(s.products, nil)
+		Return(dto.ProductPage{Items: s.products, Total: 1}, nil)
 
 	products, err := s.service.Get(s.ctx, s.get)
 
 	s.NoError(err)
-	s.Equal(s.products, products)
+	s.Equal(dto.ProductPage{Items: s.products, Total: 1}, products)
 }
 
-func (s *ProductTestSuite) TestGetByIdSuccessful() {
+func (s *ProductTestSuite) TestSearchSuccessful() {
+	search := dto.SearchProduct{Query: "корм", Limit: s.get.Limit, Offset: s.get.Offset}
+	found := []dto.FoundProduct{{Product: s.product, Rank: 0.5, Snippet: "<b>корм</b>"}}
+
 	s.mock.
 		EXPECT().
-		GetById(s.ctx, s.product.ID).
-		Return(s.product, nil)
+		Search(s.ctx, search).
+		Return(found, nil)
 
-	product, err := s.service.GetById(s.ctx, s.product.ID)
+	products, err := s.service.Search(s.ctx, search)
 
 	s.NoError(err)
-	s.Equal(s.product, product)
+	s.Equal(found, products)
 }
 
-func (s *ProductTestSuite) TestGetByCategoryIdSuccessful() {
+func (s *ProductTestSuite) TestGetByIdSuccessful() {
 	s.mock.
 		EXPECT().
-		GetByCategoryId(s.ctx, s.get, s.category).
-		Return(s.products, nil).
-		Times(1)
+		GetById(s.ctx, s.product.ID).
+		Return(s.product, nil)
 
-	products, err := s.service.GetByCategoryId(s.ctx, s.get, s.category)
+	product, err := s.service.GetById(s.ctx, s.product.ID)
 
 	s.NoError(err)
-	s.Equal(s.products, products)
+	s.Equal(s.product, product)
 }
 
 func (s *ProductTestSuite) TestUpdateSuccessful() {
@@ -232,11 +256,11 @@ func (s *ProductTestSuite) TestDeleteSuccessful() {
 
 	s.mock.
 		EXPECT().
-		Delete(s.ctx, s.product).
+		Delete(s.ctx, s.product, 0).
 		Return(s.product.ID, nil).
 		Times(1)
 
-	productId, err := s.service.Delete(s.ctx, s.product.ID)
+	productId, err := s.service.Delete(s.ctx, s.product.ID, 0)
 
 	s.NoError(err)
 	s.Equal(1, productId)
@@ -257,7 +281,7 @@ func (s *ProductTestSuite) TestDeleteFailure() {
 		Return(dto.Product{}, expectedError).
 		Times(1)
 
-	productId, err := s.service.Delete(s.ctx, s.product.ID)
+	productId, err := s.service.Delete(s.ctx, s.product.ID, 0)
 
 	s.NotNil(err)
 	s.Equal(expectedError.Error(), err.Error())
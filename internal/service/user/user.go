@@ -12,6 +12,10 @@ type repository interface {
 	Create(context.Context, dto.Credentials) (int, error)
 
 	GetByUsername(context.Context, string) (dto.User, error)
+	CountByRole(context.Context, string) (int, error)
+	LockRole(context.Context, string) error
+
+	UpdateRole(context.Context, dto.UpdateRole) error
 }
 
 type Service struct {
@@ -52,6 +56,10 @@ func (s Service) Create(
 
 	credentials.Password = string(password)
 
+	if credentials.Role == "" {
+		credentials.Role = dto.RoleViewer
+	}
+
 	return s.repository.Create(ctx, credentials)
 }
 
@@ -63,6 +71,30 @@ func (s Service) GetByUsername(
 	return s.repository.GetByUsername(ctx, userName)
 }
 
+func (s Service) CountByRole(
+	ctx context.Context,
+	role string,
+) (int, error) {
+
+	return s.repository.CountByRole(ctx, role)
+}
+
+func (s Service) LockRole(
+	ctx context.Context,
+	role string,
+) error {
+
+	return s.repository.LockRole(ctx, role)
+}
+
+func (s Service) UpdateRole(
+	ctx context.Context,
+	data dto.UpdateRole,
+) error {
+
+	return s.repository.UpdateRole(ctx, data)
+}
+
 func (s Service) Verify(
 	ctx context.Context,
 	credentials dto.Credentials,
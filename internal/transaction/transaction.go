@@ -0,0 +1,139 @@
+package transaction
+
+import (
+	"context"
+	"github.com/jackvonhouse/product-catalog/internal/repository/errors"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/jmoiron/sqlx"
+)
+
+type contextKey struct{}
+
+// unit — транзакция, открытая Manager и переданная через контекст
+type unit struct {
+	tx          *sqlx.Tx
+	afterCommit []func()
+}
+
+// Manager открывает транзакцию и передаёт её через контекст, чтобы
+// репозитории выполняли запросы в ней, а не в отдельных транзакциях.
+// Вложенный вызов присоединяется к уже открытой транзакции
+type Manager struct {
+	db *sqlx.DB
+
+	logger log.Logger
+}
+
+func New(
+	db *sqlx.DB,
+	logger log.Logger,
+) Manager {
+
+	return Manager{
+		db:     db,
+		logger: logger.WithField("unit", "transaction"),
+	}
+}
+
+// Do выполняет fn как единицу работы: изменения всех репозиториев внутри fn
+// фиксируются вместе, если fn завершилась без ошибки, и отменяются иначе
+func (m Manager) Do(
+	ctx context.Context,
+	fn func(context.Context) error,
+) error {
+
+	return m.Run(ctx, errInternal, func(ctx context.Context, _ *sqlx.Tx) error {
+		return fn(ctx)
+	})
+}
+
+// Run выполняет fn в транзакции из контекста, а если её нет — в новой.
+// Ошибки открытия и завершения новой транзакции оборачиваются в wrap,
+// чтобы вызывающий вернул их от своего имени
+func (m Manager) Run(
+	ctx context.Context,
+	wrap func(error) error,
+	fn func(context.Context, *sqlx.Tx) error,
+) error {
+
+	if u, ok := ctx.Value(contextKey{}).(*unit); ok {
+		return fn(ctx, u.tx)
+	}
+
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		m.logger.Warnf("unknown error on starting transaction: %s", err)
+
+		return wrap(err)
+	}
+
+	u := &unit{tx: tx}
+
+	if err := fn(context.WithValue(ctx, contextKey{}, u), tx); err != nil {
+		if rErr := tx.Rollback(); rErr != nil {
+			m.logger.Warnf("unknown error on rollback: %s", rErr)
+
+			return wrap(rErr)
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		m.logger.Warnf("unknown error on commit: %s", err)
+
+		return wrap(err)
+	}
+
+	for _, callback := range u.afterCommit {
+		callback()
+	}
+
+	return nil
+}
+
+// Executor возвращает транзакцию из контекста, а если её нет — соединение с базой
+func (m Manager) Executor(
+	ctx context.Context,
+) sqlx.ExtContext {
+
+	if u, ok := ctx.Value(contextKey{}).(*unit); ok {
+		return u.tx
+	}
+
+	return m.db
+}
+
+// InProgress сообщает, выполняется ли вызов внутри транзакции
+func InProgress(
+	ctx context.Context,
+) bool {
+
+	_, ok := ctx.Value(contextKey{}).(*unit)
+
+	return ok
+}
+
+// AfterCommit откладывает callback до фиксации транзакции из контекста.
+// Вне транзакции callback выполняется сразу, а при откате не выполняется
+func AfterCommit(
+	ctx context.Context,
+	callback func(),
+) {
+
+	u, ok := ctx.Value(contextKey{}).(*unit)
+	if !ok {
+		callback()
+
+		return
+	}
+
+	u.afterCommit = append(u.afterCommit, callback)
+}
+
+func errInternal(
+	err error,
+) error {
+
+	return errors.ErrInternal("executing", "transaction", err)
+}
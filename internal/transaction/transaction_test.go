@@ -0,0 +1,147 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type TransactionTestSuite struct {
+	suite.Suite
+
+	// Вспомогательные параметры
+	ctx     context.Context
+	logger  log.Logger
+	db      *sqlx.DB
+	manager Manager
+
+	// Служебные параметры
+	mock sqlmock.Sqlmock
+}
+
+func TestSuiteTransaction(t *testing.T) {
+	suite.Run(t, &TransactionTestSuite{})
+}
+
+func (s *TransactionTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.logger = log.NewNullLogger()
+}
+
+func (s *TransactionTestSuite) BeforeTest(_, _ string) {
+	db, mock, err := sqlmock.New()
+	s.NoError(err)
+
+	s.db = sqlx.NewDb(db, "sqlmock")
+	s.mock = mock
+	s.manager = New(s.db, s.logger)
+}
+
+func (s *TransactionTestSuite) AfterTest(_, _ string) {
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionTestSuite) TestDoCommit() {
+	s.mock.ExpectBegin()
+	s.mock.ExpectCommit()
+
+	committed := false
+
+	err := s.manager.Do(s.ctx, func(ctx context.Context) error {
+		s.True(InProgress(ctx))
+		s.NotEqual(s.db, s.manager.Executor(ctx))
+
+		AfterCommit(ctx, func() { committed = true })
+		s.False(committed)
+
+		return nil
+	})
+
+	s.NoError(err)
+	s.True(committed)
+	s.False(InProgress(s.ctx))
+	s.Equal(s.db, s.manager.Executor(s.ctx))
+}
+
+func (s *TransactionTestSuite) TestDoRollback() {
+	expectedErr := errors.New("operation failed")
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectRollback()
+
+	committed := false
+
+	err := s.manager.Do(s.ctx, func(ctx context.Context) error {
+		AfterCommit(ctx, func() { committed = true })
+
+		return expectedErr
+	})
+
+	s.ErrorIs(err, expectedErr)
+	s.False(committed)
+}
+
+func (s *TransactionTestSuite) TestDoNested() {
+	s.mock.ExpectBegin()
+	s.mock.ExpectCommit()
+
+	err := s.manager.Do(s.ctx, func(ctx context.Context) error {
+		outer := s.manager.Executor(ctx)
+
+		return s.manager.Do(ctx, func(ctx context.Context) error {
+			s.Equal(outer, s.manager.Executor(ctx))
+
+			return nil
+		})
+	})
+
+	s.NoError(err)
+}
+
+func (s *TransactionTestSuite) TestDoBeginFailed() {
+	const (
+		expectedErrorMsg = "unknown error on executing transaction"
+	)
+
+	s.mock.ExpectBegin().WillReturnError(errors.New("connection refused"))
+
+	err := s.manager.Do(s.ctx, func(ctx context.Context) error {
+		s.Fail("fn must not be called")
+
+		return nil
+	})
+
+	s.EqualError(err, expectedErrorMsg)
+}
+
+func (s *TransactionTestSuite) TestDoCommitFailed() {
+	const (
+		expectedErrorMsg = "unknown error on executing transaction"
+	)
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectCommit().WillReturnError(errors.New("connection reset"))
+
+	committed := false
+
+	err := s.manager.Do(s.ctx, func(ctx context.Context) error {
+		AfterCommit(ctx, func() { committed = true })
+
+		return nil
+	})
+
+	s.EqualError(err, expectedErrorMsg)
+	s.False(committed)
+}
+
+func (s *TransactionTestSuite) TestAfterCommitWithoutTransaction() {
+	committed := false
+
+	AfterCommit(s.ctx, func() { committed = true })
+
+	s.True(committed)
+}
@@ -0,0 +1,132 @@
+package audit
+
+import (
+	"context"
+	"github.com/gorilla/mux"
+	"github.com/jackvonhouse/product-catalog/internal/audit"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/transport"
+	"github.com/jackvonhouse/product-catalog/internal/transport/middleware"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"net/http"
+	"time"
+)
+
+type useCaseAudit interface {
+	Get(context.Context, dto.GetAudit) (dto.AuditPage, error)
+}
+
+type useCaseAccessToken interface {
+	Parse(context.Context, string) (dto.AccessToken, error)
+}
+
+type Transport struct {
+	useCase useCaseAudit
+
+	mw     middleware.Middleware
+	logger log.Logger
+}
+
+func New(
+	audit useCaseAudit,
+	accessToken useCaseAccessToken,
+	logger log.Logger,
+) Transport {
+
+	return Transport{
+		useCase: audit,
+		mw:      middleware.New(accessToken, logger),
+		logger:  logger.WithField("unit", "audit"),
+	}
+}
+
+func (t Transport) Handle(
+	router *mux.Router,
+) {
+
+	adminsOnly := router.PathPrefix("").Subrouter()
+	adminsOnly.Use(t.mw.RequireRole(dto.RoleAdmin))
+
+	adminsOnly.HandleFunc("", t.Get).
+		Methods(http.MethodGet)
+}
+
+// Get godoc
+// @Summary			Получить журнал изменений
+// @Description		Получение страницы журнала изменений товаров и категорий: кто, когда и что изменил, состояние сущности до и после изменения. Записи упорядочены от новых к старым. Доступно только администраторам
+// @Security		Bearer
+// @Accept			json
+// @Produce			json
+// @Param			entity query string true "Тип сущности: product или category"
+// @Param			id query int false "Идентификатор сущности"
+// @Param			limit query int false "Лимит"
+// @Param			offset query int false "Смещение"
+// @Success			200 {object} transport.Page{items=[]dto.AuditRecord}
+// @Header			200 {int} X-Total-Count "Общее количество записей"
+// @Failure			400 {object} object{error=string} "Некорректный тип или идентификатор сущности"
+// @Failure			401 {object} object{error=string} "Пользователь не авторизован"
+// @Failure			403 {object} object{error=string} "Недостаточно прав"
+// @Failure			500 {object} object{error=string} "Неизвестная ошибка"
+// @Tags			Журнал изменений
+// @Router /audit [get]
+func (t Transport) Get(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+
+	queries := r.URL.Query()
+
+	limit, err := transport.StringToInt(queries.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 10
+	}
+
+	offset, err := transport.StringToInt(queries.Get("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	entity := queries.Get("entity")
+	if entity != audit.EntityProduct && entity != audit.EntityCategory {
+		transport.Error(w, http.StatusBadRequest, "invalid entity")
+
+		return
+	}
+
+	entityId := 0
+
+	if queries.Has("id") {
+		entityId, err = transport.StringToInt(queries.Get("id"))
+		if err != nil || entityId <= 0 {
+			transport.Error(w, http.StatusBadRequest, "invalid entity id")
+
+			return
+		}
+	}
+
+	data := dto.GetAudit{
+		Entity:   entity,
+		EntityId: entityId,
+		Limit:    limit,
+		Offset:   offset,
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	records, err := t.useCase.Get(ctx, data)
+	if err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	transport.PageResponse(
+		w,
+		transport.OffsetPage(r, records.Items, records.Total, limit, offset),
+	)
+}
@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"encoding/json"
+	"github.com/jackvonhouse/product-catalog/internal/transport/middleware"
 	"github.com/jackvonhouse/product-catalog/internal/transport/validator"
 	"net/http"
 	"time"
@@ -14,24 +15,38 @@ import (
 )
 
 type useCaseAuth interface {
-	SignUp(context.Context, dto.Credentials) (dto.TokenPair, error)
-	SignIn(context.Context, dto.Credentials) (dto.TokenPair, error)
-	Refresh(context.Context, dto.TokenPair) (dto.TokenPair, error)
+	SignUp(context.Context, dto.Credentials, dto.Device) (dto.TokenPair, error)
+	SignIn(context.Context, dto.Credentials, dto.Device) (dto.TokenPair, error)
+	Refresh(context.Context, dto.TokenPair, dto.Device) (dto.TokenPair, error)
+
+	GetSessions(context.Context) ([]dto.Session, error)
+	DeleteSession(context.Context, int) error
+	SignOut(context.Context) error
+	SignOutAll(context.Context) error
+
+	UpdateRole(context.Context, dto.UpdateRole) error
+}
+
+type useCaseAccessToken interface {
+	Parse(context.Context, string) (dto.AccessToken, error)
 }
 
 type Transport struct {
 	useCase useCaseAuth
 
+	mw     middleware.Middleware
 	logger log.Logger
 }
 
 func New(
 	auth useCaseAuth,
+	accessToken useCaseAccessToken,
 	logger log.Logger,
 ) Transport {
 
 	return Transport{
 		useCase: auth,
+		mw:      middleware.New(accessToken, logger),
 		logger:  logger.WithField("layer", "transport"),
 	}
 }
@@ -48,6 +63,27 @@ func (t Transport) Handle(
 
 	router.HandleFunc("/refresh", t.Refresh).
 		Methods(http.MethodPost)
+
+	authorized := router.PathPrefix("").Subrouter()
+	authorized.Use(t.mw.AuthorizedOnly)
+
+	authorized.HandleFunc("/sessions", t.GetSessions).
+		Methods(http.MethodGet)
+
+	authorized.HandleFunc("/sessions/{id:[0-9]+}", t.DeleteSession).
+		Methods(http.MethodDelete)
+
+	authorized.HandleFunc("/sign-out", t.SignOut).
+		Methods(http.MethodPost)
+
+	authorized.HandleFunc("/sign-out-all", t.SignOutAll).
+		Methods(http.MethodPost)
+
+	adminsOnly := router.PathPrefix("").Subrouter()
+	adminsOnly.Use(t.mw.RequireRole(dto.RoleAdmin))
+
+	adminsOnly.HandleFunc("/{id:[0-9]+}/role", t.UpdateRole).
+		Methods(http.MethodPut)
 }
 
 // SignUp godoc
@@ -55,7 +91,7 @@ func (t Transport) Handle(
 // @Description		Регистрация нового пользователя
 // @Accept			json
 // @Produce			json
-// @Param			request body object{username=string,password=string} true "Данные пользователя"
+// @Param			request body object{username=string,password=string,device_name=string} true "Данные пользователя и необязательное имя устройства"
 // @Success			200 {object} dto.TokenPair
 // @Failure			409 {object} object{error=string} "Пользователь уже существует"
 // @Failure			500 {object} object{error=string} "Неизвестная ошибка"
@@ -67,8 +103,9 @@ func (t Transport) SignUp(
 ) {
 
 	var data struct {
-		Username string `json:"username"`
-		Password string `json:"password"`
+		Username   string `json:"username"`
+		Password   string `json:"password"`
+		DeviceName string `json:"device_name"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
@@ -97,7 +134,7 @@ func (t Transport) SignUp(
 		Password: data.Password,
 	}
 
-	tokenPair, err := t.useCase.SignUp(ctx, signUp)
+	tokenPair, err := t.useCase.SignUp(ctx, signUp, device(r, data.DeviceName))
 	if err != nil {
 		t.logger.Warn(err)
 
@@ -113,10 +150,10 @@ func (t Transport) SignUp(
 
 // SignIn godoc
 // @Summary			Авторизация
-// @Description		Авторизация пользователя
+// @Description		Авторизация пользователя. Каждый вход открывает отдельную сессию, сессии на других устройствах сохраняются
 // @Accept			json
 // @Produce			json
-// @Param			request body object{username=string,password=string} true "Данные пользователя"
+// @Param			request body object{username=string,password=string,device_name=string} true "Данные пользователя и необязательное имя устройства"
 // @Success			200 {object} dto.TokenPair
 // @Failure			500 {object} object{error=string} "Неизвестная ошибка"
 // @Tags			Авторизация
@@ -127,8 +164,9 @@ func (t Transport) SignIn(
 ) {
 
 	var data struct {
-		Username string `json:"username"`
-		Password string `json:"password"`
+		Username   string `json:"username"`
+		Password   string `json:"password"`
+		DeviceName string `json:"device_name"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
@@ -147,7 +185,7 @@ func (t Transport) SignIn(
 		Password: data.Password,
 	}
 
-	tokenPair, err := t.useCase.SignIn(ctx, signIn)
+	tokenPair, err := t.useCase.SignIn(ctx, signIn, device(r, data.DeviceName))
 	if err != nil {
 		t.logger.Warn(err)
 
@@ -163,11 +201,12 @@ func (t Transport) SignIn(
 
 // Refresh godoc
 // @Summary			Обновление токенов
-// @Description		Обновление токенов
+// @Description		Обновление токенов. Refresh-токен одноразовый: повторное использование отзывает все токены, выпущенные вместе с ним
 // @Accept			json
 // @Produce			json
 // @Param			request body object{access_token=string,refresh_token=string} true "Пара токенов"
 // @Success			200 {object} dto.TokenPair
+// @Failure			401 {object} object{error=string} "Токен истёк или отозван"
 // @Failure			500 {object} object{error=string} "Неизвестная ошибка"
 // @Tags			Авторизация
 // @Router /user/refresh [post]
@@ -201,7 +240,7 @@ func (t Transport) Refresh(
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
-	tokenPair, err := t.useCase.Refresh(ctx, data)
+	tokenPair, err := t.useCase.Refresh(ctx, data, device(r, ""))
 	if err != nil {
 		t.logger.Warn(err)
 
@@ -214,3 +253,77 @@ func (t Transport) Refresh(
 
 	transport.Response(w, tokenPair)
 }
+
+// UpdateRole godoc
+// @Summary			Изменить роль пользователя
+// @Description		Изменение роли пользователя (admin, editor, viewer). Доступно только администраторам. Новая роль применяется после обновления токенов
+// @Security		Bearer
+// @Accept			json
+// @Produce			json
+// @Param			id path int true "Идентификатор пользователя"
+// @Param			request body object{role=string} true "Новая роль"
+// @Success			200 {object} object{id=int,role=string}
+// @Failure			400 {object} object{error=string} "Некорректная роль"
+// @Failure			401 {object} object{error=string} "Пользователь не авторизован"
+// @Failure			403 {object} object{error=string} "Недостаточно прав"
+// @Failure			404 {object} object{error=string} "Пользователь не найден"
+// @Failure			500 {object} object{error=string} "Неизвестная ошибка"
+// @Tags			Авторизация
+// @Router /user/{id}/role [put]
+func (t Transport) UpdateRole(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+
+	userId, err := transport.StringToInt(mux.Vars(r)["id"])
+	if err != nil || userId <= 0 {
+		transport.Error(w, http.StatusBadRequest, "invalid user id")
+
+		return
+	}
+
+	var data struct {
+		Role string `json:"role"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		t.logger.Warn(err)
+
+		transport.Error(w, http.StatusBadRequest, "invalid json structure")
+
+		return
+	}
+
+	if err := validator.IsValidRole(data.Role); err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	updateRole := dto.UpdateRole{
+		UserId: userId,
+		Role:   data.Role,
+	}
+
+	if err := t.useCase.UpdateRole(ctx, updateRole); err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	transport.Response(w, map[string]any{
+		"id":   userId,
+		"role": data.Role,
+	})
+}
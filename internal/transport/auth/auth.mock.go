@@ -0,0 +1,195 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/transport/auth/auth.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/transport/auth/auth.go -destination=internal/transport/auth/auth.mock.go -package=auth
+//
+
+// Package auth is a generated GoMock package.
+package auth
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/jackvonhouse/product-catalog/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockuseCaseAuth is a mock of useCaseAuth interface.
+type MockuseCaseAuth struct {
+	ctrl     *gomock.Controller
+	recorder *MockuseCaseAuthMockRecorder
+}
+
+// MockuseCaseAuthMockRecorder is the mock recorder for MockuseCaseAuth.
+type MockuseCaseAuthMockRecorder struct {
+	mock *MockuseCaseAuth
+}
+
+// NewMockuseCaseAuth creates a new mock instance.
+func NewMockuseCaseAuth(ctrl *gomock.Controller) *MockuseCaseAuth {
+	mock := &MockuseCaseAuth{ctrl: ctrl}
+	mock.recorder = &MockuseCaseAuthMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuseCaseAuth) EXPECT() *MockuseCaseAuthMockRecorder {
+	return m.recorder
+}
+
+// DeleteSession mocks base method.
+func (m *MockuseCaseAuth) DeleteSession(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockuseCaseAuthMockRecorder) DeleteSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockuseCaseAuth)(nil).DeleteSession), arg0, arg1)
+}
+
+// GetSessions mocks base method.
+func (m *MockuseCaseAuth) GetSessions(arg0 context.Context) ([]dto.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessions", arg0)
+	ret0, _ := ret[0].([]dto.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessions indicates an expected call of GetSessions.
+func (mr *MockuseCaseAuthMockRecorder) GetSessions(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessions", reflect.TypeOf((*MockuseCaseAuth)(nil).GetSessions), arg0)
+}
+
+// Refresh mocks base method.
+func (m *MockuseCaseAuth) Refresh(arg0 context.Context, arg1 dto.TokenPair, arg2 dto.Device) (dto.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", arg0, arg1, arg2)
+	ret0, _ := ret[0].(dto.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockuseCaseAuthMockRecorder) Refresh(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockuseCaseAuth)(nil).Refresh), arg0, arg1, arg2)
+}
+
+// SignIn mocks base method.
+func (m *MockuseCaseAuth) SignIn(arg0 context.Context, arg1 dto.Credentials, arg2 dto.Device) (dto.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignIn", arg0, arg1, arg2)
+	ret0, _ := ret[0].(dto.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignIn indicates an expected call of SignIn.
+func (mr *MockuseCaseAuthMockRecorder) SignIn(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockuseCaseAuth)(nil).SignIn), arg0, arg1, arg2)
+}
+
+// SignOut mocks base method.
+func (m *MockuseCaseAuth) SignOut(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignOut", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SignOut indicates an expected call of SignOut.
+func (mr *MockuseCaseAuthMockRecorder) SignOut(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignOut", reflect.TypeOf((*MockuseCaseAuth)(nil).SignOut), arg0)
+}
+
+// SignOutAll mocks base method.
+func (m *MockuseCaseAuth) SignOutAll(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignOutAll", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SignOutAll indicates an expected call of SignOutAll.
+func (mr *MockuseCaseAuthMockRecorder) SignOutAll(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignOutAll", reflect.TypeOf((*MockuseCaseAuth)(nil).SignOutAll), arg0)
+}
+
+// SignUp mocks base method.
+func (m *MockuseCaseAuth) SignUp(arg0 context.Context, arg1 dto.Credentials, arg2 dto.Device) (dto.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignUp", arg0, arg1, arg2)
+	ret0, _ := ret[0].(dto.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignUp indicates an expected call of SignUp.
+func (mr *MockuseCaseAuthMockRecorder) SignUp(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignUp", reflect.TypeOf((*MockuseCaseAuth)(nil).SignUp), arg0, arg1, arg2)
+}
+
+// UpdateRole mocks base method.
+func (m *MockuseCaseAuth) UpdateRole(arg0 context.Context, arg1 dto.UpdateRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockuseCaseAuthMockRecorder) UpdateRole(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockuseCaseAuth)(nil).UpdateRole), arg0, arg1)
+}
+
+// MockuseCaseAccessToken is a mock of useCaseAccessToken interface.
+type MockuseCaseAccessToken struct {
+	ctrl     *gomock.Controller
+	recorder *MockuseCaseAccessTokenMockRecorder
+}
+
+// MockuseCaseAccessTokenMockRecorder is the mock recorder for MockuseCaseAccessToken.
+type MockuseCaseAccessTokenMockRecorder struct {
+	mock *MockuseCaseAccessToken
+}
+
+// NewMockuseCaseAccessToken creates a new mock instance.
+func NewMockuseCaseAccessToken(ctrl *gomock.Controller) *MockuseCaseAccessToken {
+	mock := &MockuseCaseAccessToken{ctrl: ctrl}
+	mock.recorder = &MockuseCaseAccessTokenMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuseCaseAccessToken) EXPECT() *MockuseCaseAccessTokenMockRecorder {
+	return m.recorder
+}
+
+// Parse mocks base method.
+func (m *MockuseCaseAccessToken) Parse(arg0 context.Context, arg1 string) (dto.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Parse", arg0, arg1)
+	ret0, _ := ret[0].(dto.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Parse indicates an expected call of Parse.
+func (mr *MockuseCaseAccessTokenMockRecorder) Parse(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockuseCaseAccessToken)(nil).Parse), arg0, arg1)
+}
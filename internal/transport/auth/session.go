@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"context"
+	"github.com/gorilla/mux"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/transport"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	maxDeviceNameLength = 128
+	maxUserAgentLength  = 512
+)
+
+// GetSessions godoc
+// @Summary			Список сессий
+// @Description		Активные сессии текущего пользователя: устройство, user agent и IP входа, время последнего обновления токенов. Сессия, в которой выполнен запрос, отмечена полем current
+// @Security		Bearer
+// @Produce			json
+// @Success			200 {array} dto.Session
+// @Failure			401 {object} object{error=string} "Пользователь не авторизован"
+// @Failure			500 {object} object{error=string} "Неизвестная ошибка"
+// @Tags			Авторизация
+// @Router /user/sessions [get]
+func (t Transport) GetSessions(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	sessions, err := t.useCase.GetSessions(ctx)
+	if err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	transport.Response(w, sessions)
+}
+
+// DeleteSession godoc
+// @Summary			Завершить сессию
+// @Description		Завершение сессии текущего пользователя на другом устройстве: её refresh-токены удаляются
+// @Security		Bearer
+// @Produce			json
+// @Param			id path int true "Идентификатор сессии"
+// @Success			200 {object} object{id=int}
+// @Failure			400 {object} object{error=string} "Некорректный идентификатор"
+// @Failure			401 {object} object{error=string} "Пользователь не авторизован"
+// @Failure			404 {object} object{error=string} "Сессия не найдена"
+// @Failure			500 {object} object{error=string} "Неизвестная ошибка"
+// @Tags			Авторизация
+// @Router /user/sessions/{id} [delete]
+func (t Transport) DeleteSession(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+
+	id, err := transport.StringToInt(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		transport.Error(w, http.StatusBadRequest, "invalid session id")
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := t.useCase.DeleteSession(ctx, id); err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	transport.Response(w, map[string]any{"id": id})
+}
+
+// SignOut godoc
+// @Summary			Выход
+// @Description		Завершение текущей сессии. Access-токен остаётся действительным до истечения срока, а в строгом режиме — не дольше revocation_ttl секунд
+// @Security		Bearer
+// @Produce			json
+// @Success			200 {object} object{status=string}
+// @Failure			401 {object} object{error=string} "Пользователь не авторизован"
+// @Failure			500 {object} object{error=string} "Неизвестная ошибка"
+// @Tags			Авторизация
+// @Router /user/sign-out [post]
+func (t Transport) SignOut(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := t.useCase.SignOut(ctx); err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	transport.Response(w, map[string]any{"status": "ok"})
+}
+
+// SignOutAll godoc
+// @Summary			Выход на всех устройствах
+// @Description		Завершение всех сессий текущего пользователя, включая текущую
+// @Security		Bearer
+// @Produce			json
+// @Success			200 {object} object{status=string}
+// @Failure			401 {object} object{error=string} "Пользователь не авторизован"
+// @Failure			500 {object} object{error=string} "Неизвестная ошибка"
+// @Tags			Авторизация
+// @Router /user/sign-out-all [post]
+func (t Transport) SignOutAll(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := t.useCase.SignOutAll(ctx); err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	transport.Response(w, map[string]any{"status": "ok"})
+}
+
+// device описывает устройство, с которого выполнен запрос. IP берётся из
+// адреса соединения: заголовкам прокси без доверенного списка верить нельзя
+func device(
+	r *http.Request,
+	name string,
+) dto.Device {
+
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	return dto.Device{
+		Name:      truncate(name, maxDeviceNameLength),
+		UserAgent: truncate(r.UserAgent(), maxUserAgentLength),
+		IP:        ip,
+	}
+}
+
+func truncate(
+	value string,
+	length int,
+) string {
+
+	runes := []rune(value)
+	if len(runes) <= length {
+		return value
+	}
+
+	return string(runes[:length])
+}
@@ -0,0 +1,239 @@
+package auth
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/errors"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type SessionTestSuite struct {
+	suite.Suite
+
+	// Вспомогательные параметры
+	logger    log.Logger
+	transport Transport
+
+	// Входные параметры
+	sessions []dto.Session
+
+	// Служебные параметры
+	useCaseAuthMock        *MockuseCaseAuth
+	useCaseAccessTokenMock *MockuseCaseAccessToken
+}
+
+func TestSuiteSession(t *testing.T) {
+	suite.Run(t, &SessionTestSuite{})
+}
+
+func (s *SessionTestSuite) SetupTest() {
+	s.logger = log.NewNullLogger()
+}
+
+func (s *SessionTestSuite) BeforeTest(_, _ string) {
+	controller := gomock.NewController(s.T())
+
+	// Входные значения по-умолчанию
+	s.setupMock(controller).setupTransport().
+		setupSessions(1, "Ноутбук")
+}
+
+func (s *SessionTestSuite) setupMock(
+	controller *gomock.Controller,
+) *SessionTestSuite {
+
+	s.useCaseAuthMock = NewMockuseCaseAuth(controller)
+	s.useCaseAccessTokenMock = NewMockuseCaseAccessToken(controller)
+
+	return s
+}
+
+func (s *SessionTestSuite) setupTransport() *SessionTestSuite {
+	s.transport = New(s.useCaseAuthMock, s.useCaseAccessTokenMock, s.logger)
+
+	return s
+}
+
+func (s *SessionTestSuite) setupSessions(
+	id int,
+	name string,
+) {
+
+	s.sessions = []dto.Session{
+		{
+			ID: id,
+			Device: dto.Device{
+				Name:      name,
+				UserAgent: "curl/8.0",
+				IP:        "127.0.0.1",
+			},
+			LastUsedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Current:    true,
+		},
+	}
+}
+
+func (s *SessionTestSuite) TestGetSessionsSuccessful() {
+	const (
+		expectedResult = `[{"id":1,"device_name":"Ноутбук","user_agent":"curl/8.0","ip":"127.0.0.1",` +
+			`"last_used_at":"2024-01-01T00:00:00Z","current":true}]`
+	)
+
+	s.useCaseAuthMock.
+		EXPECT().
+		GetSessions(gomock.Any()).
+		Return(s.sessions, nil).
+		Times(1)
+
+	r := httptest.NewRecorder()
+	w, err := http.NewRequest(http.MethodGet, "/user/sessions", nil)
+	s.NoError(err)
+
+	s.transport.GetSessions(r, w)
+
+	s.Equal(http.StatusOK, r.Code)
+	s.Equal(expectedResult, strings.Trim(r.Body.String(), " \n"))
+}
+
+func (s *SessionTestSuite) TestDeleteSessionSuccessful() {
+	const (
+		expectedResult = `{"id":1}`
+	)
+
+	s.useCaseAuthMock.
+		EXPECT().
+		DeleteSession(gomock.Any(), 1).
+		Return(nil).
+		Times(1)
+
+	r := httptest.NewRecorder()
+	w, err := http.NewRequest(http.MethodDelete, "/user/sessions/1", nil)
+	s.NoError(err)
+
+	w = mux.SetURLVars(w, map[string]string{"id": "1"})
+
+	s.transport.DeleteSession(r, w)
+
+	s.Equal(http.StatusOK, r.Code)
+	s.Equal(expectedResult, strings.Trim(r.Body.String(), " \n"))
+}
+
+func (s *SessionTestSuite) TestDeleteSessionFailed() {
+	testCases := []struct {
+		testName       string
+		id             string
+		expectedCode   int
+		expectedResult string
+		setupMock      func()
+	}{
+		{
+			testName:       "Invalid id",
+			id:             "0",
+			expectedCode:   http.StatusBadRequest,
+			expectedResult: `{"error":"invalid session id"}`,
+			setupMock:      func() {},
+		},
+		{
+			testName:       "Not found",
+			id:             "2",
+			expectedCode:   http.StatusNotFound,
+			expectedResult: `{"error":"session not found"}`,
+			setupMock: func() {
+				s.useCaseAuthMock.
+					EXPECT().
+					DeleteSession(gomock.Any(), 2).
+					Return(errors.ErrNotFound.New("session not found")).
+					Times(1)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		s.Run(testCase.testName, func() {
+			testCase.setupMock()
+
+			r := httptest.NewRecorder()
+			w, err := http.NewRequest(http.MethodDelete, "/user/sessions/"+testCase.id, nil)
+			s.NoError(err)
+
+			w = mux.SetURLVars(w, map[string]string{"id": testCase.id})
+
+			s.transport.DeleteSession(r, w)
+
+			s.Equal(testCase.expectedCode, r.Code)
+			s.Equal(testCase.expectedResult, strings.Trim(r.Body.String(), " \n"))
+		})
+	}
+}
+
+func (s *SessionTestSuite) TestSignOutSuccessful() {
+	const (
+		expectedResult = `{"status":"ok"}`
+	)
+
+	s.useCaseAuthMock.
+		EXPECT().
+		SignOut(gomock.Any()).
+		Return(nil).
+		Times(1)
+
+	r := httptest.NewRecorder()
+	w, err := http.NewRequest(http.MethodPost, "/user/sign-out", nil)
+	s.NoError(err)
+
+	s.transport.SignOut(r, w)
+
+	s.Equal(http.StatusOK, r.Code)
+	s.Equal(expectedResult, strings.Trim(r.Body.String(), " \n"))
+}
+
+func (s *SessionTestSuite) TestSignOutAllSuccessful() {
+	const (
+		expectedResult = `{"status":"ok"}`
+	)
+
+	s.useCaseAuthMock.
+		EXPECT().
+		SignOutAll(gomock.Any()).
+		Return(nil).
+		Times(1)
+
+	r := httptest.NewRecorder()
+	w, err := http.NewRequest(http.MethodPost, "/user/sign-out-all", nil)
+	s.NoError(err)
+
+	s.transport.SignOutAll(r, w)
+
+	s.Equal(http.StatusOK, r.Code)
+	s.Equal(expectedResult, strings.Trim(r.Body.String(), " \n"))
+}
+
+func (s *SessionTestSuite) TestSignInDevice() {
+	const (
+		body = `{"username":"user","password":"password","device_name":"Ноутбук"}`
+	)
+
+	s.useCaseAuthMock.
+		EXPECT().
+		SignIn(gomock.Any(), dto.Credentials{Username: "user", Password: "password"}, s.sessions[0].Device).
+		Return(dto.TokenPair{}, nil).
+		Times(1)
+
+	r := httptest.NewRecorder()
+	w, err := http.NewRequest(http.MethodPost, "/user/sign-in", strings.NewReader(body))
+	s.NoError(err)
+
+	w.RemoteAddr = "127.0.0.1:54321"
+	w.Header.Set("User-Agent", "curl/8.0")
+
+	s.transport.SignIn(r, w)
+
+	s.Equal(http.StatusOK, r.Code)
+}
@@ -0,0 +1,164 @@
+package transport
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	CacheControlHeader    = "Cache-Control"
+	LastModifiedHeader    = "Last-Modified"
+	IfNoneMatchHeader     = "If-None-Match"
+	IfModifiedSinceHeader = "If-Modified-Since"
+
+	authorizationHeader = "Authorization"
+	privateCacheControl = "private, no-cache"
+)
+
+type HTTPCache struct {
+	cacheControl string
+}
+
+func NewHTTPCache(
+	cacheControl string,
+) HTTPCache {
+
+	return HTTPCache{
+		cacheControl: cacheControl,
+	}
+}
+
+// Response отвечает 304, если клиент уже получил это представление,
+// иначе выставляет ETag и Cache-Control и отдаёт данные. Last-Modified
+// не выставляется: для списков максимум updated_at не монотонен, он не
+// меняется при удалении записей и изменении состава списка
+func (c HTTPCache) Response(
+	w http.ResponseWriter,
+	r *http.Request,
+	data any,
+) {
+
+	body, err := json.Marshal(&data)
+	if err != nil {
+		Error(
+			w,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+		)
+
+		return
+	}
+
+	hash := sha256.Sum256(body)
+	etag := strconv.Quote(hex.EncodeToString(hash[:16]))
+
+	w.Header().Set(ETagHeader, etag)
+
+	// Ответы авторизованным пользователям могут отличаться от публичных,
+	// поэтому разделяемым кэшам их хранить нельзя
+	if r.Header.Get(authorizationHeader) != "" {
+		w.Header().Set(CacheControlHeader, privateCacheControl)
+	} else if c.cacheControl != "" {
+		w.Header().Set(CacheControlHeader, c.cacheControl)
+	}
+
+	if notModified(r, etag, time.Time{}) {
+		w.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	w.Write(append(body, '\n'))
+}
+
+func (c HTTPCache) PageResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	page Page,
+) {
+
+	w.Header().Set(totalCountHeader, strconv.Itoa(page.Total))
+
+	c.Response(w, r, page)
+}
+
+// ResourceResponse отдаёт отдельную запись с ETag по её версии и
+// Last-Modified по времени последнего изменения, отвечая 304, если
+// клиент уже получил это представление
+func ResourceResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	data any,
+	version int,
+	lastModified time.Time,
+) {
+
+	etag := ETag(version)
+
+	w.Header().Set(ETagHeader, etag)
+
+	if !lastModified.IsZero() {
+		w.Header().Set(LastModifiedHeader, lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(r, etag, lastModified) {
+		w.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	Response(w, data)
+}
+
+func LastModified(
+	times ...time.Time,
+) time.Time {
+
+	var latest time.Time
+
+	for _, t := range times {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+
+	return latest
+}
+
+func notModified(
+	r *http.Request,
+	etag string,
+	lastModified time.Time,
+) bool {
+
+	// If-Modified-Since учитывается только без If-None-Match (RFC 9110)
+	if value := r.Header.Get(IfNoneMatchHeader); value != "" {
+		for _, candidate := range strings.Split(value, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if lastModified.IsZero() {
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get(IfModifiedSinceHeader))
+	if err != nil {
+		return false
+	}
+
+	return !lastModified.Truncate(time.Second).After(since)
+}
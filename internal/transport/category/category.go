@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"github.com/gorilla/mux"
 	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/principal"
 	"github.com/jackvonhouse/product-catalog/internal/transport"
+	"github.com/jackvonhouse/product-catalog/internal/transport/cursor"
 	"github.com/jackvonhouse/product-catalog/internal/transport/middleware"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 	"net/http"
@@ -15,54 +17,93 @@ import (
 type useCaseCategory interface {
 	Create(context.Context, dto.CreateCategory) (int, error)
 
-	Get(context.Context, dto.GetCategory) ([]dto.Category, error)
+	Get(context.Context, dto.GetCategory) (dto.CategoryPage, error)
+	GetById(context.Context, int) (dto.Category, error)
+	GetChildren(context.Context, int) ([]dto.Category, error)
+	GetAncestors(context.Context, int) ([]dto.Category, error)
+	GetTree(context.Context) ([]dto.CategoryTree, error)
 
 	Update(context.Context, dto.UpdateCategory) (int, error)
 
-	Delete(context.Context, int) (int, error)
+	Delete(context.Context, int, int) (int, error)
+	Restore(context.Context, int) (int, error)
 }
 
 type useCaseAccessToken interface {
-	Verify(context.Context, string) error
+	Parse(context.Context, string) (dto.AccessToken, error)
 }
 
 type Transport struct {
 	useCase useCaseCategory
 
-	mw     middleware.Middleware
-	logger log.Logger
+	cursor      cursor.Cursor
+	cache       transport.HTTPCache
+	mw          middleware.Middleware
+	idempotency middleware.Idempotency
+	logger      log.Logger
 }
 
 func New(
 	category useCaseCategory,
 	accessToken useCaseAccessToken,
+	idempotency middleware.Idempotency,
+	cursor cursor.Cursor,
+	cache transport.HTTPCache,
 	logger log.Logger,
 ) Transport {
 
 	return Transport{
-		useCase: category,
-		mw:      middleware.New(accessToken, logger),
-		logger:  logger.WithField("unit", "category"),
+		useCase:     category,
+		cursor:      cursor,
+		cache:       cache,
+		mw:          middleware.New(accessToken, logger),
+		idempotency: idempotency,
+		logger:      logger.WithField("unit", "category"),
 	}
 }
 
 func (t Transport) Handle(
 	router *mux.Router,
 ) {
-	authorizedOnly := router.PathPrefix("").Subrouter()
-	authorizedOnly.Use(t.mw.AuthorizedOnly)
+	editorsOnly := router.PathPrefix("").Subrouter()
+	editorsOnly.Use(t.mw.RequireRole(dto.RoleAdmin, dto.RoleEditor))
 
-	authorizedOnly.HandleFunc("", t.Create).
+	adminsOnly := router.PathPrefix("").Subrouter()
+	adminsOnly.Use(t.mw.RequireRole(dto.RoleAdmin))
+
+	idempotent := editorsOnly.PathPrefix("").Subrouter()
+	idempotent.Use(t.idempotency.Idempotent)
+
+	idempotent.HandleFunc("", t.Create).
 		Methods(http.MethodPost)
 
+	adminsOnly.HandleFunc("", t.Get).
+		Methods(http.MethodGet).
+		Queries("include_deleted", "true")
+
 	router.HandleFunc("", t.Get).
 		Methods(http.MethodGet)
 
-	authorizedOnly.HandleFunc("/{id:[0-9]+}", t.Update).
+	router.HandleFunc("/tree", t.GetTree).
+		Methods(http.MethodGet)
+
+	router.HandleFunc("/{id:[0-9]+}", t.GetById).
+		Methods(http.MethodGet)
+
+	router.HandleFunc("/{id:[0-9]+}/children", t.GetChildren).
+		Methods(http.MethodGet)
+
+	router.HandleFunc("/{id:[0-9]+}/ancestors", t.GetAncestors).
+		Methods(http.MethodGet)
+
+	editorsOnly.HandleFunc("/{id:[0-9]+}", t.Update).
 		Methods(http.MethodPut)
 
-	authorizedOnly.HandleFunc("/{id:[0-9]+}", t.Delete).
+	editorsOnly.HandleFunc("/{id:[0-9]+}", t.Delete).
 		Methods(http.MethodDelete)
+
+	editorsOnly.HandleFunc("/{id:[0-9]+}/restore", t.Restore).
+		Methods(http.MethodPost)
 }
 
 // Create godoc
@@ -72,9 +113,14 @@ func (t Transport) Handle(
 // @Accept			json
 // @Produce			json
 // @Param			request body dto.CreateCategory true "Данные о категории"
+// @Param			Idempotency-Key header string false "Ключ идемпотентности: повторный запрос с тем же ключом возвращает сохранённый ответ"
 // @Success			200 {object} object{id=int}
+// @Failure			400 {object} object{error=string} "Некорректные данные категории"
 // @Failure			401 {object} object{error=string} "Пользователь не авторизован"
-// @Failure			409 {object} object{error=string} "Категория уже существует"
+// @Failure			403 {object} object{error=string} "Недостаточно прав"
+// @Failure			404 {object} object{error=string} "Родительская категория не найдена"
+// @Failure			409 {object} object{error=string} "Категория уже существует или запрос с тем же ключом идемпотентности ещё выполняется"
+// @Failure			422 {object} object{error=string} "Ключ идемпотентности использован для другого запроса"
 // @Failure			500 {object} object{error=string} "Неизвестная ошибка"
 // @Tags			Категория
 // @Router /category [post]
@@ -123,12 +169,22 @@ func (t Transport) Create(
 
 // Get godoc
 // @Summary			Получить категории
-// @Description		Получение категорий
+// @Description		Получение страницы категорий с общим количеством (также в заголовке X-Total-Count) и ссылками на соседние страницы. При передаче параметра cursor используется курсорная пагинация, next_cursor отсутствует на последней странице
 // @Accept			json
 // @Produce			json
 // @Param			limit path int false "Лимит"
 // @Param			offset path int false "Смещение"
-// @Success			200 {array} dto.Category
+// @Param			cursor query string false "Курсор следующей страницы. Пустое значение включает курсорную пагинацию с первой страницы"
+// @Param			include_deleted query bool false "Включить удалённые категории. Доступно только администраторам"
+// @Param			If-None-Match header string false "ETag ранее полученного ответа"
+// @Success			200 {object} transport.Page{items=[]dto.Category}
+// @Header			200 {int} X-Total-Count "Общее количество категорий"
+// @Header			200 {string} ETag "Хэш содержимого ответа"
+// @Header			200 {string} Cache-Control "Политика кэширования"
+// @Success			304 "Содержимое не изменилось"
+// @Failure			400 {object} object{error=string} "Некорректный курсор"
+// @Failure			401 {object} object{error=string} "Пользователь не авторизован (только с include_deleted)"
+// @Failure			403 {object} object{error=string} "Недостаточно прав (только с include_deleted)"
 // @Failure			404 {object} object{error=string} "Категории отсутствуют"
 // @Failure			500 {object} object{error=string} "Неизвестная ошибка"
 // @Tags			Категория
@@ -150,9 +206,21 @@ func (t Transport) Get(
 		offset = 0
 	}
 
+	afterId, cursorMode, err := t.cursor.FromQuery(queries)
+	if err != nil {
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
 	data := dto.GetCategory{
-		Limit:  limit,
-		Offset: offset,
+		Limit:   limit,
+		Offset:  offset,
+		AfterId: afterId,
+
+		IncludeDeleted: t.includeDeleted(r),
 	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
@@ -169,6 +237,184 @@ func (t Transport) Get(
 		return
 	}
 
+	if cursorMode {
+		t.cursorResponse(w, r, categories, limit)
+
+		return
+	}
+
+	t.cache.PageResponse(
+		w, r,
+		transport.OffsetPage(r, categories.Items, categories.Total, limit, offset),
+	)
+}
+
+// GetById godoc
+// @Summary			Получить категорию
+// @Description		Получение категории по идентификатору
+// @Accept			json
+// @Produce			json
+// @Param			id path int true "Идентификатор категории"
+// @Param			If-None-Match header string false "ETag ранее полученного ответа"
+// @Param			If-Modified-Since header string false "Значение Last-Modified ранее полученного ответа"
+// @Success			200 {object} dto.Category
+// @Header			200 {string} ETag "Версия категории"
+// @Header			200 {string} Last-Modified "Время последнего изменения категории"
+// @Success			304 "Категория не изменилась"
+// @Failure			400 {object} object{error=string} "Некорректный идентификатор категории"
+// @Failure			404 {object} object{error=string} "Категория не найдена"
+// @Failure			500 {object} object{error=string} "Неизвестная ошибка"
+// @Tags			Категория
+// @Router /category/{id} [get]
+func (t Transport) GetById(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+
+	vars := mux.Vars(r)
+
+	categoryId, err := transport.StringToInt(vars["id"])
+	if err != nil || categoryId <= 0 {
+		transport.Error(w, http.StatusBadRequest, "invalid category id")
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	category, err := t.useCase.GetById(ctx, categoryId)
+	if err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	transport.ResourceResponse(w, r, category, category.Version, category.UpdatedAt)
+}
+
+// GetTree godoc
+// @Summary			Получить дерево категорий
+// @Description		Получение всех категорий в виде дерева
+// @Accept			json
+// @Produce			json
+// @Param			If-None-Match header string false "ETag ранее полученного ответа"
+// @Success			200 {array} dto.CategoryTree
+// @Header			200 {string} ETag "Хэш содержимого ответа"
+// @Header			200 {string} Cache-Control "Политика кэширования"
+// @Success			304 "Содержимое не изменилось"
+// @Failure			500 {object} object{error=string} "Неизвестная ошибка"
+// @Tags			Категория
+// @Router /category/tree [get]
+func (t Transport) GetTree(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	tree, err := t.useCase.GetTree(ctx)
+	if err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	t.cache.Response(w, r, tree)
+}
+
+// GetChildren godoc
+// @Summary			Получить дочерние категории
+// @Description		Получение непосредственных дочерних категорий
+// @Accept			json
+// @Produce			json
+// @Param			id path int true "Идентификатор категории"
+// @Success			200 {array} dto.Category
+// @Failure			400 {object} object{error=string} "Некорректный идентификатор категории"
+// @Failure			404 {object} object{error=string} "Категория не найдена"
+// @Failure			500 {object} object{error=string} "Неизвестная ошибка"
+// @Tags			Категория
+// @Router /category/{id}/children [get]
+func (t Transport) GetChildren(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+
+	vars := mux.Vars(r)
+
+	categoryId, err := transport.StringToInt(vars["id"])
+	if err != nil || categoryId <= 0 {
+		transport.Error(w, http.StatusBadRequest, "invalid category id")
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	categories, err := t.useCase.GetChildren(ctx, categoryId)
+	if err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	transport.Response(w, categories)
+}
+
+// GetAncestors godoc
+// @Summary			Получить предков категории
+// @Description		Получение цепочки родительских категорий, начиная с корневой
+// @Accept			json
+// @Produce			json
+// @Param			id path int true "Идентификатор категории"
+// @Success			200 {array} dto.Category
+// @Failure			400 {object} object{error=string} "Некорректный идентификатор категории"
+// @Failure			404 {object} object{error=string} "Категория не найдена"
+// @Failure			500 {object} object{error=string} "Неизвестная ошибка"
+// @Tags			Категория
+// @Router /category/{id}/ancestors [get]
+func (t Transport) GetAncestors(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+
+	vars := mux.Vars(r)
+
+	categoryId, err := transport.StringToInt(vars["id"])
+	if err != nil || categoryId <= 0 {
+		transport.Error(w, http.StatusBadRequest, "invalid category id")
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	categories, err := t.useCase.GetAncestors(ctx, categoryId)
+	if err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
 	transport.Response(w, categories)
 }
 
@@ -180,10 +426,14 @@ func (t Transport) Get(
 // @Produce			json
 // @Param			request body dto.UpdateCategory true "Данные о категории"
 // @Param			id path int true "Идентификатор категории"
+// @Param			If-Match header string false "Ожидаемая версия категории из заголовка ETag"
 // @Success			200 {object} object{id=int}
+// @Failure			400 {object} object{error=string} "Категория не может быть потомком самой себя"
 // @Failure			401 {object} object{error=string} "Пользователь не авторизован"
+// @Failure			403 {object} object{error=string} "Недостаточно прав"
 // @Failure			404 {object} object{error=string} "Категория не найдена"
 // @Failure			409 {object} object{error=string} "Категория уже существует"
+// @Failure			412 {object} object{error=string} "Категория была изменена"
 // @Failure			500 {object} object{error=string} "Неизвестная ошибка"
 // @Tags			Категория
 // @Router /category/{id} [put]
@@ -214,6 +464,15 @@ func (t Transport) Update(
 
 	data.ID = categoryId
 
+	data.Version, err = transport.IfMatch(r)
+	if err != nil {
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
 	if data.Name == "" {
 		transport.Error(
 			w,
@@ -243,14 +502,19 @@ func (t Transport) Update(
 
 // Delete godoc
 // @Summary			Удалить категорию
-// @Description		Удаление категории
+// @Description		Удаление категории. Категорию с неудалёнными дочерними категориями удалить нельзя
 // @Security		Bearer
 // @Accept			json
 // @Produce			json
 // @Param			id path int true "Идентификатор категории"
+// @Param			If-Match header string false "Ожидаемая версия категории из заголовка ETag"
 // @Success			200 {object} object{id=int}
+// @Failure			400 {object} object{error=string} "Некорректный заголовок If-Match"
 // @Failure			401 {object} object{error=string} "Пользователь не авторизован"
+// @Failure			403 {object} object{error=string} "Недостаточно прав"
 // @Failure			404 {object} object{error=string} "Категория не найдена"
+// @Failure			409 {object} object{error=string} "У категории есть дочерние категории"
+// @Failure			412 {object} object{error=string} "Категория была изменена"
 // @Failure			500 {object} object{error=string} "Неизвестная ошибка"
 // @Tags			Категория
 // @Router /category/{id} [delete]
@@ -268,10 +532,19 @@ func (t Transport) Delete(
 		return
 	}
 
+	version, err := transport.IfMatch(r)
+	if err != nil {
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
-	id, err := t.useCase.Delete(ctx, categoryId)
+	id, err := t.useCase.Delete(ctx, categoryId, version)
 	if err != nil {
 		t.logger.Warn(err)
 
@@ -284,3 +557,87 @@ func (t Transport) Delete(
 
 	transport.Response(w, map[string]any{"id": id})
 }
+
+// Restore godoc
+// @Summary			Восстановить категорию
+// @Description		Восстановление удалённого категории вместе с его привязками к товарам
+// @Security		Bearer
+// @Accept			json
+// @Produce			json
+// @Param			id path int true "Идентификатор категории"
+// @Success			200 {object} object{id=int}
+// @Failure			400 {object} object{error=string} "Родительская категория удалена"
+// @Failure			401 {object} object{error=string} "Пользователь не авторизован"
+// @Failure			403 {object} object{error=string} "Недостаточно прав"
+// @Failure			404 {object} object{error=string} "Удалённая категория не найдена"
+// @Failure			409 {object} object{error=string} "Категория с таким названием уже существует"
+// @Failure			500 {object} object{error=string} "Неизвестная ошибка"
+// @Tags			Категория
+// @Router /category/{id}/restore [post]
+func (t Transport) Restore(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+
+	vars := mux.Vars(r)
+
+	categoryId, err := transport.StringToInt(vars["id"])
+	if err != nil || categoryId <= 0 {
+		transport.Error(w, http.StatusBadRequest, "invalid category id")
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	id, err := t.useCase.Restore(ctx, categoryId)
+	if err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	transport.Response(w, map[string]any{"id": id})
+}
+
+func (t Transport) includeDeleted(
+	r *http.Request,
+) bool {
+
+	return r.URL.Query().Get("include_deleted") == "true" &&
+		principal.HasRole(r.Context(), dto.RoleAdmin)
+}
+
+func (t Transport) cursorResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	page dto.CategoryPage,
+	limit int,
+) {
+
+	lastId := 0
+	if len(page.Items) > 0 {
+		lastId = page.Items[len(page.Items)-1].ID
+	}
+
+	nextCursor, err := t.cursor.Next(len(page.Items), limit, lastId)
+	if err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	t.cache.PageResponse(
+		w, r,
+		transport.CursorPage(r, page.Items, page.Total, limit, nextCursor),
+	)
+}
@@ -56,25 +56,25 @@ func (mr *MockuseCaseCategoryMockRecorder) Create(arg0, arg1 any) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockuseCaseCategory) Delete(arg0 context.Context, arg1 int) (int, error) {
+func (m *MockuseCaseCategory) Delete(arg0 context.Context, arg1, arg2 int) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockuseCaseCategoryMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+func (mr *MockuseCaseCategoryMockRecorder) Delete(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockuseCaseCategory)(nil).Delete), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockuseCaseCategory)(nil).Delete), arg0, arg1, arg2)
 }
 
 // Get mocks base method.
-func (m *MockuseCaseCategory) Get(arg0 context.Context, arg1 dto.GetCategory) ([]dto.Category, error) {
+func (m *MockuseCaseCategory) Get(arg0 context.Context, arg1 dto.GetCategory) (dto.CategoryPage, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Get", arg0, arg1)
-	ret0, _ := ret[0].([]dto.Category)
+	ret0, _ := ret[0].(dto.CategoryPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -85,6 +85,81 @@ func (mr *MockuseCaseCategoryMockRecorder) Get(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockuseCaseCategory)(nil).Get), arg0, arg1)
 }
 
+// GetAncestors mocks base method.
+func (m *MockuseCaseCategory) GetAncestors(arg0 context.Context, arg1 int) ([]dto.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAncestors", arg0, arg1)
+	ret0, _ := ret[0].([]dto.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAncestors indicates an expected call of GetAncestors.
+func (mr *MockuseCaseCategoryMockRecorder) GetAncestors(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAncestors", reflect.TypeOf((*MockuseCaseCategory)(nil).GetAncestors), arg0, arg1)
+}
+
+// GetById mocks base method.
+func (m *MockuseCaseCategory) GetById(arg0 context.Context, arg1 int) (dto.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", arg0, arg1)
+	ret0, _ := ret[0].(dto.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockuseCaseCategoryMockRecorder) GetById(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockuseCaseCategory)(nil).GetById), arg0, arg1)
+}
+
+// GetChildren mocks base method.
+func (m *MockuseCaseCategory) GetChildren(arg0 context.Context, arg1 int) ([]dto.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChildren", arg0, arg1)
+	ret0, _ := ret[0].([]dto.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChildren indicates an expected call of GetChildren.
+func (mr *MockuseCaseCategoryMockRecorder) GetChildren(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChildren", reflect.TypeOf((*MockuseCaseCategory)(nil).GetChildren), arg0, arg1)
+}
+
+// GetTree mocks base method.
+func (m *MockuseCaseCategory) GetTree(arg0 context.Context) ([]dto.CategoryTree, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTree", arg0)
+	ret0, _ := ret[0].([]dto.CategoryTree)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTree indicates an expected call of GetTree.
+func (mr *MockuseCaseCategoryMockRecorder) GetTree(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTree", reflect.TypeOf((*MockuseCaseCategory)(nil).GetTree), arg0)
+}
+
+// Restore mocks base method.
+func (m *MockuseCaseCategory) Restore(arg0 context.Context, arg1 int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockuseCaseCategoryMockRecorder) Restore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockuseCaseCategory)(nil).Restore), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockuseCaseCategory) Update(arg0 context.Context, arg1 dto.UpdateCategory) (int, error) {
 	m.ctrl.T.Helper()
@@ -123,16 +198,17 @@ func (m *MockuseCaseAccessToken) EXPECT() *MockuseCaseAccessTokenMockRecorder {
 	return m.recorder
 }
 
-// Verify mocks base method.
-func (m *MockuseCaseAccessToken) Verify(arg0 context.Context, arg1 string) error {
+// Parse mocks base method.
+func (m *MockuseCaseAccessToken) Parse(arg0 context.Context, arg1 string) (dto.AccessToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Verify", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "Parse", arg0, arg1)
+	ret0, _ := ret[0].(dto.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Verify indicates an expected call of Verify.
-func (mr *MockuseCaseAccessTokenMockRecorder) Verify(arg0, arg1 any) *gomock.Call {
+// Parse indicates an expected call of Parse.
+func (mr *MockuseCaseAccessTokenMockRecorder) Parse(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockuseCaseAccessToken)(nil).Verify), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockuseCaseAccessToken)(nil).Parse), arg0, arg1)
 }
@@ -0,0 +1,163 @@
+package cursor
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/jackvonhouse/product-catalog/config"
+	"github.com/jackvonhouse/product-catalog/internal/errors"
+	"net/url"
+	"strings"
+)
+
+type Position struct {
+	ID     int    `json:"id,omitempty"`
+	Sort   string `json:"sort,omitempty"`
+	Values []any  `json:"values,omitempty"`
+}
+
+type Cursor struct {
+	secretKey []byte
+}
+
+func New(
+	config config.Cursor,
+) Cursor {
+
+	return Cursor{
+		secretKey: []byte(config.SecretKey),
+	}
+}
+
+func (c Cursor) Encode(
+	position Position,
+) (string, error) {
+
+	payload, err := json.Marshal(position)
+	if err != nil {
+		return "", errors.
+			ErrInternal.
+			New("can't encode cursor").
+			Wrap(err)
+	}
+
+	encoding := base64.RawURLEncoding
+
+	return encoding.EncodeToString(payload) + "." +
+		encoding.EncodeToString(c.sign(payload)), nil
+}
+
+func (c Cursor) Decode(
+	token string,
+) (Position, error) {
+
+	encodedPayload, encodedSignature, found := strings.Cut(token, ".")
+	if !found {
+		return Position{}, errors.ErrInvalid.New("invalid cursor")
+	}
+
+	encoding := base64.RawURLEncoding
+
+	payload, err := encoding.DecodeString(encodedPayload)
+	if err != nil {
+		return Position{}, errors.ErrInvalid.New("invalid cursor")
+	}
+
+	signature, err := encoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, c.sign(payload)) {
+		return Position{}, errors.ErrInvalid.New("invalid cursor")
+	}
+
+	position := Position{}
+
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&position); err != nil {
+		return Position{}, errors.ErrInvalid.New("invalid cursor")
+	}
+
+	if position.ID <= 0 && len(position.Values) == 0 {
+		return Position{}, errors.ErrInvalid.New("invalid cursor")
+	}
+
+	return position, nil
+}
+
+func (c Cursor) sign(
+	payload []byte,
+) []byte {
+
+	mac := hmac.New(sha256.New, c.secretKey)
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
+
+func (c Cursor) FromQuery(
+	queries url.Values,
+) (int, bool, error) {
+
+	if !queries.Has("cursor") {
+		return 0, false, nil
+	}
+
+	token := queries.Get("cursor")
+	if token == "" {
+		return 0, true, nil
+	}
+
+	position, err := c.Decode(token)
+	if err != nil {
+		return 0, true, err
+	}
+
+	return position.ID, true, nil
+}
+
+func (c Cursor) ValuesFromQuery(
+	queries url.Values,
+	sort string,
+) ([]any, bool, error) {
+
+	if !queries.Has("cursor") {
+		return nil, false, nil
+	}
+
+	token := queries.Get("cursor")
+	if token == "" {
+		return nil, true, nil
+	}
+
+	position, err := c.Decode(token)
+	if err != nil {
+		return nil, true, err
+	}
+
+	if position.Sort != sort || len(position.Values) == 0 {
+		return nil, true, errors.ErrInvalid.New("cursor doesn't match sort")
+	}
+
+	return position.Values, true, nil
+}
+
+func (c Cursor) Next(
+	count, limit, lastId int,
+) (string, error) {
+
+	return c.NextPosition(count, limit, Position{ID: lastId})
+}
+
+func (c Cursor) NextPosition(
+	count, limit int,
+	position Position,
+) (string, error) {
+
+	if count < limit || count == 0 {
+		return "", nil
+	}
+
+	return c.Encode(position)
+}
@@ -0,0 +1,39 @@
+package transport
+
+import (
+	"github.com/jackvonhouse/product-catalog/internal/errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	ETagHeader    = "ETag"
+	IfMatchHeader = "If-Match"
+)
+
+func ETag(
+	version int,
+) string {
+
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+func IfMatch(
+	r *http.Request,
+) (int, error) {
+
+	value := strings.TrimSpace(r.Header.Get(IfMatchHeader))
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+
+	value = strings.Trim(strings.TrimPrefix(value, "W/"), `"`)
+
+	version, err := strconv.Atoi(value)
+	if err != nil || version <= 0 {
+		return 0, errors.ErrInvalid.New("invalid If-Match header")
+	}
+
+	return version, nil
+}
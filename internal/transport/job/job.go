@@ -0,0 +1,212 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/transport"
+	"github.com/jackvonhouse/product-catalog/internal/transport/middleware"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type useCaseJob interface {
+	GetById(context.Context, int) (dto.Job, error)
+	GetFile(context.Context, int) (dto.JobFile, error)
+	Cancel(context.Context, int) (dto.Job, error)
+}
+
+type useCaseAccessToken interface {
+	Parse(context.Context, string) (dto.AccessToken, error)
+}
+
+type Transport struct {
+	useCase useCaseJob
+
+	mw     middleware.Middleware
+	logger log.Logger
+}
+
+func New(
+	job useCaseJob,
+	accessToken useCaseAccessToken,
+	logger log.Logger,
+) Transport {
+
+	return Transport{
+		useCase: job,
+		mw:      middleware.New(accessToken, logger),
+		logger:  logger.WithField("unit", "job"),
+	}
+}
+
+func (t Transport) Handle(
+	router *mux.Router,
+) {
+
+	editorsOnly := router.PathPrefix("").Subrouter()
+	editorsOnly.Use(t.mw.RequireRole(dto.RoleAdmin, dto.RoleEditor))
+
+	editorsOnly.HandleFunc("/{id:[0-9]+}", t.GetById).
+		Methods(http.MethodGet)
+
+	editorsOnly.HandleFunc("/{id:[0-9]+}", t.Cancel).
+		Methods(http.MethodDelete)
+
+	editorsOnly.HandleFunc("/{id:[0-9]+}/file", t.GetFile).
+		Methods(http.MethodGet)
+}
+
+// GetById godoc
+// @Summary			Получить задачу
+// @Description		Получение состояния фоновой задачи: статус (pending, running, done, failed, cancelled), число обработанных строк и результат. Задача доступна своему автору и администраторам
+// @Security		Bearer
+// @Accept			json
+// @Produce			json
+// @Param			id path int true "Идентификатор задачи"
+// @Success			200 {object} dto.Job
+// @Failure			400 {object} object{error=string} "Некорректный идентификатор задачи"
+// @Failure			401 {object} object{error=string} "Пользователь не авторизован"
+// @Failure			403 {object} object{error=string} "Недостаточно прав"
+// @Failure			404 {object} object{error=string} "Задача не найдена"
+// @Failure			500 {object} object{error=string} "Неизвестная ошибка"
+// @Tags			Задача
+// @Router /jobs/{id} [get]
+func (t Transport) GetById(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+
+	id, ok := t.jobId(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	job, err := t.useCase.GetById(ctx, id)
+	if err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	transport.Response(w, job)
+}
+
+// Cancel godoc
+// @Summary			Отменить задачу
+// @Description		Задача из очереди отменяется сразу, выполняющаяся — при следующей отметке обработчика. Изменения прерванного импорта не сохраняются
+// @Security		Bearer
+// @Accept			json
+// @Produce			json
+// @Param			id path int true "Идентификатор задачи"
+// @Success			200 {object} dto.Job
+// @Failure			400 {object} object{error=string} "Некорректный идентификатор задачи"
+// @Failure			401 {object} object{error=string} "Пользователь не авторизован"
+// @Failure			403 {object} object{error=string} "Недостаточно прав"
+// @Failure			404 {object} object{error=string} "Задача не найдена"
+// @Failure			409 {object} object{error=string} "Задача уже завершена"
+// @Failure			500 {object} object{error=string} "Неизвестная ошибка"
+// @Tags			Задача
+// @Router /jobs/{id} [delete]
+func (t Transport) Cancel(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+
+	id, ok := t.jobId(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	job, err := t.useCase.Cancel(ctx, id)
+	if err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	transport.Response(w, job)
+}
+
+// GetFile godoc
+// @Summary			Скачать файл задачи
+// @Description		Скачивание файла, подготовленного завершённой задачей выгрузки
+// @Security		Bearer
+// @Produce			text/csv
+// @Produce			application/x-ndjson
+// @Param			id path int true "Идентификатор задачи"
+// @Success			200 {file} file
+// @Header			200 {string} Content-Disposition "Имя файла"
+// @Failure			400 {object} object{error=string} "Некорректный идентификатор задачи"
+// @Failure			401 {object} object{error=string} "Пользователь не авторизован"
+// @Failure			403 {object} object{error=string} "Недостаточно прав"
+// @Failure			404 {object} object{error=string} "Задача или файл не найдены"
+// @Failure			500 {object} object{error=string} "Неизвестная ошибка"
+// @Tags			Задача
+// @Router /jobs/{id}/file [get]
+func (t Transport) GetFile(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+
+	id, ok := t.jobId(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	file, err := t.useCase.GetFile(ctx, id)
+	if err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	header := w.Header()
+
+	header.Set("Content-Type", file.ContentType)
+	header.Set("Content-Length", strconv.Itoa(len(file.Data)))
+	header.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, file.Name))
+
+	if _, err := w.Write(file.Data); err != nil {
+		t.logger.Warn(err)
+	}
+}
+
+func (t Transport) jobId(
+	w http.ResponseWriter,
+	r *http.Request,
+) (int, bool) {
+
+	id, err := transport.StringToInt(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		transport.Error(w, http.StatusBadRequest, "invalid job id")
+
+		return 0, false
+	}
+
+	return id, true
+}
@@ -0,0 +1,253 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/principal"
+	"github.com/jackvonhouse/product-catalog/internal/transport"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	idempotencyKeyHeader      = "Idempotency-Key"
+	idempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	maxIdempotentRequestSize  = 1 << 20
+	idempotencyStorageTimeout = 5 * time.Second
+)
+
+type useCaseIdempotency interface {
+	Begin(context.Context, dto.IdempotencyKey) (dto.IdempotencyKey, bool, error)
+	Complete(context.Context, dto.IdempotencyKey) error
+	Release(context.Context, dto.IdempotencyKey) error
+}
+
+// Idempotency повторяет сохранённый ответ на запрос с уже встречавшимся
+// заголовком Idempotency-Key вместо повторного выполнения запроса.
+// Ключи разделяются по пользователям, поэтому middleware подключается
+// после проверки авторизации
+type Idempotency struct {
+	idempotency useCaseIdempotency
+	logger      log.Logger
+}
+
+func NewIdempotency(
+	idempotency useCaseIdempotency,
+	logger log.Logger,
+) Idempotency {
+
+	return Idempotency{
+		idempotency: idempotency,
+		logger:      logger.WithField("unit", "idempotency"),
+	}
+}
+
+func (i Idempotency) Idempotent(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keyValue := r.Header.Get(idempotencyKeyHeader)
+		if keyValue == "" || r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		if len(keyValue) > maxIdempotencyKeyLength {
+			transport.Error(w, http.StatusBadRequest, "invalid idempotency key")
+
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentRequestSize))
+		if err != nil {
+			i.logger.Warn(err)
+
+			transport.Error(w, http.StatusRequestEntityTooLarge, "request body is too large")
+
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx, cancel := context.WithTimeout(r.Context(), idempotencyStorageTimeout)
+		defer cancel()
+
+		key, created, err := i.idempotency.Begin(ctx, dto.IdempotencyKey{
+			Key:         keyValue,
+			UserId:      principal.UserId(r.Context()),
+			Fingerprint: i.fingerprint(r, body),
+		})
+
+		if err != nil {
+			i.logger.Warn(err)
+
+			code, msg := transport.ErrorToHttpResponse(err)
+
+			transport.Error(w, code, msg)
+
+			return
+		}
+
+		if !created {
+			i.replay(w, r, key, body)
+
+			return
+		}
+
+		i.execute(w, r, key, next)
+	})
+}
+
+func (i Idempotency) replay(
+	w http.ResponseWriter,
+	r *http.Request,
+	key dto.IdempotencyKey,
+	body []byte,
+) {
+
+	logger := i.logger.WithFields(map[string]any{
+		"key":     key.Key,
+		"user_id": key.UserId,
+	})
+
+	if key.Fingerprint != i.fingerprint(r, body) {
+		logger.Warn("idempotency key reused with different request")
+
+		transport.Error(
+			w,
+			http.StatusUnprocessableEntity,
+			"idempotency key is already used for another request",
+		)
+
+		return
+	}
+
+	if key.StatusCode == 0 {
+		logger.Warn("request with idempotency key is still in progress")
+
+		transport.Error(
+			w,
+			http.StatusConflict,
+			"request with this idempotency key is in progress",
+		)
+
+		return
+	}
+
+	logger.Info("replaying stored response")
+
+	header := w.Header()
+
+	if key.ContentType != "" {
+		header.Set("Content-Type", key.ContentType)
+	}
+
+	header.Set(idempotentReplayedHeader, "true")
+
+	w.WriteHeader(key.StatusCode)
+
+	if _, err := w.Write(key.Body); err != nil {
+		logger.Warn(err)
+	}
+}
+
+// execute выполняет запрос и сохраняет ответ. Ответ с ошибкой сервера
+// не сохраняется, а ключ освобождается, чтобы запрос можно было повторить
+func (i Idempotency) execute(
+	w http.ResponseWriter,
+	r *http.Request,
+	key dto.IdempotencyKey,
+	next http.Handler,
+) {
+
+	recorder := &responseRecorder{ResponseWriter: w}
+	completed := false
+
+	ctx := context.WithoutCancel(r.Context())
+
+	defer func() {
+		if completed {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, idempotencyStorageTimeout)
+		defer cancel()
+
+		if err := i.idempotency.Release(ctx, key); err != nil {
+			i.logger.Warn(err)
+		}
+	}()
+
+	next.ServeHTTP(recorder, r)
+
+	if recorder.StatusCode() >= http.StatusInternalServerError {
+		return
+	}
+
+	key.StatusCode = recorder.StatusCode()
+	key.ContentType = w.Header().Get("Content-Type")
+	key.Body = recorder.body.Bytes()
+
+	ctx, cancel := context.WithTimeout(ctx, idempotencyStorageTimeout)
+	defer cancel()
+
+	if err := i.idempotency.Complete(ctx, key); err != nil {
+		i.logger.Warn(err)
+
+		return
+	}
+
+	completed = true
+}
+
+// fingerprint отличает запросы с одним ключом по адресу и телу
+func (i Idempotency) fingerprint(
+	r *http.Request,
+	body []byte,
+) string {
+
+	hash := sha256.New()
+
+	hash.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+type responseRecorder struct {
+	http.ResponseWriter
+
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	if r.statusCode == 0 {
+		r.statusCode = statusCode
+	}
+
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	if r.statusCode == 0 {
+		r.statusCode = http.StatusOK
+	}
+
+	r.body.Write(data)
+
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) StatusCode() int {
+	if r.statusCode == 0 {
+		return http.StatusOK
+	}
+
+	return r.statusCode
+}
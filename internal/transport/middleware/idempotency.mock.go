@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/transport/middleware/idempotency.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/transport/middleware/idempotency.go -destination=internal/transport/middleware/idempotency.mock.go -package=middleware
+//
+
+// Package middleware is a generated GoMock package.
+package middleware
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/jackvonhouse/product-catalog/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockuseCaseIdempotency is a mock of useCaseIdempotency interface.
+type MockuseCaseIdempotency struct {
+	ctrl     *gomock.Controller
+	recorder *MockuseCaseIdempotencyMockRecorder
+}
+
+// MockuseCaseIdempotencyMockRecorder is the mock recorder for MockuseCaseIdempotency.
+type MockuseCaseIdempotencyMockRecorder struct {
+	mock *MockuseCaseIdempotency
+}
+
+// NewMockuseCaseIdempotency creates a new mock instance.
+func NewMockuseCaseIdempotency(ctrl *gomock.Controller) *MockuseCaseIdempotency {
+	mock := &MockuseCaseIdempotency{ctrl: ctrl}
+	mock.recorder = &MockuseCaseIdempotencyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuseCaseIdempotency) EXPECT() *MockuseCaseIdempotencyMockRecorder {
+	return m.recorder
+}
+
+// Begin mocks base method.
+func (m *MockuseCaseIdempotency) Begin(arg0 context.Context, arg1 dto.IdempotencyKey) (dto.IdempotencyKey, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Begin", arg0, arg1)
+	ret0, _ := ret[0].(dto.IdempotencyKey)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Begin indicates an expected call of Begin.
+func (mr *MockuseCaseIdempotencyMockRecorder) Begin(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockuseCaseIdempotency)(nil).Begin), arg0, arg1)
+}
+
+// Complete mocks base method.
+func (m *MockuseCaseIdempotency) Complete(arg0 context.Context, arg1 dto.IdempotencyKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockuseCaseIdempotencyMockRecorder) Complete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockuseCaseIdempotency)(nil).Complete), arg0, arg1)
+}
+
+// Release mocks base method.
+func (m *MockuseCaseIdempotency) Release(arg0 context.Context, arg1 dto.IdempotencyKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockuseCaseIdempotencyMockRecorder) Release(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockuseCaseIdempotency)(nil).Release), arg0, arg1)
+}
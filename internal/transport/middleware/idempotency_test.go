@@ -0,0 +1,261 @@
+package middleware
+
+import (
+	"bytes"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/principal"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type IdempotencyTestSuite struct {
+	suite.Suite
+
+	// Вспомогательные параметры
+	logger      log.Logger
+	idempotency Idempotency
+
+	// Входные параметры
+	key  dto.IdempotencyKey
+	body string
+
+	// Служебные параметры
+	useCaseIdempotencyMock *MockuseCaseIdempotency
+}
+
+func TestSuiteIdempotency(t *testing.T) {
+	suite.Run(t, &IdempotencyTestSuite{})
+}
+
+func (s *IdempotencyTestSuite) SetupTest() {
+	s.logger = log.NewNullLogger()
+}
+
+func (s *IdempotencyTestSuite) BeforeTest(_, _ string) {
+	controller := gomock.NewController(s.T())
+
+	// Входные значения по-умолчанию
+	s.setupMock(controller).setupIdempotency().
+		setupKey("key", 1, `{"name":"Продукт"}`)
+}
+
+func (s *IdempotencyTestSuite) setupMock(
+	controller *gomock.Controller,
+) *IdempotencyTestSuite {
+
+	s.useCaseIdempotencyMock = NewMockuseCaseIdempotency(controller)
+
+	return s
+}
+
+func (s *IdempotencyTestSuite) setupIdempotency() *IdempotencyTestSuite {
+	s.idempotency = NewIdempotency(s.useCaseIdempotencyMock, s.logger)
+
+	return s
+}
+
+func (s *IdempotencyTestSuite) setupKey(
+	key string,
+	userId int,
+	body string,
+) {
+
+	s.body = body
+	s.key = dto.IdempotencyKey{
+		Key:         key,
+		UserId:      userId,
+		Fingerprint: s.idempotency.fingerprint(s.request(body), []byte(body)),
+	}
+}
+
+func (s *IdempotencyTestSuite) request(
+	body string,
+) *http.Request {
+
+	w, err := http.NewRequest(http.MethodPost, "/api/v1/product", bytes.NewBufferString(body))
+	s.NoError(err)
+
+	return w.WithContext(principal.WithContext(w.Context(), principal.Principal{UserId: 1}))
+}
+
+func (s *IdempotencyTestSuite) serve(
+	next http.Handler,
+	key string,
+	body string,
+) *httptest.ResponseRecorder {
+
+	r := httptest.NewRecorder()
+	w := s.request(body)
+
+	if key != "" {
+		w.Header.Set(idempotencyKeyHeader, key)
+	}
+
+	s.idempotency.Idempotent(next).ServeHTTP(r, w)
+
+	return r
+}
+
+func (s *IdempotencyTestSuite) TestWithoutKeySuccessful() {
+	called := false
+
+	next := http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		called = true
+	})
+
+	r := s.serve(next, "", s.body)
+
+	s.Equal(http.StatusOK, r.Code)
+	s.True(called)
+}
+
+func (s *IdempotencyTestSuite) TestFirstRequestSuccessful() {
+	const (
+		expectedResult = `{"id":1}`
+	)
+
+	s.useCaseIdempotencyMock.
+		EXPECT().
+		Begin(gomock.Any(), s.key).
+		Return(s.key, true, nil).
+		Times(1)
+
+	completed := s.key
+	completed.StatusCode = http.StatusOK
+	completed.ContentType = "application/json"
+	completed.Body = []byte(expectedResult)
+
+	s.useCaseIdempotencyMock.
+		EXPECT().
+		Complete(gomock.Any(), completed).
+		Return(nil).
+		Times(1)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := new(bytes.Buffer)
+
+		_, err := body.ReadFrom(r.Body)
+		s.NoError(err)
+		s.Equal(s.body, body.String())
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(expectedResult))
+	})
+
+	r := s.serve(next, s.key.Key, s.body)
+
+	s.Equal(http.StatusOK, r.Code)
+	s.Equal(expectedResult, r.Body.String())
+	s.Empty(r.Header().Get(idempotentReplayedHeader))
+}
+
+func (s *IdempotencyTestSuite) TestReplaySuccessful() {
+	const (
+		expectedResult = `{"id":1}`
+	)
+
+	stored := s.key
+	stored.StatusCode = http.StatusOK
+	stored.ContentType = "application/json"
+	stored.Body = []byte(expectedResult)
+
+	s.useCaseIdempotencyMock.
+		EXPECT().
+		Begin(gomock.Any(), s.key).
+		Return(stored, false, nil).
+		Times(1)
+
+	next := http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		s.Fail("next handler must not be called")
+	})
+
+	r := s.serve(next, s.key.Key, s.body)
+
+	s.Equal(http.StatusOK, r.Code)
+	s.Equal(expectedResult, r.Body.String())
+	s.Equal("application/json", r.Header().Get("Content-Type"))
+	s.Equal("true", r.Header().Get(idempotentReplayedHeader))
+}
+
+func (s *IdempotencyTestSuite) TestServerErrorReleased() {
+	s.useCaseIdempotencyMock.
+		EXPECT().
+		Begin(gomock.Any(), s.key).
+		Return(s.key, true, nil).
+		Times(1)
+
+	s.useCaseIdempotencyMock.
+		EXPECT().
+		Release(gomock.Any(), s.key).
+		Return(nil).
+		Times(1)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	r := s.serve(next, s.key.Key, s.body)
+
+	s.Equal(http.StatusInternalServerError, r.Code)
+}
+
+func (s *IdempotencyTestSuite) TestFailed() {
+	next := http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		s.Fail("next handler must not be called")
+	})
+
+	testCases := []struct {
+		testName       string
+		key            string
+		body           string
+		stored         dto.IdempotencyKey
+		expectedCode   int
+		expectedResult string
+	}{
+		{
+			testName:       "Too long key",
+			key:            strings.Repeat("k", maxIdempotencyKeyLength+1),
+			body:           s.body,
+			expectedCode:   http.StatusBadRequest,
+			expectedResult: `{"error":"invalid idempotency key"}`,
+		},
+		{
+			testName:       "Different body",
+			key:            s.key.Key,
+			body:           `{"name":"Другой продукт"}`,
+			stored:         dto.IdempotencyKey{Key: s.key.Key, UserId: 1, Fingerprint: s.key.Fingerprint, StatusCode: http.StatusOK},
+			expectedCode:   http.StatusUnprocessableEntity,
+			expectedResult: `{"error":"idempotency key is already used for another request"}`,
+		},
+		{
+			testName:       "In progress",
+			key:            s.key.Key,
+			body:           s.body,
+			stored:         s.key,
+			expectedCode:   http.StatusConflict,
+			expectedResult: `{"error":"request with this idempotency key is in progress"}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		s.Run(testCase.testName, func() {
+			if testCase.stored.Key != "" {
+				s.useCaseIdempotencyMock.
+					EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Return(testCase.stored, false, nil).
+					Times(1)
+			}
+
+			r := s.serve(next, testCase.key, testCase.body)
+
+			s.Equal(testCase.expectedCode, r.Code)
+			s.Equal(testCase.expectedResult, strings.Trim(r.Body.String(), " \n"))
+		})
+	}
+}
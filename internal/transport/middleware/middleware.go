@@ -2,18 +2,23 @@ package middleware
 
 import (
 	"context"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/principal"
+	"github.com/jackvonhouse/product-catalog/internal/requestid"
 	"github.com/jackvonhouse/product-catalog/internal/transport"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 	"net/http"
+	"slices"
 	"strings"
 )
 
 const (
 	authorizationHeader = "Authorization"
+	requestIdHeader     = "X-Request-Id"
 )
 
 type useCaseAccessToken interface {
-	Verify(context.Context, string) error
+	Parse(context.Context, string) (dto.AccessToken, error)
 }
 
 type Middleware struct {
@@ -32,36 +37,113 @@ func New(
 	}
 }
 
-func (m Middleware) AuthorizedOnly(next http.Handler) http.Handler {
+func RequestId(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		authHeader := r.Header.Get(authorizationHeader)
+		requestId := r.Header.Get(requestIdHeader)
+		if requestId == "" || len(requestId) > 128 {
+			requestId = requestid.New()
+		}
+
+		w.Header().Set(requestIdHeader, requestId)
 
-		if authHeader == "" {
-			transport.Error(w,
-				http.StatusUnauthorized,
-				http.StatusText(http.StatusUnauthorized),
-			)
+		next.ServeHTTP(w, r.WithContext(requestid.WithContext(r.Context(), requestId)))
+	})
+}
 
+func (m Middleware) AuthorizedOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, ok := m.authenticate(w, r)
+		if !ok {
 			return
 		}
 
-		accessToken := strings.Trim(
-			strings.Replace(authHeader, "Bearer", "", 1),
-			" ",
-		)
+		next.ServeHTTP(w, r.WithContext(principal.WithContext(r.Context(), p)))
+	})
+}
 
-		if err := m.accessToken.Verify(r.Context(), accessToken); err != nil {
-			m.logger.
-				WithField("token", accessToken).
-				Warnf("access token verification failed: %s", err)
+func (m Middleware) RequireRole(
+	roles ...string,
+) func(http.Handler) http.Handler {
 
-			code, msg := transport.ErrorToHttpResponse(err)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			p, ok := m.authenticate(w, r)
+			if !ok {
+				return
+			}
 
-			transport.Error(w, code, msg)
+			if !slices.Contains(roles, p.Role) {
+				m.logger.
+					WithFields(map[string]any{
+						"username": p.Username,
+						"role":     p.Role,
+					}).
+					Warnf("access denied, required roles: %s", strings.Join(roles, ", "))
 
-			return
-		}
+				transport.Error(w,
+					http.StatusForbidden,
+					http.StatusText(http.StatusForbidden),
+				)
 
-		next.ServeHTTP(w, r)
-	})
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(principal.WithContext(r.Context(), p)))
+		})
+	}
+}
+
+func (m Middleware) authenticate(
+	w http.ResponseWriter,
+	r *http.Request,
+) (principal.Principal, bool) {
+
+	accessToken, ok := m.bearerToken(w, r)
+	if !ok {
+		return principal.Principal{}, false
+	}
+
+	token, err := m.accessToken.Parse(r.Context(), accessToken)
+	if err != nil {
+		m.logger.
+			WithField("token", accessToken).
+			Warnf("access token verification failed: %s", err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return principal.Principal{}, false
+	}
+
+	return principal.Principal{
+		UserId:         token.UserId,
+		Username:       token.Username,
+		RefreshTokenId: token.RefreshTokenId,
+		Role:           token.Role,
+	}, true
+}
+
+func (m Middleware) bearerToken(
+	w http.ResponseWriter,
+	r *http.Request,
+) (string, bool) {
+
+	authHeader := r.Header.Get(authorizationHeader)
+
+	if authHeader == "" {
+		transport.Error(w,
+			http.StatusUnauthorized,
+			http.StatusText(http.StatusUnauthorized),
+		)
+
+		return "", false
+	}
+
+	accessToken := strings.Trim(
+		strings.Replace(authHeader, "Bearer", "", 1),
+		" ",
+	)
+
+	return accessToken, true
 }
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/transport/middleware/middleware.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/transport/middleware/middleware.go -destination=internal/transport/middleware/middleware.mock.go -package=middleware
+//
+
+// Package middleware is a generated GoMock package.
+package middleware
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/jackvonhouse/product-catalog/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockuseCaseAccessToken is a mock of useCaseAccessToken interface.
+type MockuseCaseAccessToken struct {
+	ctrl     *gomock.Controller
+	recorder *MockuseCaseAccessTokenMockRecorder
+}
+
+// MockuseCaseAccessTokenMockRecorder is the mock recorder for MockuseCaseAccessToken.
+type MockuseCaseAccessTokenMockRecorder struct {
+	mock *MockuseCaseAccessToken
+}
+
+// NewMockuseCaseAccessToken creates a new mock instance.
+func NewMockuseCaseAccessToken(ctrl *gomock.Controller) *MockuseCaseAccessToken {
+	mock := &MockuseCaseAccessToken{ctrl: ctrl}
+	mock.recorder = &MockuseCaseAccessTokenMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuseCaseAccessToken) EXPECT() *MockuseCaseAccessTokenMockRecorder {
+	return m.recorder
+}
+
+// Parse mocks base method.
+func (m *MockuseCaseAccessToken) Parse(arg0 context.Context, arg1 string) (dto.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Parse", arg0, arg1)
+	ret0, _ := ret[0].(dto.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Parse indicates an expected call of Parse.
+func (mr *MockuseCaseAccessTokenMockRecorder) Parse(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockuseCaseAccessToken)(nil).Parse), arg0, arg1)
+}
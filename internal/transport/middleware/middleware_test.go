@@ -0,0 +1,220 @@
+package middleware
+
+import (
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/errors"
+	"github.com/jackvonhouse/product-catalog/internal/principal"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type MiddlewareTestSuite struct {
+	suite.Suite
+
+	// Вспомогательные параметры
+	logger     log.Logger
+	middleware Middleware
+
+	// Входные параметры
+	token       string
+	accessToken dto.AccessToken
+
+	// Служебные параметры
+	useCaseAccessTokenMock *MockuseCaseAccessToken
+}
+
+func TestSuiteMiddleware(t *testing.T) {
+	suite.Run(t, &MiddlewareTestSuite{})
+}
+
+func (s *MiddlewareTestSuite) SetupTest() {
+	s.logger = log.NewNullLogger()
+}
+
+func (s *MiddlewareTestSuite) BeforeTest(_, _ string) {
+	controller := gomock.NewController(s.T())
+
+	// Входные значения по-умолчанию
+	s.setupMock(controller).setupMiddleware().
+		setupAccessToken("token", 1, "user", dto.RoleEditor)
+}
+
+func (s *MiddlewareTestSuite) setupMock(
+	controller *gomock.Controller,
+) *MiddlewareTestSuite {
+
+	s.useCaseAccessTokenMock = NewMockuseCaseAccessToken(controller)
+
+	return s
+}
+
+func (s *MiddlewareTestSuite) setupMiddleware() *MiddlewareTestSuite {
+	s.middleware = New(s.useCaseAccessTokenMock, s.logger)
+
+	return s
+}
+
+func (s *MiddlewareTestSuite) setupAccessToken(
+	token string,
+	userId int,
+	username string,
+	role string,
+) *MiddlewareTestSuite {
+
+	s.token = token
+	s.accessToken = dto.AccessToken{
+		UserId:         userId,
+		Username:       username,
+		RefreshTokenId: 1,
+		Role:           role,
+	}
+
+	return s
+}
+
+func (s *MiddlewareTestSuite) serve(
+	handler http.Handler,
+	authHeader string,
+) (int, string) {
+
+	r := httptest.NewRecorder()
+	w, err := http.NewRequest(http.MethodGet, "", nil)
+	s.NoError(err)
+
+	if authHeader != "" {
+		w.Header.Set(authorizationHeader, authHeader)
+	}
+
+	handler.ServeHTTP(r, w)
+
+	bodyResult := r.Result()
+	defer bodyResult.Body.Close()
+
+	byteResult, err := io.ReadAll(bodyResult.Body)
+	s.NoError(err)
+
+	return bodyResult.StatusCode, strings.Trim(string(byteResult), " \n")
+}
+
+func (s *MiddlewareTestSuite) TestAuthorizedOnlySuccessful() {
+	s.useCaseAccessTokenMock.
+		EXPECT().
+		Parse(gomock.Any(), s.token).
+		Return(s.accessToken, nil).
+		Times(1)
+
+	var actual principal.Principal
+
+	next := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		p, ok := principal.FromContext(r.Context())
+		s.True(ok)
+
+		actual = p
+	})
+
+	code, _ := s.serve(s.middleware.AuthorizedOnly(next), "Bearer "+s.token)
+
+	s.Equal(http.StatusOK, code)
+	s.Equal(
+		principal.Principal{
+			UserId:         s.accessToken.UserId,
+			Username:       s.accessToken.Username,
+			RefreshTokenId: s.accessToken.RefreshTokenId,
+			Role:           s.accessToken.Role,
+		},
+		actual,
+	)
+}
+
+func (s *MiddlewareTestSuite) TestAuthorizedOnlyFailed() {
+	s.useCaseAccessTokenMock.
+		EXPECT().
+		Parse(gomock.Any(), s.token).
+		Return(dto.AccessToken{}, errors.ErrInvalid.New("invalid token")).
+		Times(1)
+
+	next := http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		s.Fail("next handler must not be called")
+	})
+
+	testCases := []struct {
+		testName       string
+		authHeader     string
+		expectedCode   int
+		expectedResult string
+	}{
+		{
+			testName:       "Without header",
+			authHeader:     "",
+			expectedCode:   http.StatusUnauthorized,
+			expectedResult: `{"error":"Unauthorized"}`,
+		},
+		{
+			testName:       "Invalid token",
+			authHeader:     "Bearer " + s.token,
+			expectedCode:   http.StatusBadRequest,
+			expectedResult: `{"error":"invalid token"}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		s.Run(testCase.testName, func() {
+			code, result := s.serve(s.middleware.AuthorizedOnly(next), testCase.authHeader)
+
+			s.Equal(testCase.expectedCode, code)
+			s.Equal(testCase.expectedResult, result)
+		})
+	}
+}
+
+func (s *MiddlewareTestSuite) TestRequireRoleSuccessful() {
+	s.useCaseAccessTokenMock.
+		EXPECT().
+		Parse(gomock.Any(), s.token).
+		Return(s.accessToken, nil).
+		Times(1)
+
+	called := false
+
+	next := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		called = principal.HasRole(r.Context(), dto.RoleEditor)
+	})
+
+	handler := s.middleware.RequireRole(dto.RoleAdmin, dto.RoleEditor)(next)
+
+	code, _ := s.serve(handler, "Bearer "+s.token)
+
+	s.Equal(http.StatusOK, code)
+	s.True(called)
+}
+
+func (s *MiddlewareTestSuite) TestRequireRoleFailed() {
+	const (
+		expectedResult = `{"error":"Forbidden"}`
+	)
+
+	s.accessToken.Role = dto.RoleViewer
+
+	s.useCaseAccessTokenMock.
+		EXPECT().
+		Parse(gomock.Any(), s.token).
+		Return(s.accessToken, nil).
+		Times(1)
+
+	next := http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		s.Fail("next handler must not be called")
+	})
+
+	handler := s.middleware.RequireRole(dto.RoleAdmin, dto.RoleEditor)(next)
+
+	code, result := s.serve(handler, "Bearer "+s.token)
+
+	s.Equal(http.StatusForbidden, code)
+	s.Equal(expectedResult, result)
+}
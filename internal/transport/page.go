@@ -0,0 +1,99 @@
+package transport
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	totalCountHeader = "X-Total-Count"
+)
+
+type Page struct {
+	Items      any     `json:"items"`
+	Total      int     `json:"total"`
+	Limit      int     `json:"limit"`
+	Offset     int     `json:"offset"`
+	Next       *string `json:"next"`
+	Prev       *string `json:"prev"`
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+func OffsetPage(
+	r *http.Request,
+	items any,
+	total, limit, offset int,
+) Page {
+
+	page := Page{
+		Items:  items,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if offset+limit < total {
+		next := pageLink(r, limit, "offset", strconv.Itoa(offset+limit))
+		page.Next = &next
+	}
+
+	if offset > 0 {
+		prev := pageLink(r, limit, "offset", strconv.Itoa(max(offset-limit, 0)))
+		page.Prev = &prev
+	}
+
+	return page
+}
+
+func CursorPage(
+	r *http.Request,
+	items any,
+	total, limit int,
+	nextCursor string,
+) Page {
+
+	page := Page{
+		Items: items,
+		Total: total,
+		Limit: limit,
+	}
+
+	if nextCursor != "" {
+		next := pageLink(r, limit, "cursor", nextCursor)
+		page.Next = &next
+		page.NextCursor = &nextCursor
+	}
+
+	return page
+}
+
+func PageResponse(
+	w http.ResponseWriter,
+	page Page,
+) {
+
+	w.Header().Set(totalCountHeader, strconv.Itoa(page.Total))
+
+	Response(w, page)
+}
+
+func pageLink(
+	r *http.Request,
+	limit int,
+	key, value string,
+) string {
+
+	queries := r.URL.Query()
+	queries.Set("limit", strconv.Itoa(limit))
+	queries.Del("offset")
+	queries.Del("cursor")
+	queries.Set(key, value)
+
+	link := url.URL{
+		Path:     r.URL.Path,
+		RawQuery: queries.Encode(),
+	}
+
+	return link.String()
+}
@@ -0,0 +1,164 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/transport"
+	"github.com/jackvonhouse/product-catalog/internal/transport/validator"
+	"net/http"
+	"time"
+)
+
+const (
+	maxBatchOperations = 100
+)
+
+// Batch godoc
+// @Summary			Выполнить пакет операций над товарами
+// @Description		Создание, изменение, удаление товаров и их привязка к категориям одним запросом. Операции выполняются по порядку: create принимает данные в поле create, update — идентификатор, версию и данные в поле update, delete — идентификатор и версию, attach и detach — идентификатор товара и category_id. По умолчанию все операции выполняются в одной транзакции и отменяются при первой ошибке: упавшая операция получает статус failed, предыдущие — rolled_back, последующие — skipped. С параметром atomic=false каждая операция фиксируется отдельно. В пакете не больше 100 операций
+// @Security		Bearer
+// @Accept			json
+// @Produce			json
+// @Param			request body object{operations=[]dto.BatchOperation} true "Операции"
+// @Param			atomic query bool false "Отменить все операции при ошибке одной из них, по умолчанию true"
+// @Success			200 {object} dto.BatchResult
+// @Failure			400 {object} object{error=string} "Некорректная операция"
+// @Failure			401 {object} object{error=string} "Пользователь не авторизован"
+// @Failure			403 {object} object{error=string} "Недостаточно прав"
+// @Failure			500 {object} object{error=string} "Неизвестная ошибка"
+// @Tags			Товар
+// @Router /product/batch [post]
+func (t Transport) Batch(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+
+	data := dto.BatchProducts{}
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		transport.Error(
+			w,
+			http.StatusInternalServerError,
+			"invalid json structure",
+		)
+
+		return
+	}
+
+	if len(data.Operations) == 0 {
+		transport.Error(w, http.StatusBadRequest, "operations can't be empty")
+
+		return
+	}
+
+	if len(data.Operations) > maxBatchOperations {
+		transport.Error(
+			w,
+			http.StatusBadRequest,
+			fmt.Sprintf("too many operations (max %d)", maxBatchOperations),
+		)
+
+		return
+	}
+
+	for i := range data.Operations {
+		if err := validateBatchOperation(&data.Operations[i]); err != nil {
+			transport.Error(
+				w,
+				http.StatusBadRequest,
+				fmt.Sprintf("operation %d: %s", i, err),
+			)
+
+			return
+		}
+	}
+
+	data.Atomic = r.URL.Query().Get("atomic") != "false"
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	result, err := t.product.Batch(ctx, data)
+	if err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	transport.Response(w, result)
+}
+
+// validateBatchOperation повторяет проверки отдельных обработчиков
+// для операции соответствующего типа
+func validateBatchOperation(
+	operation *dto.BatchOperation,
+) error {
+
+	switch operation.Type {
+
+	case dto.BatchCreate:
+		data := operation.Create
+		if data == nil {
+			return errors.New("create can't be empty")
+		}
+
+		if data.Name == "" {
+			return errors.New("name can't be empty")
+		}
+
+		if len(data.CategoryIds) == 0 {
+			return errors.New("category ids can't be empty")
+		}
+
+		if data.Currency == "" {
+			data.Currency = defaultCurrency
+		}
+
+		return validator.IsValidProduct(data.SKU, data.Currency, data.Price, data.Stock)
+
+	case dto.BatchUpdate:
+		if operation.ID <= 0 {
+			return errors.New("invalid product id")
+		}
+
+		data := operation.Update
+		if data == nil {
+			return errors.New("update can't be empty")
+		}
+
+		if data.Name == "" {
+			return errors.New("name can't be empty")
+		}
+
+		if data.Currency == "" {
+			data.Currency = defaultCurrency
+		}
+
+		return validator.IsValidProduct(data.SKU, data.Currency, data.Price, data.Stock)
+
+	case dto.BatchDelete:
+		if operation.ID <= 0 {
+			return errors.New("invalid product id")
+		}
+
+	case dto.BatchAttach, dto.BatchDetach:
+		if operation.ID <= 0 {
+			return errors.New("invalid product id")
+		}
+
+		if operation.CategoryId <= 0 {
+			return errors.New("invalid category id")
+		}
+
+	default:
+		return fmt.Errorf("unknown operation type %q", operation.Type)
+	}
+
+	return nil
+}
@@ -0,0 +1,219 @@
+package product
+
+import (
+	"bytes"
+	"github.com/jackvonhouse/product-catalog/config"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/errors"
+	"github.com/jackvonhouse/product-catalog/internal/transport"
+	"github.com/jackvonhouse/product-catalog/internal/transport/cursor"
+	"github.com/jackvonhouse/product-catalog/internal/transport/middleware"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type BatchTestSuite struct {
+	suite.Suite
+
+	// Вспомогательные параметры
+	logger    log.Logger
+	cursor    cursor.Cursor
+	cache     transport.HTTPCache
+	transport Transport
+
+	// Входные параметры
+	operations []dto.BatchOperation
+
+	// Служебные параметры
+	useCaseProductMock     *MockproductUseCase
+	useCaseAccessTokenMock *MockuseCaseAccessToken
+}
+
+func TestSuiteBatch(t *testing.T) {
+	suite.Run(t, &BatchTestSuite{})
+}
+
+func (s *BatchTestSuite) SetupTest() {
+	s.logger = log.NewNullLogger()
+	s.cursor = cursor.New(config.Cursor{SecretKey: "secret"})
+	s.cache = transport.NewHTTPCache("public, max-age=60")
+}
+
+func (s *BatchTestSuite) BeforeTest(_, _ string) {
+	controller := gomock.NewController(s.T())
+
+	// Входные значения по-умолчанию
+	s.setupMock(controller).setupTransport().
+		setupOperations("Продукт")
+}
+
+func (s *BatchTestSuite) setupMock(
+	controller *gomock.Controller,
+) *BatchTestSuite {
+
+	s.useCaseProductMock = NewMockproductUseCase(controller)
+	s.useCaseAccessTokenMock = NewMockuseCaseAccessToken(controller)
+
+	return s
+}
+
+func (s *BatchTestSuite) setupTransport() *BatchTestSuite {
+	s.transport = New(s.useCaseProductMock, s.useCaseAccessTokenMock, middleware.Idempotency{}, s.cursor, s.cache, s.logger)
+
+	return s
+}
+
+func (s *BatchTestSuite) setupOperations(
+	name string,
+) {
+
+	s.operations = []dto.BatchOperation{
+		{
+			Type: dto.BatchUpdate,
+			ID:   1,
+			Update: &dto.UpdateProduct{
+				Name:          name,
+				Currency:      "RUB",
+				SKU:           "SKU-1",
+				OldCategoryId: 1,
+				NewCategoryId: 2,
+			},
+		},
+		{Type: dto.BatchDelete, ID: 2, Version: 3},
+	}
+}
+
+func (s *BatchTestSuite) do(
+	target string,
+	body string,
+) string {
+
+	r := httptest.NewRecorder()
+	w, err := http.NewRequest(http.MethodPost, target, bytes.NewBufferString(body))
+	s.NoError(err)
+
+	s.transport.Batch(r, w)
+
+	return strings.Trim(r.Body.String(), " \n")
+}
+
+func (s *BatchTestSuite) TestBatchSuccessful() {
+	const (
+		expectedBody = `{"operations":[` +
+			`{"type":"update","id":1,"update":{"name":"Продукт","sku":"SKU-1","old_category_id":1,"new_category_id":2}},` +
+			`{"type":"delete","id":2,"version":3}` +
+			`]}`
+		expectedResult = `{"atomic":true,"committed":true,"succeeded":2,"failed":0,"results":[` +
+			`{"index":0,"type":"update","status":"done","id":1},` +
+			`{"index":1,"type":"delete","status":"done","id":2}` +
+			`]}`
+	)
+
+	s.useCaseProductMock.
+		EXPECT().
+		Batch(gomock.Any(), dto.BatchProducts{Operations: s.operations, Atomic: true}).
+		Return(dto.BatchResult{
+			Atomic:    true,
+			Committed: true,
+			Succeeded: 2,
+			Results: []dto.BatchOperationResult{
+				{Index: 0, Type: dto.BatchUpdate, Status: dto.BatchDone, ID: 1},
+				{Index: 1, Type: dto.BatchDelete, Status: dto.BatchDone, ID: 2},
+			},
+		}, nil).
+		Times(1)
+
+	s.Equal(expectedResult, s.do("/product/batch", expectedBody))
+}
+
+func (s *BatchTestSuite) TestBatchNonAtomicSuccessful() {
+	const (
+		expectedBody   = `{"operations":[{"type":"delete","id":2,"version":3}]}`
+		expectedResult = `{"atomic":false,"committed":false,"succeeded":0,"failed":1,"results":[` +
+			`{"index":0,"type":"delete","status":"failed","error":"product not found"}` +
+			`]}`
+	)
+
+	s.useCaseProductMock.
+		EXPECT().
+		Batch(gomock.Any(), dto.BatchProducts{Operations: s.operations[1:]}).
+		Return(dto.BatchResult{
+			Failed: 1,
+			Results: []dto.BatchOperationResult{
+				{Index: 0, Type: dto.BatchDelete, Status: dto.BatchFailed, Error: "product not found"},
+			},
+		}, nil).
+		Times(1)
+
+	s.Equal(expectedResult, s.do("/product/batch?atomic=false", expectedBody))
+}
+
+func (s *BatchTestSuite) TestBatchFailed() {
+	testCases := []struct {
+		testName       string
+		body           string
+		expectedResult string
+	}{
+		{
+			testName:       "Invalid json",
+			body:           `{wrong json}`,
+			expectedResult: `{"error":"invalid json structure"}`,
+		},
+		{
+			testName:       "Empty operations",
+			body:           `{"operations":[]}`,
+			expectedResult: `{"error":"operations can't be empty"}`,
+		},
+		{
+			testName:       "Too many operations",
+			body:           `{"operations":[` + strings.Repeat(`{"type":"delete","id":1},`, maxBatchOperations) + `{"type":"delete","id":1}]}`,
+			expectedResult: `{"error":"too many operations (max 100)"}`,
+		},
+		{
+			testName:       "Unknown type",
+			body:           `{"operations":[{"type":"rename","id":1}]}`,
+			expectedResult: `{"error":"operation 0: unknown operation type \"rename\""}`,
+		},
+		{
+			testName:       "Create without categories",
+			body:           `{"operations":[{"type":"delete","id":1},{"type":"create","create":{"name":"Продукт","sku":"SKU-1"}}]}`,
+			expectedResult: `{"error":"operation 1: category ids can't be empty"}`,
+		},
+		{
+			testName:       "Update without id",
+			body:           `{"operations":[{"type":"update","update":{"name":"Продукт"}}]}`,
+			expectedResult: `{"error":"operation 0: invalid product id"}`,
+		},
+		{
+			testName:       "Attach without category",
+			body:           `{"operations":[{"type":"attach","id":1}]}`,
+			expectedResult: `{"error":"operation 0: invalid category id"}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		s.Run(testCase.testName, func() {
+			s.Equal(testCase.expectedResult, s.do("/product/batch", testCase.body))
+		})
+	}
+}
+
+func (s *BatchTestSuite) TestBatchUseCaseFailed() {
+	const (
+		expectedBody   = `{"operations":[{"type":"delete","id":2,"version":3}]}`
+		expectedResult = `{"error":"Internal Server Error"}`
+	)
+
+	s.useCaseProductMock.
+		EXPECT().
+		Batch(gomock.Any(), gomock.Any()).
+		Return(dto.BatchResult{}, errors.ErrInternal.New("unknown error on starting transaction")).
+		Times(1)
+
+	s.Equal(expectedResult, s.do("/product/batch", expectedBody))
+}
@@ -3,7 +3,11 @@ package product
 import (
 	"bytes"
 	"fmt"
+	"github.com/jackvonhouse/product-catalog/config"
 	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/transport"
+	"github.com/jackvonhouse/product-catalog/internal/transport/cursor"
+	"github.com/jackvonhouse/product-catalog/internal/transport/middleware"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
@@ -19,6 +23,8 @@ type CreateTestSuite struct {
 
 	// Вспомогательные параметры
 	logger    log.Logger
+	cursor    cursor.Cursor
+	cache     transport.HTTPCache
 	transport Transport
 
 	// Входные параметры
@@ -35,6 +41,8 @@ func TestSuiteCreate(t *testing.T) {
 
 func (s *CreateTestSuite) SetupTest() {
 	s.logger = log.NewNullLogger()
+	s.cursor = cursor.New(config.Cursor{SecretKey: "secret"})
+	s.cache = transport.NewHTTPCache("public, max-age=60")
 }
 
 func (s *CreateTestSuite) BeforeTest(_, _ string) {
@@ -56,7 +64,7 @@ func (s *CreateTestSuite) setupMock(
 }
 
 func (s *CreateTestSuite) setupTransport() *CreateTestSuite {
-	s.transport = New(s.useCaseProductMock, s.useCaseAccessTokenMock, s.logger)
+	s.transport = New(s.useCaseProductMock, s.useCaseAccessTokenMock, middleware.Idempotency{}, s.cursor, s.cache, s.logger)
 
 	return s
 }
@@ -66,8 +74,10 @@ func (s *CreateTestSuite) setupCreateProduct(
 ) *CreateTestSuite {
 
 	s.create = dto.CreateProduct{
-		Name:       name,
-		CategoryId: 1,
+		Name:        name,
+		Currency:    "RUB",
+		SKU:         "SKU-1",
+		CategoryIds: []int{1},
 	}
 
 	return s
@@ -75,7 +85,7 @@ func (s *CreateTestSuite) setupCreateProduct(
 
 func (s *CreateTestSuite) TestCreateSuccessful() {
 	const (
-		expectedBody   = `{"name": "Продукт","category_id":1}`
+		expectedBody   = `{"name": "Продукт","sku":"SKU-1","category_ids":[1]}`
 		expectedResult = `{"id":1}`
 	)
 
@@ -160,6 +170,34 @@ func (s *CreateTestSuite) TestCreateEmptyNameFailed() {
 	s.Equal(expectedResult, strings.Trim(result, " \n"))
 }
 
+func (s *CreateTestSuite) TestCreateEmptyCategoriesFailed() {
+	const (
+		expectedBody   = `{"name":"Продукт","sku":"SKU-1","category_ids":[]}`
+		expectedResult = `{"error":"category ids can't be empty"}`
+	)
+
+	r := httptest.NewRecorder()
+	w, err := http.NewRequest(
+		http.MethodPost,
+		"",
+		bytes.NewBufferString(expectedBody),
+	)
+	s.NoError(err)
+
+	s.transport.Create(r, w)
+
+	bodyResult := r.Result()
+	defer bodyResult.Body.Close()
+
+	byteResult, err := io.ReadAll(bodyResult.Body)
+	s.NoError(err)
+
+	result := string(byteResult)
+
+	s.Equal(http.StatusBadRequest, bodyResult.StatusCode)
+	s.Equal(expectedResult, strings.Trim(result, " \n"))
+}
+
 func (s *CreateTestSuite) TestCreateFailed() {
 	const (
 		expectedInternalErrorMsg                       = "unknown error on creating product"
@@ -178,31 +216,31 @@ func (s *CreateTestSuite) TestCreateFailed() {
 		{
 			testName:         "Internal error",
 			expectedErrorMsg: expectedInternalErrorMsg,
-			expectedBody:     `{"name":"Продукт","category_id":1}`,
+			expectedBody:     `{"name":"Продукт","sku":"SKU-1","category_ids":[1]}`,
 			expectedResult:   fmt.Sprintf(`{"error":"%s"}`, expectedInternalErrorMsg),
 		},
 		{
 			testName:         "Product already exists",
 			expectedErrorMsg: expectedProductAlreadyExistsErrorMsg,
-			expectedBody:     `{"name":"Продукт","category_id":1}`,
+			expectedBody:     `{"name":"Продукт","sku":"SKU-1","category_ids":[1]}`,
 			expectedResult:   fmt.Sprintf(`{"error":"%s"}`, expectedProductAlreadyExistsErrorMsg),
 		},
 		{
 			testName:         "Product in category already exists",
 			expectedErrorMsg: expectedProductInCategoryAlreadyExistsErrorMsg,
-			expectedBody:     `{"name":"Продукт","category_id":1}`,
+			expectedBody:     `{"name":"Продукт","sku":"SKU-1","category_ids":[1]}`,
 			expectedResult:   fmt.Sprintf(`{"error":"%s"}`, expectedProductInCategoryAlreadyExistsErrorMsg),
 		},
 		{
 			testName:         "Product not found",
 			expectedErrorMsg: expectedProductNotFoundErrorMsg,
-			expectedBody:     `{"name":"Продукт","category_id":1}`,
+			expectedBody:     `{"name":"Продукт","sku":"SKU-1","category_ids":[1]}`,
 			expectedResult:   fmt.Sprintf(`{"error":"%s"}`, expectedProductNotFoundErrorMsg),
 		},
 		{
 			testName:         "Category not found",
 			expectedErrorMsg: expectedCategoryNotFoundErrorMsg,
-			expectedBody:     `{"name":"Продукт","category_id":1}`,
+			expectedBody:     `{"name":"Продукт","sku":"SKU-1","category_ids":[1]}`,
 			expectedResult:   fmt.Sprintf(`{"error":"%s"}`, expectedCategoryNotFoundErrorMsg),
 		},
 	}
@@ -237,3 +275,62 @@ func (s *CreateTestSuite) TestCreateFailed() {
 		})
 	}
 }
+
+func (s *CreateTestSuite) TestCreateInvalidProductFailed() {
+	testCases := []struct {
+		testName       string
+		expectedBody   string
+		expectedResult string
+	}{
+		{
+			testName:       "Empty sku",
+			expectedBody:   `{"name":"Продукт","category_ids":[1]}`,
+			expectedResult: `{"error":"sku length must be between 3 and 32 (actual 0)"}`,
+		},
+		{
+			testName:       "Invalid sku",
+			expectedBody:   `{"name":"Продукт","sku":"sku_1","category_ids":[1]}`,
+			expectedResult: `{"error":"sku must contains only uppercase latin chars, numbers and hyphens"}`,
+		},
+		{
+			testName:       "Invalid currency",
+			expectedBody:   `{"name":"Продукт","sku":"SKU-1","currency":"rub","category_ids":[1]}`,
+			expectedResult: `{"error":"currency must contains only uppercase latin chars"}`,
+		},
+		{
+			testName:       "Negative price",
+			expectedBody:   `{"name":"Продукт","sku":"SKU-1","price":-1,"category_ids":[1]}`,
+			expectedResult: `{"error":"price can't be negative"}`,
+		},
+		{
+			testName:       "Negative stock",
+			expectedBody:   `{"name":"Продукт","sku":"SKU-1","stock":-1,"category_ids":[1]}`,
+			expectedResult: `{"error":"stock can't be negative"}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		s.T().Run(testCase.testName, func(t *testing.T) {
+			r := httptest.NewRecorder()
+			w, err := http.NewRequest(
+				http.MethodPost,
+				"",
+				bytes.NewBufferString(testCase.expectedBody),
+			)
+			s.NoError(err)
+
+			s.transport.Create(r, w)
+
+			bodyResult := r.Result()
+			defer bodyResult.Body.Close()
+
+			byteResult, err := io.ReadAll(bodyResult.Body)
+			s.NoError(err)
+
+			result := string(byteResult)
+
+			s.Equal(http.StatusBadRequest, bodyResult.StatusCode)
+			s.Equal(testCase.expectedResult, strings.Trim(result, " \n"))
+		})
+	}
+}
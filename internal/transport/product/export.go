@@ -0,0 +1,92 @@
+package product
+
+import (
+	"fmt"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/export"
+	"net/http"
+	"time"
+)
+
+const (
+	exportFlushSize = 100
+)
+
+// exportWriter откладывает запись заголовков до первого товара, чтобы
+// ошибка до начала выгрузки вернулась клиенту обычным ответом
+type exportWriter struct {
+	w        http.ResponseWriter
+	format   string
+	filename string
+
+	writer  *export.Writer
+	written int
+}
+
+func newExportWriter(
+	w http.ResponseWriter,
+	format string,
+	now time.Time,
+) (*exportWriter, error) {
+
+	if _, err := export.NewWriter(nil, format); err != nil {
+		return nil, err
+	}
+
+	return &exportWriter{
+		w:        w,
+		format:   format,
+		filename: export.FileName(format, now),
+	}, nil
+}
+
+func (e *exportWriter) Started() bool { return e.writer != nil }
+
+func (e *exportWriter) start() {
+	header := e.w.Header()
+
+	header.Set("Content-Type", export.ContentType(e.format))
+	header.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, e.filename))
+
+	e.writer, _ = export.NewWriter(e.w, e.format)
+}
+
+func (e *exportWriter) Write(
+	product dto.ExportProduct,
+) error {
+
+	if !e.Started() {
+		e.start()
+	}
+
+	if err := e.writer.Write(product); err != nil {
+		return err
+	}
+
+	if e.written++; e.written%exportFlushSize == 0 {
+		return e.flush()
+	}
+
+	return nil
+}
+
+// Close дописывает буфер; пустая выгрузка состоит только из заголовков
+func (e *exportWriter) Close() error {
+	if !e.Started() {
+		e.start()
+	}
+
+	return e.flush()
+}
+
+func (e *exportWriter) flush() error {
+	if err := e.writer.Flush(); err != nil {
+		return err
+	}
+
+	if flusher, ok := e.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+
+	return nil
+}
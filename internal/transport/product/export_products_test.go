@@ -0,0 +1,234 @@
+package product
+
+import (
+	"github.com/jackvonhouse/product-catalog/config"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/errors"
+	"github.com/jackvonhouse/product-catalog/internal/transport"
+	"github.com/jackvonhouse/product-catalog/internal/transport/cursor"
+	"github.com/jackvonhouse/product-catalog/internal/transport/middleware"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type ExportTestSuite struct {
+	suite.Suite
+
+	// Вспомогательные параметры
+	logger    log.Logger
+	cursor    cursor.Cursor
+	cache     transport.HTTPCache
+	transport Transport
+
+	// Входные параметры
+	products []dto.ExportProduct
+
+	// Служебные параметры
+	useCaseProductMock     *MockproductUseCase
+	useCaseAccessTokenMock *MockuseCaseAccessToken
+}
+
+func TestSuiteExport(t *testing.T) {
+	suite.Run(t, &ExportTestSuite{})
+}
+
+func (s *ExportTestSuite) SetupTest() {
+	s.logger = log.NewNullLogger()
+	s.cursor = cursor.New(config.Cursor{SecretKey: "secret"})
+	s.cache = transport.NewHTTPCache("public, max-age=60")
+}
+
+func (s *ExportTestSuite) BeforeTest(_, _ string) {
+	controller := gomock.NewController(s.T())
+
+	// Входные значения по-умолчанию
+	s.setupMock(controller).setupTransport().
+		setupProducts()
+}
+
+func (s *ExportTestSuite) setupMock(
+	controller *gomock.Controller,
+) *ExportTestSuite {
+
+	s.useCaseProductMock = NewMockproductUseCase(controller)
+	s.useCaseAccessTokenMock = NewMockuseCaseAccessToken(controller)
+
+	return s
+}
+
+func (s *ExportTestSuite) setupTransport() *ExportTestSuite {
+	s.transport = New(s.useCaseProductMock, s.useCaseAccessTokenMock, middleware.Idempotency{}, s.cursor, s.cache, s.logger)
+
+	return s
+}
+
+func (s *ExportTestSuite) setupProducts() {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	s.products = []dto.ExportProduct{
+		{
+			Product: dto.Product{
+				ID:        1,
+				Name:      "Продукт, новый",
+				Price:     10000,
+				Currency:  "RUB",
+				SKU:       "SKU-1",
+				Stock:     10,
+				CreatedAt: createdAt,
+				UpdatedAt: createdAt,
+				Version:   1,
+			},
+			Categories: []string{"Категория А", "Категория Б"},
+		},
+	}
+}
+
+func (s *ExportTestSuite) expectExport(
+	data dto.GetProduct,
+	err error,
+) {
+
+	s.useCaseProductMock.
+		EXPECT().
+		Export(gomock.Any(), data, gomock.Any()).
+		DoAndReturn(func(_ any, _ dto.GetProduct, write func(dto.ExportProduct) error) error {
+			if err != nil {
+				return err
+			}
+
+			for _, product := range s.products {
+				if err := write(product); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}).
+		Times(1)
+}
+
+func (s *ExportTestSuite) do(
+	target string,
+) (*http.Response, string) {
+
+	r := httptest.NewRecorder()
+	w, err := http.NewRequest(
+		http.MethodGet,
+		target,
+		nil,
+	)
+	s.NoError(err)
+
+	s.transport.Export(r, w)
+
+	bodyResult := r.Result()
+	defer bodyResult.Body.Close()
+
+	byteResult, err := io.ReadAll(bodyResult.Body)
+	s.NoError(err)
+
+	return bodyResult, string(byteResult)
+}
+
+func (s *ExportTestSuite) TestExportCSVSuccessful() {
+	const (
+		expectedResult = "id,name,description,price,currency,sku,stock,categories,created_at,updated_at\n" +
+			`1,"Продукт, новый",,10000,RUB,SKU-1,10,Категория А|Категория Б,2024-01-02T03:04:05Z,2024-01-02T03:04:05Z` + "\n"
+	)
+
+	s.expectExport(dto.GetProduct{
+		Sort:   []dto.SortField{{Field: "name", Desc: true}},
+		Filter: dto.ProductFilter{NamePrefix: "Про"},
+	}, nil)
+
+	response, result := s.do("/product/export?sort=-name&name_prefix=Про")
+
+	s.Equal(http.StatusOK, response.StatusCode)
+	s.Equal("text/csv; charset=utf-8", response.Header.Get("Content-Type"))
+	s.True(strings.HasPrefix(response.Header.Get("Content-Disposition"), `attachment; filename="products-`))
+	s.True(strings.HasSuffix(response.Header.Get("Content-Disposition"), `.csv"`))
+	s.Equal(expectedResult, result)
+}
+
+func (s *ExportTestSuite) TestExportNDJSONSuccessful() {
+	const (
+		expectedResult = `{"id":1,"name":"Продукт, новый","description":"","price":10000,"currency":"RUB","sku":"SKU-1","stock":10,` +
+			`"created_at":"2024-01-02T03:04:05Z","updated_at":"2024-01-02T03:04:05Z","version":1,"categories":["Категория А","Категория Б"]}` + "\n"
+	)
+
+	s.expectExport(dto.GetProduct{}, nil)
+
+	response, result := s.do("/product/export?format=ndjson")
+
+	s.Equal(http.StatusOK, response.StatusCode)
+	s.Equal("application/x-ndjson", response.Header.Get("Content-Type"))
+	s.Equal(expectedResult, result)
+}
+
+func (s *ExportTestSuite) TestExportEmptySuccessful() {
+	const (
+		expectedResult = "id,name,description,price,currency,sku,stock,categories,created_at,updated_at\n"
+	)
+
+	s.products = nil
+
+	s.expectExport(dto.GetProduct{}, nil)
+
+	response, result := s.do("/product/export")
+
+	s.Equal(http.StatusOK, response.StatusCode)
+	s.Equal(expectedResult, result)
+}
+
+func (s *ExportTestSuite) TestExportFailed() {
+	const (
+		expectedResult = `{"error":"unknown sort field \"description\""}`
+	)
+
+	s.expectExport(
+		dto.GetProduct{Sort: []dto.SortField{{Field: "description"}}},
+		errors.ErrInvalid.New(`unknown sort field "description"`),
+	)
+
+	response, result := s.do("/product/export?sort=description")
+
+	s.Equal(http.StatusBadRequest, response.StatusCode)
+	s.Equal("", response.Header.Get("Content-Disposition"))
+	s.Equal(expectedResult, strings.Trim(result, " \n"))
+}
+
+func (s *ExportTestSuite) TestExportAbortedAfterStart() {
+	s.useCaseProductMock.
+		EXPECT().
+		Export(gomock.Any(), dto.GetProduct{}, gomock.Any()).
+		DoAndReturn(func(_ any, _ dto.GetProduct, write func(dto.ExportProduct) error) error {
+			if err := write(s.products[0]); err != nil {
+				return err
+			}
+
+			return errors.ErrInternal.New("connection reset")
+		}).
+		Times(1)
+
+	s.PanicsWithValue(http.ErrAbortHandler, func() {
+		s.do("/product/export")
+	})
+}
+
+func (s *ExportTestSuite) TestExportUnsupportedFormatFailed() {
+	const (
+		expectedResult = `{"error":"unsupported export format"}`
+	)
+
+	response, result := s.do("/product/export?format=xlsx")
+
+	s.Equal(http.StatusBadRequest, response.StatusCode)
+	s.Equal(expectedResult, strings.Trim(result, " \n"))
+}
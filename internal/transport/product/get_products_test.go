@@ -2,16 +2,27 @@ package product
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/jackvonhouse/product-catalog/config"
 	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/errors"
+	"github.com/jackvonhouse/product-catalog/internal/principal"
+	"github.com/jackvonhouse/product-catalog/internal/transport"
+	"github.com/jackvonhouse/product-catalog/internal/transport/cursor"
+	"github.com/jackvonhouse/product-catalog/internal/transport/middleware"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
 )
 
 type GetTestSuite struct {
@@ -19,6 +30,8 @@ type GetTestSuite struct {
 
 	// Вспомогательные параметры
 	logger    log.Logger
+	cursor    cursor.Cursor
+	cache     transport.HTTPCache
 	transport Transport
 
 	// Входные параметры
@@ -38,6 +51,8 @@ func TestSuiteGet(t *testing.T) {
 
 func (s *GetTestSuite) SetupTest() {
 	s.logger = log.NewNullLogger()
+	s.cursor = cursor.New(config.Cursor{SecretKey: "secret"})
+	s.cache = transport.NewHTTPCache("public, max-age=60")
 }
 
 func (s *GetTestSuite) BeforeTest(_, _ string) {
@@ -81,8 +96,13 @@ func (s *GetTestSuite) setupProducts(
 
 	s.products = []dto.Product{
 		{
-			ID:   id,
-			Name: name,
+			ID:          id,
+			Name:        name,
+			Description: "Описание",
+			Price:       10000,
+			Currency:    "RUB",
+			SKU:         "SKU-1",
+			Stock:       10,
 		},
 	}
 
@@ -90,7 +110,7 @@ func (s *GetTestSuite) setupProducts(
 }
 
 func (s *GetTestSuite) setupTransport() *GetTestSuite {
-	s.transport = New(s.useCaseProductMock, s.useCaseAccessTokenMock, s.logger)
+	s.transport = New(s.useCaseProductMock, s.useCaseAccessTokenMock, middleware.Idempotency{}, s.cursor, s.cache, s.logger)
 
 	return s
 }
@@ -110,13 +130,13 @@ func (s *GetTestSuite) setupGetProduct(
 func (s *GetTestSuite) TestGetSuccessful() {
 	const (
 		expectedBody   = ``
-		expectedResult = `[{"id":1,"name":"Продукт"}]`
+		expectedResult = `{"items":[{"id":1,"name":"Продукт","description":"Описание","price":10000,"currency":"RUB","sku":"SKU-1","stock":10,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}],"total":1,"limit":10,"offset":0,"next":null,"prev":null}`
 	)
 
 	s.useCaseProductMock.
 		EXPECT().
 		Get(gomock.Any(), s.get).
-		Return(s.products, nil).
+		Return(dto.ProductPage{Items: s.products, Total: 1}, nil).
 		Times(1)
 
 	r := httptest.NewRecorder()
@@ -146,16 +166,109 @@ func (s *GetTestSuite) TestGetSuccessful() {
 	s.Equal(expectedResult, strings.Trim(result, " \n"))
 }
 
+func (s *GetTestSuite) TestGetCacheHeadersSuccessful() {
+	const (
+		expectedBody         = ``
+		expectedCacheControl = "public, max-age=60"
+	)
+
+	s.products[0].UpdatedAt = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	s.useCaseProductMock.
+		EXPECT().
+		Get(gomock.Any(), s.get).
+		Return(dto.ProductPage{Items: s.products, Total: 1}, nil).
+		Times(1)
+
+	r := httptest.NewRecorder()
+	w, err := http.NewRequest(
+		http.MethodGet,
+		"",
+		bytes.NewBufferString(expectedBody),
+	)
+	s.NoError(err)
+
+	s.transport.Get(r, w)
+
+	bodyResult := r.Result()
+	defer bodyResult.Body.Close()
+
+	s.Equal(http.StatusOK, bodyResult.StatusCode)
+	s.NotEmpty(bodyResult.Header.Get(transport.ETagHeader))
+	s.Equal(expectedCacheControl, bodyResult.Header.Get(transport.CacheControlHeader))
+}
+
+func (s *GetTestSuite) TestGetNotModifiedSuccessful() {
+	const (
+		expectedBody = ``
+	)
+
+	s.products[0].UpdatedAt = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	request := func(headers map[string]string) *http.Response {
+		r := httptest.NewRecorder()
+		w, err := http.NewRequest(
+			http.MethodGet,
+			"",
+			bytes.NewBufferString(expectedBody),
+		)
+		s.NoError(err)
+
+		for key, value := range headers {
+			w.Header.Set(key, value)
+		}
+
+		s.transport.Get(r, w)
+
+		return r.Result()
+	}
+
+	s.useCaseProductMock.
+		EXPECT().
+		Get(gomock.Any(), s.get).
+		Return(dto.ProductPage{Items: s.products, Total: 1}, nil).
+		Times(3)
+
+	etag := request(nil).Header.Get(transport.ETagHeader)
+
+	testCases := []struct {
+		testName       string
+		headers        map[string]string
+		expectedStatus int
+	}{
+		{
+			testName:       "Matching ETag",
+			headers:        map[string]string{transport.IfNoneMatchHeader: etag},
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			testName:       "Stale ETag",
+			headers:        map[string]string{transport.IfNoneMatchHeader: `"stale"`},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, testCase := range testCases {
+		s.Run(testCase.testName, func() {
+			bodyResult := request(testCase.headers)
+			defer bodyResult.Body.Close()
+
+			s.Equal(testCase.expectedStatus, bodyResult.StatusCode)
+			s.Equal(etag, bodyResult.Header.Get(transport.ETagHeader))
+		})
+	}
+}
+
 func (s *GetTestSuite) TestGetDefaultParamsSuccessful() {
 	const (
 		expectedBody   = ``
-		expectedResult = `[{"id":1,"name":"Продукт"}]`
+		expectedResult = `{"items":[{"id":1,"name":"Продукт","description":"Описание","price":10000,"currency":"RUB","sku":"SKU-1","stock":10,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}],"total":1,"limit":10,"offset":0,"next":null,"prev":null}`
 	)
 
 	s.useCaseProductMock.
 		EXPECT().
 		Get(gomock.Any(), s.get).
-		Return(s.products, nil).
+		Return(dto.ProductPage{Items: s.products, Total: 1}, nil).
 		Times(1)
 
 	r := httptest.NewRecorder()
@@ -216,7 +329,7 @@ func (s *GetTestSuite) TestGetFailed() {
 			s.useCaseProductMock.
 				EXPECT().
 				Get(gomock.Any(), s.get).
-				Return(s.products, fmt.Errorf(testCase.expectedErrorMsg)).
+				Return(dto.ProductPage{Items: s.products, Total: 1}, fmt.Errorf(testCase.expectedErrorMsg)).
 				Times(1)
 
 			r := httptest.NewRecorder()
@@ -242,18 +355,56 @@ func (s *GetTestSuite) TestGetFailed() {
 	}
 }
 
-func (s *GetTestSuite) TestGetByCategoryIdSuccessful() {
+func (s *GetTestSuite) TestGetCategoryFilterSuccessful() {
 	const (
-		expectedBody   = ``
-		expectedResult = `[{"id":1,"name":"Продукт"}]`
+		expectedResult = `{"items":[{"id":1,"name":"Продукт","description":"Описание","price":10000,"currency":"RUB","sku":"SKU-1","stock":10,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}],"total":1,"limit":10,"offset":0,"next":null,"prev":null}`
 	)
 
+	s.get.Sort = []dto.SortField{
+		{Field: "price", Desc: true},
+	}
+
+	s.get.Filter = dto.ProductFilter{
+		NamePrefix:         "Про",
+		CategoryIds:        []int{s.category.ID},
+		IncludeDescendants: true,
+	}
+
 	s.useCaseProductMock.
 		EXPECT().
-		GetByCategoryId(gomock.Any(), s.get, s.category.ID).
-		Return(s.products, nil).
+		Get(gomock.Any(), s.get).
+		Return(dto.ProductPage{Items: s.products, Total: 1}, nil).
 		Times(1)
 
+	router := mux.NewRouter()
+	s.transport.Handle(router.PathPrefix("/product").Subrouter())
+
+	queries := url.Values{}
+	queries.Add("category_id", fmt.Sprintf("%d", s.category.ID))
+	queries.Add("include_descendants", "true")
+	queries.Add("sort", "-price")
+	queries.Add("name_prefix", "Про")
+
+	r := httptest.NewRecorder()
+	w, err := http.NewRequest(
+		http.MethodGet,
+		"/product?"+queries.Encode(),
+		nil,
+	)
+	s.NoError(err)
+
+	router.ServeHTTP(r, w)
+
+	s.Equal(http.StatusOK, r.Code)
+	s.Equal(expectedResult, strings.Trim(r.Body.String(), " \n"))
+}
+
+func (s *GetTestSuite) TestGetCategoryFilterInvalidFailed() {
+	const (
+		expectedBody   = ``
+		expectedResult = `{"error":"invalid category id"}`
+	)
+
 	r := httptest.NewRecorder()
 	w, err := http.NewRequest(
 		http.MethodGet,
@@ -265,11 +416,11 @@ func (s *GetTestSuite) TestGetByCategoryIdSuccessful() {
 	queries := w.URL.Query()
 	queries.Add("limit", fmt.Sprintf("%d", s.get.Limit))
 	queries.Add("offset", fmt.Sprintf("%d", s.get.Offset))
-	queries.Add("category_id", fmt.Sprintf("%d", s.category.ID))
+	queries.Add("category_id", fmt.Sprintf("%s", "invalid category id"))
 
 	w.URL.RawQuery = queries.Encode()
 
-	s.transport.GetByCategoryId(r, w)
+	s.transport.Get(r, w)
 
 	bodyResult := r.Result()
 	defer bodyResult.Body.Close()
@@ -282,12 +433,255 @@ func (s *GetTestSuite) TestGetByCategoryIdSuccessful() {
 	s.Equal(expectedResult, strings.Trim(result, " \n"))
 }
 
-func (s *GetTestSuite) TestGetByCategoryIdInvalidFailed() {
+func (s *GetTestSuite) TestGetByIdSuccessful() {
 	const (
 		expectedBody   = ``
-		expectedResult = `{"error":"invalid category id"}`
+		expectedResult = `{"id":1,"name":"Продукт","description":"Описание","price":10000,"currency":"RUB","sku":"SKU-1","stock":10,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","categories":[{"id":1,"name":"Категория","parent_id":null,"updated_at":"0001-01-01T00:00:00Z"}]}`
+	)
+
+	s.useCaseProductMock.
+		EXPECT().
+		GetById(gomock.Any(), s.products[0].ID).
+		Return(dto.ProductWithCategories{
+			Product:    s.products[0],
+			Categories: []dto.Category{s.category},
+		}, nil).
+		Times(1)
+
+	r := httptest.NewRecorder()
+	w, err := http.NewRequest(
+		http.MethodGet,
+		"",
+		bytes.NewBufferString(expectedBody),
+	)
+	s.NoError(err)
+
+	w = mux.SetURLVars(w, map[string]string{
+		"id": fmt.Sprintf("%d", s.products[0].ID),
+	})
+
+	s.transport.GetById(r, w)
+
+	bodyResult := r.Result()
+	defer bodyResult.Body.Close()
+
+	byteResult, err := io.ReadAll(bodyResult.Body)
+	s.NoError(err)
+
+	result := string(byteResult)
+
+	s.Equal(http.StatusOK, bodyResult.StatusCode)
+	s.Equal(expectedResult, strings.Trim(result, " \n"))
+}
+
+func (s *GetTestSuite) TestGetByIdETagSuccessful() {
+	const (
+		expectedBody = ``
+		expectedETag = `"3"`
+	)
+
+	s.products[0].Version = 3
+
+	s.useCaseProductMock.
+		EXPECT().
+		GetById(gomock.Any(), s.products[0].ID).
+		Return(dto.ProductWithCategories{
+			Product:    s.products[0],
+			Categories: []dto.Category{s.category},
+		}, nil).
+		Times(1)
+
+	r := httptest.NewRecorder()
+	w, err := http.NewRequest(
+		http.MethodGet,
+		"",
+		bytes.NewBufferString(expectedBody),
+	)
+	s.NoError(err)
+
+	w = mux.SetURLVars(w, map[string]string{
+		"id": fmt.Sprintf("%d", s.products[0].ID),
+	})
+
+	s.transport.GetById(r, w)
+
+	bodyResult := r.Result()
+	defer bodyResult.Body.Close()
+
+	s.Equal(http.StatusOK, bodyResult.StatusCode)
+	s.Equal(expectedETag, bodyResult.Header.Get(transport.ETagHeader))
+}
+
+func (s *GetTestSuite) TestGetByIdNotModifiedSuccessful() {
+	const (
+		expectedBody         = ``
+		expectedETag         = `"3"`
+		expectedLastModified = "Wed, 03 Jan 2024 03:04:05 GMT"
+	)
+
+	s.products[0].Version = 3
+	s.products[0].UpdatedAt = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.category.UpdatedAt = time.Date(2024, 1, 3, 3, 4, 5, 0, time.UTC)
+
+	testCases := []struct {
+		testName       string
+		headers        map[string]string
+		expectedStatus int
+	}{
+		{
+			testName:       "Matching ETag",
+			headers:        map[string]string{transport.IfNoneMatchHeader: expectedETag},
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			testName:       "Stale ETag",
+			headers:        map[string]string{transport.IfNoneMatchHeader: `"2"`},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			testName:       "Not modified since",
+			headers:        map[string]string{transport.IfModifiedSinceHeader: expectedLastModified},
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			testName:       "Modified since",
+			headers:        map[string]string{transport.IfModifiedSinceHeader: "Tue, 02 Jan 2024 03:04:05 GMT"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			testName: "If-None-Match takes precedence",
+			headers: map[string]string{
+				transport.IfNoneMatchHeader:     `"2"`,
+				transport.IfModifiedSinceHeader: expectedLastModified,
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, testCase := range testCases {
+		s.Run(testCase.testName, func() {
+			s.useCaseProductMock.
+				EXPECT().
+				GetById(gomock.Any(), s.products[0].ID).
+				Return(dto.ProductWithCategories{
+					Product:    s.products[0],
+					Categories: []dto.Category{s.category},
+				}, nil).
+				Times(1)
+
+			r := httptest.NewRecorder()
+			w, err := http.NewRequest(
+				http.MethodGet,
+				"",
+				bytes.NewBufferString(expectedBody),
+			)
+			s.NoError(err)
+
+			for key, value := range testCase.headers {
+				w.Header.Set(key, value)
+			}
+
+			w = mux.SetURLVars(w, map[string]string{
+				"id": fmt.Sprintf("%d", s.products[0].ID),
+			})
+
+			s.transport.GetById(r, w)
+
+			bodyResult := r.Result()
+			defer bodyResult.Body.Close()
+
+			s.Equal(testCase.expectedStatus, bodyResult.StatusCode)
+			s.Equal(expectedETag, bodyResult.Header.Get(transport.ETagHeader))
+			s.Equal(expectedLastModified, bodyResult.Header.Get(transport.LastModifiedHeader))
+		})
+	}
+}
+
+func (s *GetTestSuite) TestGetByIdFailed() {
+	const (
+		expectedNotFoundErrorMsg = "product not found"
 	)
 
+	testCases := []struct {
+		testName           string
+		id                 string
+		mockTimes          int
+		expectedStatusCode int
+		expectedResult     string
+	}{
+		{
+			testName:           "Invalid product id",
+			id:                 "0",
+			mockTimes:          0,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResult:     `{"error":"invalid product id"}`,
+		},
+		{
+			testName:           "Product not found",
+			id:                 "1",
+			mockTimes:          1,
+			expectedStatusCode: http.StatusNotFound,
+			expectedResult:     fmt.Sprintf(`{"error":"%s"}`, expectedNotFoundErrorMsg),
+		},
+	}
+
+	for _, testCase := range testCases {
+		s.T().Run(testCase.testName, func(t *testing.T) {
+			s.useCaseProductMock.
+				EXPECT().
+				GetById(gomock.Any(), 1).
+				Return(
+					dto.ProductWithCategories{},
+					errors.ErrNotFound.New(expectedNotFoundErrorMsg),
+				).
+				Times(testCase.mockTimes)
+
+			r := httptest.NewRecorder()
+			w, err := http.NewRequest(
+				http.MethodGet,
+				"",
+				bytes.NewBufferString(""),
+			)
+			s.NoError(err)
+
+			w = mux.SetURLVars(w, map[string]string{"id": testCase.id})
+
+			s.transport.GetById(r, w)
+
+			bodyResult := r.Result()
+			defer bodyResult.Body.Close()
+
+			byteResult, err := io.ReadAll(bodyResult.Body)
+			s.NoError(err)
+
+			result := string(byteResult)
+
+			s.Equal(testCase.expectedStatusCode, bodyResult.StatusCode)
+			s.Equal(testCase.expectedResult, strings.Trim(result, " \n"))
+		})
+	}
+}
+
+func (s *GetTestSuite) TestSearchSuccessful() {
+	const (
+		expectedBody   = ``
+		expectedResult = `[{"id":1,"name":"Продукт","description":"Описание","price":10000,"currency":"RUB","sku":"SKU-1","stock":10,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","rank":0.5,"snippet":"\u003cb\u003eПродукт\u003c/b\u003e"}]`
+	)
+
+	search := dto.SearchProduct{
+		Query:  "продукт",
+		Limit:  s.get.Limit,
+		Offset: s.get.Offset,
+	}
+
+	s.useCaseProductMock.
+		EXPECT().
+		Search(gomock.Any(), search).
+		Return([]dto.FoundProduct{
+			{Product: s.products[0], Rank: 0.5, Snippet: "<b>Продукт</b>"},
+		}, nil).
+		Times(1)
+
 	r := httptest.NewRecorder()
 	w, err := http.NewRequest(
 		http.MethodGet,
@@ -297,13 +691,13 @@ func (s *GetTestSuite) TestGetByCategoryIdInvalidFailed() {
 	s.NoError(err)
 
 	queries := w.URL.Query()
+	queries.Add("q", " продукт ")
 	queries.Add("limit", fmt.Sprintf("%d", s.get.Limit))
 	queries.Add("offset", fmt.Sprintf("%d", s.get.Offset))
-	queries.Add("category_id", fmt.Sprintf("%s", "invalid category id"))
 
 	w.URL.RawQuery = queries.Encode()
 
-	s.transport.GetByCategoryId(r, w)
+	s.transport.Search(r, w)
 
 	bodyResult := r.Result()
 	defer bodyResult.Body.Close()
@@ -316,16 +710,218 @@ func (s *GetTestSuite) TestGetByCategoryIdInvalidFailed() {
 	s.Equal(expectedResult, strings.Trim(result, " \n"))
 }
 
-func (s *GetTestSuite) TestGetByCategoryIdDefaultParamsSuccessful() {
+func (s *GetTestSuite) TestSearchEmptyQueryFailed() {
 	const (
 		expectedBody   = ``
-		expectedResult = `[{"id":1,"name":"Продукт"}]`
+		expectedResult = `{"error":"search query can't be empty"}`
 	)
 
+	r := httptest.NewRecorder()
+	w, err := http.NewRequest(
+		http.MethodGet,
+		"",
+		bytes.NewBufferString(expectedBody),
+	)
+	s.NoError(err)
+
+	s.transport.Search(r, w)
+
+	bodyResult := r.Result()
+	defer bodyResult.Body.Close()
+
+	byteResult, err := io.ReadAll(bodyResult.Body)
+	s.NoError(err)
+
+	result := string(byteResult)
+
+	s.Equal(http.StatusBadRequest, bodyResult.StatusCode)
+	s.Equal(expectedResult, strings.Trim(result, " \n"))
+}
+
+func (s *GetTestSuite) TestGetCursorSuccessful() {
+	const (
+		expectedBody = ``
+		sort         = "name,-id"
+	)
+
+	s.get.Limit = 1
+	s.get.Sort = []dto.SortField{
+		{Field: "name"},
+		{Field: "id", Desc: true},
+	}
+
+	firstCursor, err := s.cursor.Encode(cursor.Position{
+		Sort:   sort,
+		Values: []any{"Продукт", 3},
+	})
+	s.NoError(err)
+
+	testCases := []struct {
+		testName      string
+		cursor        string
+		expectedAfter []any
+	}{
+		{
+			testName:      "First page",
+			cursor:        "",
+			expectedAfter: nil,
+		},
+		{
+			testName:      "Next page",
+			cursor:        firstCursor,
+			expectedAfter: []any{"Продукт", json.Number("3")},
+		},
+	}
+
+	for i, testCase := range testCases {
+		s.Run(testCase.testName, func() {
+			get := s.get
+			get.After = testCase.expectedAfter
+
+			products := []dto.Product{s.products[0]}
+			products[0].ID = 3 - i
+
+			last := []any{products[0].Name, products[0].ID}
+
+			expectedCursor, err := s.cursor.Encode(cursor.Position{
+				Sort:   sort,
+				Values: last,
+			})
+			s.NoError(err)
+
+			s.useCaseProductMock.
+				EXPECT().
+				Get(gomock.Any(), get).
+				Return(dto.ProductPage{Items: products, Total: 2, Last: last}, nil).
+				Times(1)
+
+			r := httptest.NewRecorder()
+			w, err := http.NewRequest(
+				http.MethodGet,
+				"",
+				bytes.NewBufferString(expectedBody),
+			)
+			s.NoError(err)
+
+			queries := w.URL.Query()
+			queries.Add("limit", fmt.Sprintf("%d", s.get.Limit))
+			queries.Add("sort", sort)
+			queries.Add("cursor", testCase.cursor)
+
+			w.URL.RawQuery = queries.Encode()
+
+			s.transport.Get(r, w)
+
+			bodyResult := r.Result()
+			defer bodyResult.Body.Close()
+
+			byteResult, err := io.ReadAll(bodyResult.Body)
+			s.NoError(err)
+
+			result := string(byteResult)
+
+			s.Equal(
+				fmt.Sprintf(
+					`{"items":[{"id":%d,"name":"Продукт","description":"Описание","price":10000,"currency":"RUB","sku":"SKU-1","stock":10,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}],"total":2,"limit":1,"offset":0,"next":"?cursor=%s\u0026limit=1\u0026sort=name%%2C-id","prev":null,"next_cursor":"%s"}`,
+					products[0].ID, expectedCursor, expectedCursor,
+				),
+				strings.Trim(result, " \n"),
+			)
+		})
+	}
+}
+
+func (s *GetTestSuite) TestGetCursorFailed() {
+	const (
+		expectedBody               = ``
+		expectedInvalidResult      = `{"error":"invalid cursor"}`
+		expectedSortMismatchResult = `{"error":"cursor doesn't match sort"}`
+	)
+
+	forged, err := cursor.New(config.Cursor{SecretKey: "forged"}).
+		Encode(cursor.Position{ID: 1})
+	s.NoError(err)
+
+	otherSort, err := s.cursor.Encode(cursor.Position{
+		Sort:   "name",
+		Values: []any{"Продукт", 1},
+	})
+	s.NoError(err)
+
+	testCases := []struct {
+		testName       string
+		cursor         string
+		expectedResult string
+	}{
+		{
+			testName:       "Malformed",
+			cursor:         "malformed",
+			expectedResult: expectedInvalidResult,
+		},
+		{
+			testName:       "Forged signature",
+			cursor:         forged,
+			expectedResult: expectedInvalidResult,
+		},
+		{
+			testName:       "Sort mismatch",
+			cursor:         otherSort,
+			expectedResult: expectedSortMismatchResult,
+		},
+	}
+
+	for _, testCase := range testCases {
+		s.Run(testCase.testName, func() {
+			r := httptest.NewRecorder()
+			w, err := http.NewRequest(
+				http.MethodGet,
+				"",
+				bytes.NewBufferString(expectedBody),
+			)
+			s.NoError(err)
+
+			queries := w.URL.Query()
+			queries.Add("cursor", testCase.cursor)
+
+			w.URL.RawQuery = queries.Encode()
+
+			s.transport.Get(r, w)
+
+			bodyResult := r.Result()
+			defer bodyResult.Body.Close()
+
+			byteResult, err := io.ReadAll(bodyResult.Body)
+			s.NoError(err)
+
+			result := string(byteResult)
+
+			s.Equal(http.StatusBadRequest, bodyResult.StatusCode)
+			s.Equal(testCase.expectedResult, strings.Trim(result, " \n"))
+		})
+	}
+}
+
+func (s *GetTestSuite) TestGetSortAndFilterSuccessful() {
+	const (
+		expectedBody   = ``
+		expectedResult = `{"items":[{"id":1,"name":"Продукт","description":"Описание","price":10000,"currency":"RUB","sku":"SKU-1","stock":10,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}],"total":1,"limit":10,"offset":0,"next":null,"prev":null}`
+	)
+
+	s.get.Sort = []dto.SortField{
+		{Field: "price", Desc: true},
+		{Field: "name"},
+	}
+
+	s.get.Filter = dto.ProductFilter{
+		NamePrefix:   "Про",
+		CategoryIds:  []int{1, 2, 3},
+		CreatedAfter: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
 	s.useCaseProductMock.
 		EXPECT().
-		GetByCategoryId(gomock.Any(), s.get, s.category.ID).
-		Return(s.products, nil).
+		Get(gomock.Any(), s.get).
+		Return(dto.ProductPage{Items: s.products, Total: 1}, nil).
 		Times(1)
 
 	r := httptest.NewRecorder()
@@ -337,13 +933,14 @@ func (s *GetTestSuite) TestGetByCategoryIdDefaultParamsSuccessful() {
 	s.NoError(err)
 
 	queries := w.URL.Query()
-	queries.Add("limit", fmt.Sprintf("%s", "invalid limit"))
-	queries.Add("offset", fmt.Sprintf("%s", "invalid offset"))
-	queries.Add("category_id", fmt.Sprintf("%d", s.category.ID))
+	queries.Add("sort", "-price,name")
+	queries.Add("name_prefix", "Про")
+	queries.Add("category_id", "1,2,3")
+	queries.Add("created_after", "2024-01-01T00:00:00Z")
 
 	w.URL.RawQuery = queries.Encode()
 
-	s.transport.GetByCategoryId(r, w)
+	s.transport.Get(r, w)
 
 	bodyResult := r.Result()
 	defer bodyResult.Body.Close()
@@ -353,59 +950,130 @@ func (s *GetTestSuite) TestGetByCategoryIdDefaultParamsSuccessful() {
 
 	result := string(byteResult)
 
+	s.Equal(http.StatusOK, bodyResult.StatusCode)
 	s.Equal(expectedResult, strings.Trim(result, " \n"))
 }
 
-func (s *GetTestSuite) TestGetByCategoryIdFailed() {
+func (s *GetTestSuite) TestGetIncludeDeletedSuccessful() {
 	const (
-		expectedInternalErrorMsg        = "unknown error on getting product"
-		expectedProductNotFoundErrorMsg = "product not found"
+		expectedBody   = ``
+		expectedResult = `{"items":[{"id":1,"name":"Продукт","description":"Описание","price":10000,"currency":"RUB","sku":"SKU-1","stock":10,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}],"total":1,"limit":10,"offset":0,"next":null,"prev":null}`
 	)
 
 	testCases := []struct {
-		testName         string
-		expectedErrorMsg string
-		expectedBody     string
-		expectedResult   string
+		testName       string
+		role           string
+		includeDeleted bool
 	}{
 		{
-			testName:         "Internal error",
-			expectedErrorMsg: expectedInternalErrorMsg,
-			expectedBody:     `{"name":"Продукт"}`,
-			expectedResult:   fmt.Sprintf(`{"error":"%s"}`, expectedInternalErrorMsg),
+			testName:       "Admin",
+			role:           dto.RoleAdmin,
+			includeDeleted: true,
 		},
 		{
-			testName:         "Product not found",
-			expectedErrorMsg: expectedProductNotFoundErrorMsg,
-			expectedBody:     `{"name":"Продукт"}`,
-			expectedResult:   fmt.Sprintf(`{"error":"%s"}`, expectedProductNotFoundErrorMsg),
+			testName:       "Editor",
+			role:           dto.RoleEditor,
+			includeDeleted: false,
 		},
 	}
 
 	for _, testCase := range testCases {
-		s.T().Run(testCase.testName, func(t *testing.T) {
+		s.Run(testCase.testName, func() {
+			get := s.get
+			get.IncludeDeleted = testCase.includeDeleted
+
 			s.useCaseProductMock.
 				EXPECT().
-				GetByCategoryId(gomock.Any(), s.get, s.category.ID).
-				Return(s.products, fmt.Errorf(testCase.expectedErrorMsg)).
+				Get(gomock.Any(), get).
+				Return(dto.ProductPage{Items: s.products, Total: 1}, nil).
 				Times(1)
 
+			ctx := principal.WithContext(
+				context.Background(),
+				principal.Principal{UserId: 1, Username: "user", Role: testCase.role},
+			)
+
+			r := httptest.NewRecorder()
+			w, err := http.NewRequestWithContext(
+				ctx,
+				http.MethodGet,
+				"",
+				bytes.NewBufferString(expectedBody),
+			)
+			s.NoError(err)
+
+			queries := w.URL.Query()
+			queries.Add("include_deleted", "true")
+
+			w.URL.RawQuery = queries.Encode()
+
+			s.transport.Get(r, w)
+
+			bodyResult := r.Result()
+			defer bodyResult.Body.Close()
+
+			byteResult, err := io.ReadAll(bodyResult.Body)
+			s.NoError(err)
+
+			result := string(byteResult)
+
+			s.Equal(http.StatusOK, bodyResult.StatusCode)
+			s.Equal(expectedResult, strings.Trim(result, " \n"))
+		})
+	}
+}
+
+func (s *GetTestSuite) TestGetSortAndFilterFailed() {
+	const (
+		expectedBody = ``
+	)
+
+	testCases := []struct {
+		testName       string
+		queries        map[string]string
+		expectedResult string
+	}{
+		{
+			testName:       "Invalid category id",
+			queries:        map[string]string{"category_id": "1,a"},
+			expectedResult: `{"error":"invalid category id"}`,
+		},
+		{
+			testName:       "Invalid created after",
+			queries:        map[string]string{"created_after": "yesterday"},
+			expectedResult: `{"error":"invalid created after date"}`,
+		},
+		{
+			testName:       "Unknown sort field",
+			queries:        map[string]string{"sort": "-password"},
+			expectedResult: `{"error":"unknown sort field \"password\""}`,
+		},
+	}
+
+	s.useCaseProductMock.
+		EXPECT().
+		Get(gomock.Any(), gomock.Any()).
+		Return(dto.ProductPage{}, errors.ErrInvalid.New(`unknown sort field "password"`)).
+		Times(1)
+
+	for _, testCase := range testCases {
+		s.Run(testCase.testName, func() {
 			r := httptest.NewRecorder()
 			w, err := http.NewRequest(
-				http.MethodPost,
+				http.MethodGet,
 				"",
-				bytes.NewBufferString(testCase.expectedBody),
+				bytes.NewBufferString(expectedBody),
 			)
 			s.NoError(err)
 
 			queries := w.URL.Query()
-			queries.Add("limit", fmt.Sprintf("%s", "invalid limit"))
-			queries.Add("offset", fmt.Sprintf("%s", "invalid offset"))
-			queries.Add("category_id", fmt.Sprintf("%d", s.category.ID))
+			for key, value := range testCase.queries {
+				queries.Add(key, value)
+			}
 
 			w.URL.RawQuery = queries.Encode()
 
-			s.transport.GetByCategoryId(r, w)
+			s.transport.Get(r, w)
 
 			bodyResult := r.Result()
 			defer bodyResult.Body.Close()
@@ -415,7 +1083,50 @@ func (s *GetTestSuite) TestGetByCategoryIdFailed() {
 
 			result := string(byteResult)
 
+			s.Equal(http.StatusBadRequest, bodyResult.StatusCode)
 			s.Equal(testCase.expectedResult, strings.Trim(result, " \n"))
 		})
 	}
 }
+
+func (s *GetTestSuite) TestGetPageLinksSuccessful() {
+	const (
+		expectedBody   = ``
+		expectedResult = `{"items":[{"id":1,"name":"Продукт","description":"Описание","price":10000,"currency":"RUB","sku":"SKU-1","stock":10,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}],"total":25,"limit":10,"offset":10,"next":"/api/v1/product?limit=10\u0026offset=20","prev":"/api/v1/product?limit=10\u0026offset=0"}`
+	)
+
+	s.get.Offset = 10
+
+	s.useCaseProductMock.
+		EXPECT().
+		Get(gomock.Any(), s.get).
+		Return(dto.ProductPage{Items: s.products, Total: 25}, nil).
+		Times(1)
+
+	r := httptest.NewRecorder()
+	w, err := http.NewRequest(
+		http.MethodGet,
+		"/api/v1/product",
+		bytes.NewBufferString(expectedBody),
+	)
+	s.NoError(err)
+
+	queries := w.URL.Query()
+	queries.Add("limit", fmt.Sprintf("%d", s.get.Limit))
+	queries.Add("offset", fmt.Sprintf("%d", s.get.Offset))
+
+	w.URL.RawQuery = queries.Encode()
+
+	s.transport.Get(r, w)
+
+	bodyResult := r.Result()
+	defer bodyResult.Body.Close()
+
+	byteResult, err := io.ReadAll(bodyResult.Body)
+	s.NoError(err)
+
+	result := string(byteResult)
+
+	s.Equal("25", bodyResult.Header.Get("X-Total-Count"))
+	s.Equal(expectedResult, strings.Trim(result, " \n"))
+}
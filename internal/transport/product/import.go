@@ -0,0 +1,241 @@
+package product
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/export"
+	"github.com/jackvonhouse/product-catalog/internal/transport/validator"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	formatCSV    = export.FormatCSV
+	formatNDJSON = export.FormatNDJSON
+
+	maxImportSize     = 32 << 20
+	maxImportLineSize = 1 << 20
+
+	categoriesSeparator = export.CategoriesSeparator
+)
+
+var (
+	errUnsupportedImportFormat = errors.New("unsupported import format")
+
+	requiredImportColumns = []string{"name", "price", "sku", "categories"}
+)
+
+type importParser func(io.Reader) ([]dto.ImportProduct, []dto.ImportError, error)
+
+// importFormat определяет формат файла по параметру format,
+// а при его отсутствии — по Content-Type
+func importFormat(
+	r *http.Request,
+) (importParser, error) {
+
+	format := r.URL.Query().Get("format")
+
+	if format == "" {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+		switch mediaType {
+
+		case "text/csv":
+			format = formatCSV
+
+		case "application/x-ndjson", "application/jsonl":
+			format = formatNDJSON
+		}
+	}
+
+	switch format {
+
+	case formatCSV:
+		return parseImportCSV, nil
+
+	case formatNDJSON:
+		return parseImportNDJSON, nil
+
+	default:
+		return nil, errUnsupportedImportFormat
+	}
+}
+
+// parseImportCSV читает CSV с заголовком. Категории перечисляются в одной
+// ячейке через "|". Номер строки совпадает с номером строки файла
+func parseImportCSV(
+	body io.Reader,
+) ([]dto.ImportProduct, []dto.ImportError, error) {
+
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, nil, errors.New("empty csv file")
+		}
+
+		return nil, nil, fmt.Errorf("invalid csv header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	for _, column := range requiredImportColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, nil, fmt.Errorf("missing csv column %q", column)
+		}
+	}
+
+	field := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+
+		return strings.TrimSpace(record[i])
+	}
+
+	rows := make([]dto.ImportProduct, 0)
+	errs := make([]dto.ImportError, 0)
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			var parseErr *csv.ParseError
+
+			if !errors.As(err, &parseErr) {
+				return nil, nil, err
+			}
+
+			errs = append(errs, dto.ImportError{Row: parseErr.StartLine, Reason: parseErr.Err.Error()})
+
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		row := dto.ImportProduct{
+			Row:         line,
+			Name:        field(record, "name"),
+			Description: field(record, "description"),
+			Currency:    field(record, "currency"),
+			SKU:         field(record, "sku"),
+			Categories:  strings.Split(field(record, "categories"), categoriesSeparator),
+		}
+
+		if row.Price, err = strconv.ParseInt(field(record, "price"), 10, 64); err != nil {
+			errs = append(errs, dto.ImportError{Row: line, Reason: "invalid price"})
+
+			continue
+		}
+
+		if stock := field(record, "stock"); stock != "" {
+			if row.Stock, err = strconv.Atoi(stock); err != nil {
+				errs = append(errs, dto.ImportError{Row: line, Reason: "invalid stock"})
+
+				continue
+			}
+		}
+
+		if err := validateImportRow(&row); err != nil {
+			errs = append(errs, dto.ImportError{Row: line, Reason: err.Error()})
+
+			continue
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, errs, nil
+}
+
+// parseImportNDJSON читает по одному JSON-объекту на строку,
+// пустые строки пропускаются
+func parseImportNDJSON(
+	body io.Reader,
+) ([]dto.ImportProduct, []dto.ImportError, error) {
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineSize)
+
+	rows := make([]dto.ImportProduct, 0)
+	errs := make([]dto.ImportError, 0)
+
+	for line := 1; scanner.Scan(); line++ {
+		data := strings.TrimSpace(scanner.Text())
+		if data == "" {
+			continue
+		}
+
+		row := dto.ImportProduct{}
+
+		if err := json.Unmarshal([]byte(data), &row); err != nil {
+			errs = append(errs, dto.ImportError{Row: line, Reason: "invalid json structure"})
+
+			continue
+		}
+
+		row.Row = line
+
+		if err := validateImportRow(&row); err != nil {
+			errs = append(errs, dto.ImportError{Row: line, Reason: err.Error()})
+
+			continue
+		}
+
+		rows = append(rows, row)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return rows, errs, nil
+}
+
+// validateImportRow повторяет проверки создания товара
+// и нормализует список категорий
+func validateImportRow(
+	row *dto.ImportProduct,
+) error {
+
+	if row.Name == "" {
+		return errors.New("name can't be empty")
+	}
+
+	categories := make([]string, 0, len(row.Categories))
+
+	for _, category := range row.Categories {
+		if category = strings.TrimSpace(category); category != "" {
+			categories = append(categories, category)
+		}
+	}
+
+	if len(categories) == 0 {
+		return errors.New("categories can't be empty")
+	}
+
+	row.Categories = categories
+
+	if row.Currency == "" {
+		row.Currency = defaultCurrency
+	}
+
+	return validator.IsValidProduct(row.SKU, row.Currency, row.Price, row.Stock)
+}
@@ -0,0 +1,281 @@
+package product
+
+import (
+	"bytes"
+	"github.com/jackvonhouse/product-catalog/config"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/transport"
+	"github.com/jackvonhouse/product-catalog/internal/transport/cursor"
+	"github.com/jackvonhouse/product-catalog/internal/transport/middleware"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type ImportTestSuite struct {
+	suite.Suite
+
+	// Вспомогательные параметры
+	logger    log.Logger
+	cursor    cursor.Cursor
+	cache     transport.HTTPCache
+	transport Transport
+
+	// Входные параметры
+	rows []dto.ImportProduct
+
+	// Служебные параметры
+	useCaseProductMock     *MockproductUseCase
+	useCaseAccessTokenMock *MockuseCaseAccessToken
+}
+
+func TestSuiteImport(t *testing.T) {
+	suite.Run(t, &ImportTestSuite{})
+}
+
+func (s *ImportTestSuite) SetupTest() {
+	s.logger = log.NewNullLogger()
+	s.cursor = cursor.New(config.Cursor{SecretKey: "secret"})
+	s.cache = transport.NewHTTPCache("public, max-age=60")
+}
+
+func (s *ImportTestSuite) BeforeTest(_, _ string) {
+	controller := gomock.NewController(s.T())
+
+	// Входные значения по-умолчанию
+	s.setupMock(controller).setupTransport().
+		setupRows()
+}
+
+func (s *ImportTestSuite) setupMock(
+	controller *gomock.Controller,
+) *ImportTestSuite {
+
+	s.useCaseProductMock = NewMockproductUseCase(controller)
+	s.useCaseAccessTokenMock = NewMockuseCaseAccessToken(controller)
+
+	return s
+}
+
+func (s *ImportTestSuite) setupTransport() *ImportTestSuite {
+	s.transport = New(s.useCaseProductMock, s.useCaseAccessTokenMock, middleware.Idempotency{}, s.cursor, s.cache, s.logger)
+
+	return s
+}
+
+func (s *ImportTestSuite) setupRows() {
+	s.rows = []dto.ImportProduct{
+		{
+			Row:         2,
+			Name:        "Продукт",
+			Description: "Описание",
+			Price:       10000,
+			Currency:    "RUB",
+			SKU:         "SKU-1",
+			Stock:       10,
+			Categories:  []string{"Категория", "Новая категория"},
+		},
+	}
+}
+
+func (s *ImportTestSuite) do(
+	target string,
+	contentType string,
+	body string,
+) (int, string) {
+
+	r := httptest.NewRecorder()
+	w, err := http.NewRequest(
+		http.MethodPost,
+		target,
+		bytes.NewBufferString(body),
+	)
+	s.NoError(err)
+
+	w.Header.Set("Content-Type", contentType)
+
+	s.transport.Import(r, w)
+
+	bodyResult := r.Result()
+	defer bodyResult.Body.Close()
+
+	byteResult, err := io.ReadAll(bodyResult.Body)
+	s.NoError(err)
+
+	return bodyResult.StatusCode, strings.Trim(string(byteResult), " \n")
+}
+
+func (s *ImportTestSuite) TestImportCSVSuccessful() {
+	const (
+		expectedBody = "name,description,price,currency,sku,stock,categories\n" +
+			"Продукт,Описание,10000,,SKU-1,10,Категория | Новая категория\n" +
+			"Без цены,,abc,,SKU-2,,Категория\n" +
+			"Без категорий,,100,,SKU-3,,\n"
+		expectedResult = `{"total":3,"created":1,"failed":2,` +
+			`"created_categories":["Новая категория"],"dry_run":false,` +
+			`"errors":[{"row":3,"reason":"invalid price"},{"row":4,"reason":"categories can't be empty"}]}`
+	)
+
+	s.useCaseProductMock.
+		EXPECT().
+		Import(gomock.Any(), dto.ImportProducts{
+			Rows: s.rows,
+			Errors: []dto.ImportError{
+				{Row: 3, Reason: "invalid price"},
+				{Row: 4, Reason: "categories can't be empty"},
+			},
+		}).
+		Return(dto.ImportResult{
+			Total:             3,
+			Created:           1,
+			Failed:            2,
+			CreatedCategories: []string{"Новая категория"},
+			Errors: []dto.ImportError{
+				{Row: 3, Reason: "invalid price"},
+				{Row: 4, Reason: "categories can't be empty"},
+			},
+		}, nil).
+		Times(1)
+
+	code, result := s.do("", "text/csv; charset=utf-8", expectedBody)
+
+	s.Equal(http.StatusOK, code)
+	s.Equal(expectedResult, result)
+}
+
+func (s *ImportTestSuite) TestImportNDJSONDryRunSuccessful() {
+	const (
+		expectedBody = `{"name":"Продукт","description":"Описание","price":10000,"sku":"SKU-1","stock":10,"categories":["Категория","Новая категория"]}` + "\n" +
+			"\n" +
+			`{"name":"Дубликат","price":100,"sku":"SKU-1","categories":["Категория"]}` + "\n" +
+			`{wrong json}` + "\n"
+		expectedResult = `{"total":3,"created":1,"failed":2,` +
+			`"created_categories":[],"dry_run":true,` +
+			`"errors":[{"row":3,"reason":"product with sku already exists"},{"row":4,"reason":"invalid json structure"}]}`
+	)
+
+	s.rows[0].Row = 1
+
+	duplicate := dto.ImportProduct{
+		Row:        3,
+		Name:       "Дубликат",
+		Price:      100,
+		Currency:   "RUB",
+		SKU:        "SKU-1",
+		Categories: []string{"Категория"},
+	}
+
+	s.useCaseProductMock.
+		EXPECT().
+		Import(gomock.Any(), dto.ImportProducts{
+			Rows:   append(s.rows, duplicate),
+			Errors: []dto.ImportError{{Row: 4, Reason: "invalid json structure"}},
+			DryRun: true,
+		}).
+		Return(dto.ImportResult{
+			Total:             3,
+			Created:           1,
+			Failed:            2,
+			CreatedCategories: []string{},
+			DryRun:            true,
+			Errors: []dto.ImportError{
+				{Row: 3, Reason: "product with sku already exists"},
+				{Row: 4, Reason: "invalid json structure"},
+			},
+		}, nil).
+		Times(1)
+
+	code, result := s.do("/product/import?format=ndjson&dry_run=true", "", expectedBody)
+
+	s.Equal(http.StatusOK, code)
+	s.Equal(expectedResult, result)
+}
+
+func (s *ImportTestSuite) TestImportAsyncSuccessful() {
+	const (
+		expectedBody = "name,price,sku,categories\n" +
+			"Продукт,10000,SKU-1,Категория\n"
+		expectedResult   = `{"job_id":1}`
+		expectedLocation = "/api/v1/jobs/1"
+	)
+
+	s.useCaseProductMock.
+		EXPECT().
+		ImportAsync(gomock.Any(), dto.ImportProducts{
+			Rows: []dto.ImportProduct{
+				{
+					Row:        2,
+					Name:       "Продукт",
+					Price:      10000,
+					Currency:   "RUB",
+					SKU:        "SKU-1",
+					Categories: []string{"Категория"},
+				},
+			},
+			Errors: []dto.ImportError{},
+		}).
+		Return(1, nil).
+		Times(1)
+
+	r := httptest.NewRecorder()
+	w, err := http.NewRequest(
+		http.MethodPost,
+		"/product/import?async=true",
+		bytes.NewBufferString(expectedBody),
+	)
+	s.NoError(err)
+
+	w.Header.Set("Content-Type", "text/csv")
+
+	s.transport.Import(r, w)
+
+	s.Equal(http.StatusAccepted, r.Code)
+	s.Equal(expectedLocation, r.Header().Get("Location"))
+	s.Equal(expectedResult, strings.Trim(r.Body.String(), " \n"))
+}
+
+func (s *ImportTestSuite) TestImportFailed() {
+	testCases := []struct {
+		testName       string
+		target         string
+		contentType    string
+		body           string
+		expectedCode   int
+		expectedResult string
+	}{
+		{
+			testName:       "Unsupported format",
+			contentType:    "application/json",
+			body:           `[]`,
+			expectedCode:   http.StatusBadRequest,
+			expectedResult: `{"error":"unsupported import format"}`,
+		},
+		{
+			testName:       "Empty csv",
+			target:         "/product/import?format=csv",
+			expectedCode:   http.StatusBadRequest,
+			expectedResult: `{"error":"empty csv file"}`,
+		},
+		{
+			testName:       "Missing csv column",
+			contentType:    "text/csv",
+			body:           "name,price,categories\nПродукт,100,Категория\n",
+			expectedCode:   http.StatusBadRequest,
+			expectedResult: `{"error":"missing csv column \"sku\""}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		s.Run(testCase.testName, func() {
+			code, result := s.do(testCase.target, testCase.contentType, testCase.body)
+
+			s.Equal(testCase.expectedCode, code)
+			s.Equal(testCase.expectedResult, result)
+		})
+	}
+}
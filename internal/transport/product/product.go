@@ -3,48 +3,78 @@ package product
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"github.com/gorilla/mux"
 	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/principal"
 	"github.com/jackvonhouse/product-catalog/internal/transport"
+	"github.com/jackvonhouse/product-catalog/internal/transport/cursor"
 	"github.com/jackvonhouse/product-catalog/internal/transport/middleware"
+	"github.com/jackvonhouse/product-catalog/internal/transport/validator"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
+const (
+	defaultCurrency = "RUB"
+)
+
 type productUseCase interface {
 	Create(context.Context, dto.CreateProduct) (int, error)
-
-	Get(context.Context, dto.GetProduct) ([]dto.Product, error)
-	GetByCategoryId(context.Context, dto.GetProduct, int) ([]dto.Product, error)
+	AttachToCategory(context.Context, int, int) error
+	Import(context.Context, dto.ImportProducts) (dto.ImportResult, error)
+	ImportAsync(context.Context, dto.ImportProducts) (int, error)
+	Batch(context.Context, dto.BatchProducts) (dto.BatchResult, error)
+
+	Get(context.Context, dto.GetProduct) (dto.ProductPage, error)
+	GetById(context.Context, int) (dto.ProductWithCategories, error)
+	GetCategories(context.Context, int) ([]dto.Category, error)
+	Search(context.Context, dto.SearchProduct) ([]dto.FoundProduct, error)
+	Export(context.Context, dto.GetProduct, func(dto.ExportProduct) error) error
+	ExportAsync(context.Context, dto.ExportProducts) (int, error)
 
 	Update(context.Context, dto.UpdateProduct) (int, error)
 
-	Delete(context.Context, int) (int, error)
+	DetachFromCategory(context.Context, int, int) error
+	Delete(context.Context, int, int) (int, error)
+	Restore(context.Context, int) (int, error)
 }
 
 type useCaseAccessToken interface {
-	Verify(context.Context, string) error
+	Parse(context.Context, string) (dto.AccessToken, error)
 }
 
 type Transport struct {
 	product     productUseCase
 	accessToken useCaseAccessToken
 
-	mw     middleware.Middleware
-	logger log.Logger
+	cursor      cursor.Cursor
+	cache       transport.HTTPCache
+	mw          middleware.Middleware
+	idempotency middleware.Idempotency
+	logger      log.Logger
 }
 
 func New(
 	product productUseCase,
 	accessToken useCaseAccessToken,
+	idempotency middleware.Idempotency,
+	cursor cursor.Cursor,
+	cache transport.HTTPCache,
 	logger log.Logger,
 ) Transport {
 
 	return Transport{
 		product:     product,
 		accessToken: accessToken,
+		cursor:      cursor,
+		cache:       cache,
 		mw:          middleware.New(accessToken, logger),
+		idempotency: idempotency,
 		logger:      logger.WithField("unit", "product"),
 	}
 }
@@ -53,36 +83,77 @@ func (t Transport) Handle(
 	router *mux.Router,
 ) {
 
-	authorizedOnly := router.PathPrefix("").Subrouter()
-	authorizedOnly.Use(t.mw.AuthorizedOnly)
+	editorsOnly := router.PathPrefix("").Subrouter()
+	editorsOnly.Use(t.mw.RequireRole(dto.RoleAdmin, dto.RoleEditor))
+
+	adminsOnly := router.PathPrefix("").Subrouter()
+	adminsOnly.Use(t.mw.RequireRole(dto.RoleAdmin))
+
+	idempotent := editorsOnly.PathPrefix("").Subrouter()
+	idempotent.Use(t.idempotency.Idempotent)
+
+	idempotent.HandleFunc("", t.Create).
+		Methods(http.MethodPost)
+
+	editorsOnly.HandleFunc("/import", t.Import).
+		Methods(http.MethodPost)
 
-	authorizedOnly.HandleFunc("", t.Create).
+	editorsOnly.HandleFunc("/batch", t.Batch).
 		Methods(http.MethodPost)
 
-	router.HandleFunc("", t.GetByCategoryId).
+	adminsOnly.HandleFunc("", t.Get).
 		Methods(http.MethodGet).
-		Queries("category_id", "{category_id:[0-9]+}")
+		Queries("include_deleted", "true")
 
 	router.HandleFunc("", t.Get).
 		Methods(http.MethodGet)
 
-	authorizedOnly.HandleFunc("/{id:[0-9]+}", t.Update).
+	router.HandleFunc("/search", t.Search).
+		Methods(http.MethodGet)
+
+	editorsOnly.HandleFunc("/export", t.Export).
+		Methods(http.MethodGet)
+
+	editorsOnly.HandleFunc("/export", t.ExportAsync).
+		Methods(http.MethodPost)
+
+	router.HandleFunc("/{id:[0-9]+}", t.GetById).
+		Methods(http.MethodGet)
+
+	editorsOnly.HandleFunc("/{id:[0-9]+}", t.Update).
 		Methods(http.MethodPut)
 
-	authorizedOnly.HandleFunc("/{id:[0-9]+}", t.Delete).
+	editorsOnly.HandleFunc("/{id:[0-9]+}", t.Delete).
+		Methods(http.MethodDelete)
+
+	editorsOnly.HandleFunc("/{id:[0-9]+}/restore", t.Restore).
+		Methods(http.MethodPost)
+
+	router.HandleFunc("/{id:[0-9]+}/category", t.GetCategories).
+		Methods(http.MethodGet)
+
+	editorsOnly.HandleFunc("/{id:[0-9]+}/category/{category_id:[0-9]+}", t.AttachToCategory).
+		Methods(http.MethodPost)
+
+	editorsOnly.HandleFunc("/{id:[0-9]+}/category/{category_id:[0-9]+}", t.DetachFromCategory).
 		Methods(http.MethodDelete)
 }
 
 // Create godoc
 // @Summary			Создать товар
-// @Description		Создание товара с определённой категорией
+// @Description		Создание товара с указанными категориями. Цена указывается в минимальных единицах валюты (копейках, центах)
 // @Security		Bearer
 // @Accept			json
 // @Produce			json
 // @Param			request body dto.CreateProduct true "Данные о товаре"
+// @Param			Idempotency-Key header string false "Ключ идемпотентности: повторный запрос с тем же ключом возвращает сохранённый ответ"
 // @Success			200 {object} object{id=int}
+// @Failure			400 {object} object{error=string} "Некорректные данные о товаре"
 // @Failure			401 {object} object{error=string} "Пользователь не авторизован"
-// @Failure			409 {object} object{error=string} "Товар уже существует"
+// @Failure			403 {object} object{error=string} "Недостаточно прав"
+// @Failure			404 {object} object{error=string} "Категория не найдена"
+// @Failure			409 {object} object{error=string} "Товар уже существует или запрос с тем же ключом идемпотентности ещё выполняется"
+// @Failure			422 {object} object{error=string} "Ключ идемпотентности использован для другого запроса"
 // @Failure			500 {object} object{error=string} "Неизвестная ошибка"
 // @Tags			Товар
 // @Router /product [post]
@@ -112,6 +183,30 @@ func (t Transport) Create(
 		return
 	}
 
+	if len(data.CategoryIds) == 0 {
+		transport.Error(
+			w,
+			http.StatusBadRequest,
+			"category ids can't be empty",
+		)
+
+		return
+	}
+
+	if data.Currency == "" {
+		data.Currency = defaultCurrency
+	}
+
+	if err := validator.IsValidProduct(data.SKU, data.Currency, data.Price, data.Stock); err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
@@ -129,43 +224,108 @@ func (t Transport) Create(
 	transport.Response(w, map[string]any{"id": id})
 }
 
-func (t Transport) GetByCategoryId(
+// Import godoc
+// @Summary			Импортировать товары
+// @Description		Массовое создание товаров из CSV или JSON Lines. Формат задаётся параметром format или заголовком Content-Type (text/csv, application/x-ndjson). В CSV обязателен заголовок с колонками name, price, sku, categories (опционально description, currency, stock), категории перечисляются через "|". Отсутствующие категории создаются по имени. Некорректные строки не прерывают импорт и попадают в отчёт с номером строки файла. С параметром async=true файл проверяется сразу, а сохранение выполняется фоновой задачей: в ответ возвращается её идентификатор и адрес в заголовке Location
+// @Security		Bearer
+// @Accept			text/csv
+// @Accept			application/x-ndjson
+// @Produce			json
+// @Param			format query string false "Формат файла" Enums(csv, ndjson)
+// @Param			dry_run query bool false "Проверить файл без сохранения"
+// @Param			async query bool false "Выполнить импорт фоновой задачей"
+// @Success			200 {object} dto.ImportResult
+// @Success			202 {object} object{job_id=int} "Задача поставлена в очередь"
+// @Header			202 {string} Location "Адрес задачи"
+// @Failure			400 {object} object{error=string} "Некорректный файл"
+// @Failure			401 {object} object{error=string} "Пользователь не авторизован"
+// @Failure			403 {object} object{error=string} "Недостаточно прав"
+// @Failure			409 {object} object{error=string} "Товар уже существует"
+// @Failure			413 {object} object{error=string} "Файл слишком большой"
+// @Failure			500 {object} object{error=string} "Неизвестная ошибка"
+// @Tags			Товар
+// @Router /product/import [post]
+func (t Transport) Import(
 	w http.ResponseWriter,
 	r *http.Request,
 ) {
 
-	queries := r.URL.Query()
+	parse, err := importFormat(r)
+	if err != nil {
+		transport.Error(
+			w,
+			http.StatusBadRequest,
+			err.Error(),
+		)
 
-	limit, err := transport.StringToInt(queries.Get("limit"))
-	if err != nil || limit <= 0 {
-		limit = 10
+		return
 	}
 
-	offset, err := transport.StringToInt(queries.Get("offset"))
-	if err != nil || offset < 0 {
-		offset = 0
+	dryRun, err := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+	if err != nil {
+		dryRun = false
 	}
 
-	data := dto.GetProduct{
-		Limit:  limit,
-		Offset: offset,
+	async, err := strconv.ParseBool(r.URL.Query().Get("async"))
+	if err != nil {
+		async = false
 	}
 
-	categoryId, err := transport.StringToInt(r.URL.Query().Get("category_id"))
-	if err != nil || categoryId == 0 {
+	rows, errs, err := parse(http.MaxBytesReader(w, r.Body, maxImportSize))
+	if err != nil {
+		t.logger.Warn(err)
+
+		var maxBytesErr *http.MaxBytesError
+
+		if errors.As(err, &maxBytesErr) {
+			transport.Error(
+				w,
+				http.StatusRequestEntityTooLarge,
+				"import file is too large",
+			)
+
+			return
+		}
+
 		transport.Error(
 			w,
 			http.StatusBadRequest,
-			"invalid category id",
+			err.Error(),
 		)
 
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	data := dto.ImportProducts{
+		Rows:   rows,
+		Errors: errs,
+		DryRun: dryRun,
+	}
+
+	if async {
+		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+		defer cancel()
+
+		jobId, err := t.product.ImportAsync(ctx, data)
+		if err != nil {
+			t.logger.Warn(err)
+
+			code, msg := transport.ErrorToHttpResponse(err)
+
+			transport.Error(w, code, msg)
+
+			return
+		}
+
+		transport.JobAccepted(w, jobId)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), time.Minute)
 	defer cancel()
 
-	products, err := t.product.GetByCategoryId(ctx, data, categoryId)
+	result, err := t.product.Import(ctx, data)
 	if err != nil {
 		t.logger.Warn(err)
 
@@ -176,18 +336,32 @@ func (t Transport) GetByCategoryId(
 		return
 	}
 
-	transport.Response(w, products)
+	transport.Response(w, result)
 }
 
 // Get godoc
 // @Summary			Получить товары
-// @Description		Получение товаров
+// @Description		Получение страницы товаров с общим количеством (также в заголовке X-Total-Count) и ссылками на соседние страницы. При передаче параметра cursor используется курсорная пагинация, next_cursor отсутствует на последней странице
 // @Accept			json
 // @Produce			json
 // @Param			limit path int false "Лимит"
 // @Param			offset path int false "Смещение"
-// @Param			category_id path int false "Идентификатор категории"
-// @Success			200 {array} dto.Product
+// @Param			cursor query string false "Курсор следующей страницы. Пустое значение включает курсорную пагинацию с первой страницы"
+// @Param			sort query string false "Сортировка через запятую: id, name, price, stock, sku, created_at. Префикс - задаёт убывающий порядок, например name,-id"
+// @Param			name_prefix query string false "Префикс названия товара"
+// @Param			category_id query string false "Идентификаторы категорий через запятую"
+// @Param			include_descendants query bool false "Включить товары всех дочерних категорий"
+// @Param			created_after query string false "Товары, созданные после указанного времени (RFC 3339)"
+// @Param			include_deleted query bool false "Включить удалённые товары. Доступно только администраторам"
+// @Param			If-None-Match header string false "ETag ранее полученного ответа"
+// @Success			200 {object} transport.Page{items=[]dto.Product}
+// @Header			200 {int} X-Total-Count "Общее количество товаров"
+// @Header			200 {string} ETag "Хэш содержимого ответа"
+// @Header			200 {string} Cache-Control "Политика кэширования"
+// @Success			304 "Содержимое не изменилось"
+// @Failure			400 {object} object{error=string} "Некорректный курсор, сортировка или фильтр"
+// @Failure			401 {object} object{error=string} "Пользователь не авторизован (только с include_deleted)"
+// @Failure			403 {object} object{error=string} "Недостаточно прав (только с include_deleted)"
 // @Failure			404 {object} object{error=string} "Товары отсутствуют или категория не найдена"
 // @Failure			500 {object} object{error=string} "Неизвестная ошибка"
 // @Tags			Товар
@@ -209,9 +383,30 @@ func (t Transport) Get(
 		offset = 0
 	}
 
+	filter, ok := t.productFilter(w, queries)
+	if !ok {
+		return
+	}
+
+	sort := strings.TrimSpace(queries.Get("sort"))
+
+	after, cursorMode, err := t.cursor.ValuesFromQuery(queries, sort)
+	if err != nil {
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
 	data := dto.GetProduct{
 		Limit:  limit,
 		Offset: offset,
+		After:  after,
+		Sort:   t.sortFields(sort),
+		Filter: filter,
+
+		IncludeDeleted: t.includeDeleted(r),
 	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
@@ -228,9 +423,503 @@ func (t Transport) Get(
 		return
 	}
 
+	if cursorMode {
+		t.cursorResponse(
+			w, r, products, limit,
+			cursor.Position{Sort: sort, Values: products.Last},
+		)
+
+		return
+	}
+
+	t.cache.PageResponse(
+		w, r,
+		transport.OffsetPage(r, products.Items, products.Total, limit, offset),
+	)
+}
+
+// Export godoc
+// @Summary			Выгрузить товары
+// @Description		Потоковая выгрузка всех товаров с названиями категорий в CSV или JSON Lines. Поддерживает те же фильтры и сортировку, что и получение товаров. Колонки CSV совместимы с импортом
+// @Security		Bearer
+// @Produce			text/csv
+// @Produce			application/x-ndjson
+// @Param			format query string false "Формат файла, по умолчанию csv" Enums(csv, ndjson)
+// @Param			sort query string false "Сортировка через запятую: id, name, price, stock, sku, created_at. Префикс - задаёт убывающий порядок, например name,-id"
+// @Param			name_prefix query string false "Префикс названия товара"
+// @Param			category_id query string false "Идентификаторы категорий через запятую"
+// @Param			include_descendants query bool false "Включить товары всех дочерних категорий"
+// @Param			created_after query string false "Товары, созданные после указанного времени (RFC 3339)"
+// @Param			include_deleted query bool false "Включить удалённые товары. Доступно только администраторам"
+// @Success			200 {file} file
+// @Header			200 {string} Content-Disposition "Имя файла выгрузки"
+// @Failure			400 {object} object{error=string} "Некорректный формат, сортировка или фильтр"
+// @Failure			401 {object} object{error=string} "Пользователь не авторизован"
+// @Failure			403 {object} object{error=string} "Недостаточно прав"
+// @Failure			500 {object} object{error=string} "Неизвестная ошибка"
+// @Tags			Товар
+// @Router /product/export [get]
+func (t Transport) Export(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+
+	queries := r.URL.Query()
+
+	format := queries.Get("format")
+	if format == "" {
+		format = formatCSV
+	}
+
+	writer, err := newExportWriter(w, format, time.Now())
+	if err != nil {
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	filter, ok := t.productFilter(w, queries)
+	if !ok {
+		return
+	}
+
+	data := dto.GetProduct{
+		Sort:   t.sortFields(strings.TrimSpace(queries.Get("sort"))),
+		Filter: filter,
+
+		IncludeDeleted: t.includeDeleted(r),
+	}
+
+	if err := t.product.Export(r.Context(), data, writer.Write); err != nil {
+		t.logger.Warn(err)
+
+		if writer.Started() {
+			// Заголовки уже отправлены: обрываем соединение,
+			// чтобы клиент не принял неполный файл за целый
+			panic(http.ErrAbortHandler)
+		}
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	if err := writer.Close(); err != nil {
+		t.logger.Warn(err)
+	}
+}
+
+// ExportAsync godoc
+// @Summary			Выгрузить товары фоновой задачей
+// @Description		Постановка выгрузки в очередь задач. Параметры совпадают с потоковой выгрузкой. Готовый файл скачивается по адресу /jobs/{id}/file
+// @Security		Bearer
+// @Produce			json
+// @Param			format query string false "Формат файла, по умолчанию csv" Enums(csv, ndjson)
+// @Param			sort query string false "Сортировка через запятую: id, name, price, stock, sku, created_at. Префикс - задаёт убывающий порядок, например name,-id"
+// @Param			name_prefix query string false "Префикс названия товара"
+// @Param			category_id query string false "Идентификаторы категорий через запятую"
+// @Param			include_descendants query bool false "Включить товары всех дочерних категорий"
+// @Param			created_after query string false "Товары, созданные после указанного времени (RFC 3339)"
+// @Param			include_deleted query bool false "Включить удалённые товары. Доступно только администраторам"
+// @Success			202 {object} object{job_id=int} "Задача поставлена в очередь"
+// @Header			202 {string} Location "Адрес задачи"
+// @Failure			400 {object} object{error=string} "Некорректный формат, сортировка или фильтр"
+// @Failure			401 {object} object{error=string} "Пользователь не авторизован"
+// @Failure			403 {object} object{error=string} "Недостаточно прав"
+// @Failure			500 {object} object{error=string} "Неизвестная ошибка"
+// @Tags			Товар
+// @Router /product/export [post]
+func (t Transport) ExportAsync(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+
+	queries := r.URL.Query()
+
+	format := queries.Get("format")
+	if format == "" {
+		format = formatCSV
+	}
+
+	filter, ok := t.productFilter(w, queries)
+	if !ok {
+		return
+	}
+
+	data := dto.ExportProducts{
+		Format: format,
+		Data: dto.GetProduct{
+			Sort:   t.sortFields(strings.TrimSpace(queries.Get("sort"))),
+			Filter: filter,
+
+			IncludeDeleted: t.includeDeleted(r),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	jobId, err := t.product.ExportAsync(ctx, data)
+	if err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	transport.JobAccepted(w, jobId)
+}
+
+// Search godoc
+// @Summary			Найти товары
+// @Description		Полнотекстовый поиск товаров по названию и описанию. Результаты упорядочены по релевантности, сниппет экранирован как HTML, совпадения в нём выделены тегом <b>
+// @Accept			json
+// @Produce			json
+// @Param			q query string true "Поисковый запрос"
+// @Param			limit query int false "Лимит"
+// @Param			offset query int false "Смещение"
+// @Success			200 {array} dto.FoundProduct
+// @Failure			400 {object} object{error=string} "Пустой поисковый запрос"
+// @Failure			500 {object} object{error=string} "Неизвестная ошибка"
+// @Tags			Товар
+// @Router /product/search [get]
+func (t Transport) Search(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+
+	queries := r.URL.Query()
+
+	searchQuery := strings.TrimSpace(queries.Get("q"))
+	if searchQuery == "" {
+		transport.Error(
+			w,
+			http.StatusBadRequest,
+			"search query can't be empty",
+		)
+
+		return
+	}
+
+	limit, err := transport.StringToInt(queries.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 10
+	}
+
+	offset, err := transport.StringToInt(queries.Get("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	data := dto.SearchProduct{
+		Query:  searchQuery,
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	products, err := t.product.Search(ctx, data)
+	if err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
 	transport.Response(w, products)
 }
 
+// GetById godoc
+// @Summary			Получить товар
+// @Description		Получение товара вместе со всеми категориями, к которым он относится
+// @Accept			json
+// @Produce			json
+// @Param			id path int true "Идентификатор товара"
+// @Param			If-None-Match header string false "ETag ранее полученного ответа"
+// @Param			If-Modified-Since header string false "Значение Last-Modified ранее полученного ответа"
+// @Success			200 {object} dto.ProductWithCategories
+// @Header			200 {string} ETag "Версия товара"
+// @Header			200 {string} Last-Modified "Время последнего изменения товара и его категорий"
+// @Success			304 "Товар не изменился"
+// @Failure			400 {object} object{error=string} "Некорректный идентификатор товара"
+// @Failure			404 {object} object{error=string} "Товар не найден"
+// @Failure			500 {object} object{error=string} "Неизвестная ошибка"
+// @Tags			Товар
+// @Router /product/{id} [get]
+func (t Transport) GetById(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+
+	vars := mux.Vars(r)
+
+	productId, err := transport.StringToInt(vars["id"])
+	if err != nil || productId <= 0 {
+		transport.Error(w, http.StatusBadRequest, "invalid product id")
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	product, err := t.product.GetById(ctx, productId)
+	if err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	times := make([]time.Time, 0, len(product.Categories)+1)
+	times = append(times, product.UpdatedAt)
+
+	for _, category := range product.Categories {
+		times = append(times, category.UpdatedAt)
+	}
+
+	transport.ResourceResponse(w, r, product, product.Version, transport.LastModified(times...))
+}
+
+// GetCategories godoc
+// @Summary			Получить категории товара
+// @Description		Получение всех категорий, к которым относится товар
+// @Accept			json
+// @Produce			json
+// @Param			id path int true "Идентификатор товара"
+// @Success			200 {array} dto.Category
+// @Failure			400 {object} object{error=string} "Некорректный идентификатор товара"
+// @Failure			404 {object} object{error=string} "Товар не найден"
+// @Failure			500 {object} object{error=string} "Неизвестная ошибка"
+// @Tags			Товар
+// @Router /product/{id}/category [get]
+func (t Transport) GetCategories(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+
+	vars := mux.Vars(r)
+
+	productId, err := transport.StringToInt(vars["id"])
+	if err != nil || productId <= 0 {
+		transport.Error(w, http.StatusBadRequest, "invalid product id")
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	categories, err := t.product.GetCategories(ctx, productId)
+	if err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	transport.Response(w, categories)
+}
+
+// AttachToCategory godoc
+// @Summary			Добавить товар в категорию
+// @Description		Привязка товара к категории
+// @Security		Bearer
+// @Accept			json
+// @Produce			json
+// @Param			id path int true "Идентификатор товара"
+// @Param			category_id path int true "Идентификатор категории"
+// @Success			200 {object} object{product_id=int,category_id=int}
+// @Failure			400 {object} object{error=string} "Некорректный идентификатор товара или категории"
+// @Failure			401 {object} object{error=string} "Пользователь не авторизован"
+// @Failure			403 {object} object{error=string} "Недостаточно прав"
+// @Failure			404 {object} object{error=string} "Товар или категория не найдены"
+// @Failure			409 {object} object{error=string} "Товар уже находится в категории"
+// @Failure			500 {object} object{error=string} "Неизвестная ошибка"
+// @Tags			Товар
+// @Router /product/{id}/category/{category_id} [post]
+func (t Transport) AttachToCategory(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+
+	productId, categoryId, ok := t.productAndCategoryIds(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := t.product.AttachToCategory(ctx, productId, categoryId); err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	transport.Response(w, map[string]any{
+		"product_id":  productId,
+		"category_id": categoryId,
+	})
+}
+
+// DetachFromCategory godoc
+// @Summary			Убрать товар из категории
+// @Description		Отвязка товара от категории
+// @Security		Bearer
+// @Accept			json
+// @Produce			json
+// @Param			id path int true "Идентификатор товара"
+// @Param			category_id path int true "Идентификатор категории"
+// @Success			200 {object} object{product_id=int,category_id=int}
+// @Failure			400 {object} object{error=string} "Некорректный идентификатор товара или категории"
+// @Failure			401 {object} object{error=string} "Пользователь не авторизован"
+// @Failure			403 {object} object{error=string} "Недостаточно прав"
+// @Failure			404 {object} object{error=string} "Товар, категория или товар в категории не найдены"
+// @Failure			500 {object} object{error=string} "Неизвестная ошибка"
+// @Tags			Товар
+// @Router /product/{id}/category/{category_id} [delete]
+func (t Transport) DetachFromCategory(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+
+	productId, categoryId, ok := t.productAndCategoryIds(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := t.product.DetachFromCategory(ctx, productId, categoryId); err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	transport.Response(w, map[string]any{
+		"product_id":  productId,
+		"category_id": categoryId,
+	})
+}
+
+func (t Transport) productAndCategoryIds(
+	w http.ResponseWriter,
+	r *http.Request,
+) (int, int, bool) {
+
+	vars := mux.Vars(r)
+
+	productId, err := transport.StringToInt(vars["id"])
+	if err != nil || productId <= 0 {
+		transport.Error(w, http.StatusBadRequest, "invalid product id")
+
+		return 0, 0, false
+	}
+
+	categoryId, err := transport.StringToInt(vars["category_id"])
+	if err != nil || categoryId <= 0 {
+		transport.Error(w, http.StatusBadRequest, "invalid category id")
+
+		return 0, 0, false
+	}
+
+	return productId, categoryId, true
+}
+
+func (t Transport) sortFields(
+	sort string,
+) []dto.SortField {
+
+	var fields []dto.SortField
+
+	for _, field := range strings.Split(sort, ",") {
+		field = strings.TrimSpace(field)
+
+		if field == "" {
+			continue
+		}
+
+		desc := strings.HasPrefix(field, "-")
+
+		fields = append(fields, dto.SortField{
+			Field: strings.TrimPrefix(field, "-"),
+			Desc:  desc,
+		})
+	}
+
+	return fields
+}
+
+func (t Transport) productFilter(
+	w http.ResponseWriter,
+	queries url.Values,
+) (dto.ProductFilter, bool) {
+
+	includeDescendants, err := strconv.ParseBool(queries.Get("include_descendants"))
+	if err != nil {
+		includeDescendants = false
+	}
+
+	filter := dto.ProductFilter{
+		NamePrefix:         queries.Get("name_prefix"),
+		IncludeDescendants: includeDescendants,
+	}
+
+	if categoryIds := queries.Get("category_id"); categoryIds != "" {
+		for _, rawId := range strings.Split(categoryIds, ",") {
+			categoryId, err := transport.StringToInt(strings.TrimSpace(rawId))
+			if err != nil || categoryId <= 0 {
+				transport.Error(w, http.StatusBadRequest, "invalid category id")
+
+				return dto.ProductFilter{}, false
+			}
+
+			filter.CategoryIds = append(filter.CategoryIds, categoryId)
+		}
+	}
+
+	if createdAfter := queries.Get("created_after"); createdAfter != "" {
+		createdAt, err := time.Parse(time.RFC3339, createdAfter)
+		if err != nil {
+			transport.Error(w, http.StatusBadRequest, "invalid created after date")
+
+			return dto.ProductFilter{}, false
+		}
+
+		filter.CreatedAfter = createdAt
+	}
+
+	return filter, true
+}
+
 // Update godoc
 // @Summary			Обновить товар
 // @Description		Обновление товара
@@ -239,10 +928,14 @@ func (t Transport) Get(
 // @Produce			json
 // @Param			request body dto.UpdateProduct true "Данные о товаре"
 // @Param			id path int true "Идентификатор товара"
+// @Param			If-Match header string false "Ожидаемая версия товара из заголовка ETag"
 // @Success			200 {object} object{id=int}
+// @Failure			400 {object} object{error=string} "Некорректные данные о товаре"
 // @Failure			401 {object} object{error=string} "Пользователь не авторизован"
+// @Failure			403 {object} object{error=string} "Недостаточно прав"
 // @Failure			404 {object} object{error=string} "Товар или категория не найдены"
 // @Failure			409 {object} object{error=string} "Товар уже существует"
+// @Failure			412 {object} object{error=string} "Товар был изменён"
 // @Failure			500 {object} object{error=string} "Неизвестная ошибка"
 // @Tags			Товар
 // @Router /product/{id} [put]
@@ -273,6 +966,15 @@ func (t Transport) Update(
 
 	data.ID = productId
 
+	data.Version, err = transport.IfMatch(r)
+	if err != nil {
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
 	if data.Name == "" {
 		transport.Error(
 			w,
@@ -283,6 +985,20 @@ func (t Transport) Update(
 		return
 	}
 
+	if data.Currency == "" {
+		data.Currency = defaultCurrency
+	}
+
+	if err := validator.IsValidProduct(data.SKU, data.Currency, data.Price, data.Stock); err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
@@ -307,9 +1023,13 @@ func (t Transport) Update(
 // @Accept			json
 // @Produce			json
 // @Param			id path int true "Идентификатор товара"
+// @Param			If-Match header string false "Ожидаемая версия товара из заголовка ETag"
 // @Success			200 {object} object{id=int}
+// @Failure			400 {object} object{error=string} "Некорректный заголовок If-Match"
 // @Failure			401 {object} object{error=string} "Пользователь не авторизован"
+// @Failure			403 {object} object{error=string} "Недостаточно прав"
 // @Failure			404 {object} object{error=string} "Товар не найден"
+// @Failure			412 {object} object{error=string} "Товар был изменён"
 // @Failure			500 {object} object{error=string} "Неизвестная ошибка"
 // @Tags			Товар
 // @Router /product/{id} [delete]
@@ -327,10 +1047,65 @@ func (t Transport) Delete(
 		return
 	}
 
+	version, err := transport.IfMatch(r)
+	if err != nil {
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	id, err := t.product.Delete(ctx, productId, version)
+	if err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	transport.Response(w, map[string]any{"id": id})
+}
+
+// Restore godoc
+// @Summary			Восстановить товар
+// @Description		Восстановление удалённого товара вместе с его привязками к категориям
+// @Security		Bearer
+// @Accept			json
+// @Produce			json
+// @Param			id path int true "Идентификатор товара"
+// @Success			200 {object} object{id=int}
+// @Failure			401 {object} object{error=string} "Пользователь не авторизован"
+// @Failure			403 {object} object{error=string} "Недостаточно прав"
+// @Failure			404 {object} object{error=string} "Удалённый товар не найден"
+// @Failure			409 {object} object{error=string} "Товар с таким названием или артикулом уже существует"
+// @Failure			500 {object} object{error=string} "Неизвестная ошибка"
+// @Tags			Товар
+// @Router /product/{id}/restore [post]
+func (t Transport) Restore(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+
+	vars := mux.Vars(r)
+
+	productId, err := transport.StringToInt(vars["id"])
+	if err != nil || productId <= 0 {
+		transport.Error(w, http.StatusBadRequest, "invalid product id")
+
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
-	id, err := t.product.Delete(ctx, productId)
+	id, err := t.product.Restore(ctx, productId)
 	if err != nil {
 		t.logger.Warn(err)
 
@@ -343,3 +1118,36 @@ func (t Transport) Delete(
 
 	transport.Response(w, map[string]any{"id": id})
 }
+
+func (t Transport) includeDeleted(
+	r *http.Request,
+) bool {
+
+	return r.URL.Query().Get("include_deleted") == "true" &&
+		principal.HasRole(r.Context(), dto.RoleAdmin)
+}
+
+func (t Transport) cursorResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	page dto.ProductPage,
+	limit int,
+	position cursor.Position,
+) {
+
+	nextCursor, err := t.cursor.NextPosition(len(page.Items), limit, position)
+	if err != nil {
+		t.logger.Warn(err)
+
+		code, msg := transport.ErrorToHttpResponse(err)
+
+		transport.Error(w, code, msg)
+
+		return
+	}
+
+	t.cache.PageResponse(
+		w, r,
+		transport.CursorPage(r, page.Items, page.Total, limit, nextCursor),
+	)
+}
@@ -40,6 +40,35 @@ func (m *MockproductUseCase) EXPECT() *MockproductUseCaseMockRecorder {
 	return m.recorder
 }
 
+// AttachToCategory mocks base method.
+func (m *MockproductUseCase) AttachToCategory(arg0 context.Context, arg1, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachToCategory", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachToCategory indicates an expected call of AttachToCategory.
+func (mr *MockproductUseCaseMockRecorder) AttachToCategory(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachToCategory", reflect.TypeOf((*MockproductUseCase)(nil).AttachToCategory), arg0, arg1, arg2)
+}
+
+// Batch mocks base method.
+func (m *MockproductUseCase) Batch(arg0 context.Context, arg1 dto.BatchProducts) (dto.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Batch", arg0, arg1)
+	ret0, _ := ret[0].(dto.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Batch indicates an expected call of Batch.
+func (mr *MockproductUseCaseMockRecorder) Batch(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Batch", reflect.TypeOf((*MockproductUseCase)(nil).Batch), arg0, arg1)
+}
+
 // Create mocks base method.
 func (m *MockproductUseCase) Create(arg0 context.Context, arg1 dto.CreateProduct) (int, error) {
 	m.ctrl.T.Helper()
@@ -56,25 +85,68 @@ func (mr *MockproductUseCaseMockRecorder) Create(arg0, arg1 any) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockproductUseCase) Delete(arg0 context.Context, arg1 int) (int, error) {
+func (m *MockproductUseCase) Delete(arg0 context.Context, arg1, arg2 int) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockproductUseCaseMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+func (mr *MockproductUseCaseMockRecorder) Delete(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockproductUseCase)(nil).Delete), arg0, arg1, arg2)
+}
+
+// DetachFromCategory mocks base method.
+func (m *MockproductUseCase) DetachFromCategory(arg0 context.Context, arg1, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachFromCategory", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachFromCategory indicates an expected call of DetachFromCategory.
+func (mr *MockproductUseCaseMockRecorder) DetachFromCategory(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachFromCategory", reflect.TypeOf((*MockproductUseCase)(nil).DetachFromCategory), arg0, arg1, arg2)
+}
+
+// Export mocks base method.
+func (m *MockproductUseCase) Export(arg0 context.Context, arg1 dto.GetProduct, arg2 func(dto.ExportProduct) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockproductUseCaseMockRecorder) Export(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockproductUseCase)(nil).Export), arg0, arg1, arg2)
+}
+
+// ExportAsync mocks base method.
+func (m *MockproductUseCase) ExportAsync(arg0 context.Context, arg1 dto.ExportProducts) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportAsync", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportAsync indicates an expected call of ExportAsync.
+func (mr *MockproductUseCaseMockRecorder) ExportAsync(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockproductUseCase)(nil).Delete), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportAsync", reflect.TypeOf((*MockproductUseCase)(nil).ExportAsync), arg0, arg1)
 }
 
 // Get mocks base method.
-func (m *MockproductUseCase) Get(arg0 context.Context, arg1 dto.GetProduct) ([]dto.Product, error) {
+func (m *MockproductUseCase) Get(arg0 context.Context, arg1 dto.GetProduct) (dto.ProductPage, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Get", arg0, arg1)
-	ret0, _ := ret[0].([]dto.Product)
+	ret0, _ := ret[0].(dto.ProductPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -85,19 +157,94 @@ func (mr *MockproductUseCaseMockRecorder) Get(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockproductUseCase)(nil).Get), arg0, arg1)
 }
 
-// GetByCategoryId mocks base method.
-func (m *MockproductUseCase) GetByCategoryId(arg0 context.Context, arg1 dto.GetProduct, arg2 int) ([]dto.Product, error) {
+// GetById mocks base method.
+func (m *MockproductUseCase) GetById(arg0 context.Context, arg1 int) (dto.ProductWithCategories, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByCategoryId", arg0, arg1, arg2)
-	ret0, _ := ret[0].([]dto.Product)
+	ret := m.ctrl.Call(m, "GetById", arg0, arg1)
+	ret0, _ := ret[0].(dto.ProductWithCategories)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByCategoryId indicates an expected call of GetByCategoryId.
-func (mr *MockproductUseCaseMockRecorder) GetByCategoryId(arg0, arg1, arg2 any) *gomock.Call {
+// GetById indicates an expected call of GetById.
+func (mr *MockproductUseCaseMockRecorder) GetById(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCategoryId", reflect.TypeOf((*MockproductUseCase)(nil).GetByCategoryId), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockproductUseCase)(nil).GetById), arg0, arg1)
+}
+
+// GetCategories mocks base method.
+func (m *MockproductUseCase) GetCategories(arg0 context.Context, arg1 int) ([]dto.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategories", arg0, arg1)
+	ret0, _ := ret[0].([]dto.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategories indicates an expected call of GetCategories.
+func (mr *MockproductUseCaseMockRecorder) GetCategories(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategories", reflect.TypeOf((*MockproductUseCase)(nil).GetCategories), arg0, arg1)
+}
+
+// Import mocks base method.
+func (m *MockproductUseCase) Import(arg0 context.Context, arg1 dto.ImportProducts) (dto.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", arg0, arg1)
+	ret0, _ := ret[0].(dto.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockproductUseCaseMockRecorder) Import(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockproductUseCase)(nil).Import), arg0, arg1)
+}
+
+// ImportAsync mocks base method.
+func (m *MockproductUseCase) ImportAsync(arg0 context.Context, arg1 dto.ImportProducts) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportAsync", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportAsync indicates an expected call of ImportAsync.
+func (mr *MockproductUseCaseMockRecorder) ImportAsync(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportAsync", reflect.TypeOf((*MockproductUseCase)(nil).ImportAsync), arg0, arg1)
+}
+
+// Restore mocks base method.
+func (m *MockproductUseCase) Restore(arg0 context.Context, arg1 int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockproductUseCaseMockRecorder) Restore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockproductUseCase)(nil).Restore), arg0, arg1)
+}
+
+// Search mocks base method.
+func (m *MockproductUseCase) Search(arg0 context.Context, arg1 dto.SearchProduct) ([]dto.FoundProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0, arg1)
+	ret0, _ := ret[0].([]dto.FoundProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockproductUseCaseMockRecorder) Search(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockproductUseCase)(nil).Search), arg0, arg1)
 }
 
 // Update mocks base method.
@@ -138,16 +285,17 @@ func (m *MockuseCaseAccessToken) EXPECT() *MockuseCaseAccessTokenMockRecorder {
 	return m.recorder
 }
 
-// Verify mocks base method.
-func (m *MockuseCaseAccessToken) Verify(arg0 context.Context, arg1 string) error {
+// Parse mocks base method.
+func (m *MockuseCaseAccessToken) Parse(arg0 context.Context, arg1 string) (dto.AccessToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Verify", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "Parse", arg0, arg1)
+	ret0, _ := ret[0].(dto.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Verify indicates an expected call of Verify.
-func (mr *MockuseCaseAccessTokenMockRecorder) Verify(arg0, arg1 any) *gomock.Call {
+// Parse indicates an expected call of Parse.
+func (mr *MockuseCaseAccessTokenMockRecorder) Parse(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockuseCaseAccessToken)(nil).Verify), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockuseCaseAccessToken)(nil).Parse), arg0, arg1)
 }
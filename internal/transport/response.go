@@ -2,9 +2,14 @@ package transport
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 )
 
+const (
+	BasePath = "/api/v1"
+)
+
 func Response(
 	w http.ResponseWriter,
 	data interface{},
@@ -38,3 +43,17 @@ func Error(
 		)
 	}
 }
+
+// JobAccepted сообщает о поставленной в очередь задаче,
+// её состояние доступно по адресу из заголовка Location
+func JobAccepted(
+	w http.ResponseWriter,
+	jobId int,
+) {
+
+	w.Header().Add("Content-Type", "application/json")
+	w.Header().Set("Location", fmt.Sprintf("%s/jobs/%d", BasePath, jobId))
+	w.WriteHeader(http.StatusAccepted)
+
+	json.NewEncoder(w).Encode(map[string]int{"job_id": jobId})
+}
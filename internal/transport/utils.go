@@ -25,6 +25,8 @@ var defaultErrorHttpCodes = map[uint32]int{
 	errors.ErrInvalid.TypeId:       http.StatusBadRequest,
 	errors.ErrExpired.TypeId:       http.StatusUnauthorized,
 	errors.ErrInvalidToken.TypeId:  http.StatusInternalServerError,
+	errors.ErrConflict.TypeId:      http.StatusPreconditionFailed,
+	errors.ErrRevoked.TypeId:       http.StatusUnauthorized,
 }
 
 func ErrorToHttpResponse(
@@ -0,0 +1,117 @@
+package validator
+
+import (
+	"fmt"
+	"github.com/jackvonhouse/product-catalog/internal/errors"
+)
+
+const (
+	minSkuLen   = 3
+	maxSkuLen   = 32
+	currencyLen = 3
+)
+
+func IsValidSku(
+	sku string,
+) error {
+
+	skuLen := len(sku)
+
+	if skuLen > maxSkuLen || skuLen < minSkuLen {
+		return errors.ErrInvalid.New(
+			fmt.Sprintf("sku length must be between %d and %d (actual %d)",
+				minSkuLen, maxSkuLen, skuLen,
+			),
+		)
+	}
+
+	if isHyphen(rune(sku[0])) || isHyphen(rune(sku[skuLen-1])) {
+		return errors.ErrInvalid.New("sku can't start or end with hyphen")
+	}
+
+	for _, symbol := range sku {
+		if !isUpperLatin(symbol) && !isDigit(symbol) && !isHyphen(symbol) {
+			return errors.ErrInvalid.New(
+				"sku must contains only uppercase latin chars, numbers and hyphens",
+			)
+		}
+	}
+
+	return nil
+}
+
+func IsValidCurrency(
+	currency string,
+) error {
+
+	if len(currency) != currencyLen {
+		return errors.ErrInvalid.New(
+			fmt.Sprintf("currency must be %d letters iso 4217 code", currencyLen),
+		)
+	}
+
+	for _, symbol := range currency {
+		if !isUpperLatin(symbol) {
+			return errors.ErrInvalid.New(
+				"currency must contains only uppercase latin chars",
+			)
+		}
+	}
+
+	return nil
+}
+
+func IsValidPrice(
+	price int64,
+) error {
+
+	if price < 0 {
+		return errors.ErrInvalid.New("price can't be negative")
+	}
+
+	return nil
+}
+
+func IsValidStock(
+	stock int,
+) error {
+
+	if stock < 0 {
+		return errors.ErrInvalid.New("stock can't be negative")
+	}
+
+	return nil
+}
+
+func IsValidProduct(
+	sku, currency string,
+	price int64,
+	stock int,
+) error {
+
+	if err := IsValidSku(sku); err != nil {
+		return err
+	}
+
+	if err := IsValidCurrency(currency); err != nil {
+		return err
+	}
+
+	if err := IsValidPrice(price); err != nil {
+		return err
+	}
+
+	return IsValidStock(stock)
+}
+
+func isUpperLatin(r rune) bool {
+	return r >= 'A' && r <= 'Z'
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isHyphen(r rune) bool {
+	return r == '-'
+}
@@ -0,0 +1,23 @@
+package validator
+
+import (
+	"fmt"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/errors"
+	"slices"
+)
+
+var roles = []string{dto.RoleAdmin, dto.RoleEditor, dto.RoleViewer}
+
+func IsValidRole(
+	role string,
+) error {
+
+	if !slices.Contains(roles, role) {
+		return errors.ErrInvalid.New(
+			fmt.Sprintf("role must be one of: admin, editor, viewer (actual %q)", role),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package audit
+
+import (
+	"context"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+)
+
+type auditService interface {
+	Get(context.Context, dto.GetAudit) (dto.AuditPage, error)
+}
+
+type UseCase struct {
+	audit auditService
+
+	logger log.Logger
+}
+
+func New(
+	audit auditService,
+	logger log.Logger,
+) UseCase {
+
+	return UseCase{
+		audit:  audit,
+		logger: logger.WithField("unit", "audit"),
+	}
+}
+
+func (u UseCase) Get(
+	ctx context.Context,
+	data dto.GetAudit,
+) (dto.AuditPage, error) {
+
+	return u.audit.Get(ctx, data)
+}
@@ -3,6 +3,9 @@ package auth
 import (
 	"context"
 	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/errors"
+	"github.com/jackvonhouse/product-catalog/internal/principal"
+	errpkg "github.com/jackvonhouse/product-catalog/pkg/errors"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 )
 
@@ -15,11 +18,15 @@ type serviceAccessToken interface {
 }
 
 type serviceRefreshToken interface {
-	Create(context.Context, dto.User) (int, string, error)
+	Create(context.Context, dto.User, dto.Device) (int, string, error)
+	Rotate(context.Context, dto.User, dto.RefreshToken, dto.Device) (int, string, error)
+	RevokeFamily(context.Context, dto.RefreshToken) error
 
 	GetById(context.Context, int) (dto.RefreshToken, error)
+	GetSessions(context.Context, int) ([]dto.Session, error)
 
 	DeleteByUserId(context.Context, int) error
+	DeleteSession(context.Context, int, int) error
 
 	Verify(string, string) error
 }
@@ -28,14 +35,23 @@ type serviceUser interface {
 	Create(context.Context, dto.Credentials) (int, error)
 
 	GetByUsername(context.Context, string) (dto.User, error)
+	CountByRole(context.Context, string) (int, error)
+	LockRole(context.Context, string) error
+
+	UpdateRole(context.Context, dto.UpdateRole) error
 
 	Verify(context.Context, dto.Credentials) error
 }
 
+type transactor interface {
+	Do(context.Context, func(context.Context) error) error
+}
+
 type UseCase struct {
 	accessToken  serviceAccessToken
 	refreshToken serviceRefreshToken
 	user         serviceUser
+	transaction  transactor
 
 	logger log.Logger
 }
@@ -44,6 +60,7 @@ func New(
 	accessToken serviceAccessToken,
 	refreshToken serviceRefreshToken,
 	user serviceUser,
+	transaction transactor,
 	logger log.Logger,
 ) UseCase {
 
@@ -51,6 +68,7 @@ func New(
 		accessToken:  accessToken,
 		refreshToken: refreshToken,
 		user:         user,
+		transaction:  transaction,
 		logger:       logger.WithField("unit", "user"),
 	}
 }
@@ -58,26 +76,45 @@ func New(
 func (u UseCase) SignUp(
 	ctx context.Context,
 	credentials dto.Credentials,
+	device dto.Device,
 ) (dto.TokenPair, error) {
 
-	id, err := u.user.Create(ctx, credentials)
-	if err != nil {
-		u.logger.Warnf("can't create user: %s", err)
+	credentials.Role = dto.RoleViewer
 
-		return dto.TokenPair{}, err
-	}
+	var tokenPair dto.TokenPair
+
+	// Пользователь без пары токенов не создаётся
+	err := u.transaction.Do(ctx, func(ctx context.Context) error {
+		id, err := u.user.Create(ctx, credentials)
+		if err != nil {
+			u.logger.Warnf("can't create user: %s", err)
+
+			return err
+		}
 
-	incompleteUser := dto.User{
-		ID:       id,
-		Username: credentials.Username,
+		incompleteUser := dto.User{
+			ID:       id,
+			Username: credentials.Username,
+			Role:     credentials.Role,
+		}
+
+		tokenPair, err = u.createTokenPair(ctx, incompleteUser, device)
+
+		return err
+	})
+
+	if err != nil {
+		return dto.TokenPair{}, err
 	}
 
-	return u.createTokenPair(ctx, incompleteUser)
+	return tokenPair, nil
 }
 
+// SignIn открывает новую сессию, не затрагивая сессии на других устройствах
 func (u UseCase) SignIn(
 	ctx context.Context,
 	credentials dto.Credentials,
+	device dto.Device,
 ) (dto.TokenPair, error) {
 
 	if err := u.user.Verify(ctx, credentials); err != nil {
@@ -86,12 +123,20 @@ func (u UseCase) SignIn(
 		return dto.TokenPair{}, err
 	}
 
-	return u.updateTokenPair(ctx, credentials.Username)
+	user, err := u.user.GetByUsername(ctx, credentials.Username)
+	if err != nil {
+		u.logger.Warnf("can't get user: %s", err)
+
+		return dto.TokenPair{}, err
+	}
+
+	return u.createTokenPair(ctx, user, device)
 }
 
 func (u UseCase) Refresh(
 	ctx context.Context,
 	data dto.TokenPair,
+	device dto.Device,
 ) (dto.TokenPair, error) {
 
 	accessToken, err := u.accessToken.Parse(data.AccessToken)
@@ -108,24 +153,194 @@ func (u UseCase) Refresh(
 		return dto.TokenPair{}, err
 	}
 
-	return u.updateTokenPair(ctx, accessToken.Username)
+	user, err := u.user.GetByUsername(ctx, accessToken.Username)
+	if err != nil {
+		u.logger.Warnf("can't get user: %s", err)
+
+		return dto.TokenPair{}, err
+	}
+
+	var tokenPair dto.TokenPair
+
+	// Старый токен расходуется только вместе с выпуском новой пары: иначе
+	// повтор запроса после ошибки был бы принят за повторное использование
+	err = u.transaction.Do(ctx, func(ctx context.Context) error {
+		refreshTokenId, token, err := u.refreshToken.Rotate(ctx, user, refreshToken, device)
+		if err != nil {
+			return err
+		}
+
+		tokenPair, err = u.tokenPair(ctx, user, refreshTokenId, token)
+
+		return err
+	})
+
+	if errpkg.Has(err, errors.ErrRevoked) {
+		// Отзыв семейства выполняется после отката ротации и должен
+		// сохраниться, хотя запрос завершится ошибкой
+		return dto.TokenPair{}, u.refreshToken.RevokeFamily(ctx, refreshToken)
+	}
+
+	if err != nil {
+		u.logger.Warnf("can't rotate refresh token: %s", err)
+
+		return dto.TokenPair{}, err
+	}
+
+	return tokenPair, nil
+}
+
+// GetSessions возвращает сессии текущего пользователя и отмечает ту,
+// в которой выполнен запрос
+func (u UseCase) GetSessions(
+	ctx context.Context,
+) ([]dto.Session, error) {
+
+	p, _ := principal.FromContext(ctx)
+
+	sessions, err := u.refreshToken.GetSessions(ctx, p.UserId)
+	if err != nil {
+		u.logger.Warnf("can't get sessions: %s", err)
+
+		return nil, err
+	}
+
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == p.RefreshTokenId
+	}
+
+	return sessions, nil
+}
+
+func (u UseCase) DeleteSession(
+	ctx context.Context,
+	id int,
+) error {
+
+	if err := u.refreshToken.DeleteSession(ctx, principal.UserId(ctx), id); err != nil {
+		u.logger.Warnf("can't delete session: %s", err)
+
+		return err
+	}
+
+	return nil
+}
+
+// SignOut завершает сессию, в которой выполнен запрос
+func (u UseCase) SignOut(
+	ctx context.Context,
+) error {
+
+	p, _ := principal.FromContext(ctx)
+
+	return u.DeleteSession(ctx, p.RefreshTokenId)
+}
+
+// SignOutAll завершает все сессии текущего пользователя
+func (u UseCase) SignOutAll(
+	ctx context.Context,
+) error {
+
+	if err := u.refreshToken.DeleteByUserId(ctx, principal.UserId(ctx)); err != nil {
+		u.logger.Warnf("can't delete sessions: %s", err)
+
+		return err
+	}
+
+	return nil
+}
+
+func (u UseCase) UpdateRole(
+	ctx context.Context,
+	data dto.UpdateRole,
+) error {
+
+	if err := u.user.UpdateRole(ctx, data); err != nil {
+		u.logger.Warnf("can't update user role: %s", err)
+
+		return err
+	}
+
+	return nil
+}
+
+func (u UseCase) CreateAdmin(
+	ctx context.Context,
+	credentials dto.Credentials,
+) (int, error) {
+
+	credentials.Role = dto.RoleAdmin
+
+	var adminId int
+
+	// Без блокировки два параллельных вызова оба не найдут администратора
+	err := u.transaction.Do(ctx, func(ctx context.Context) error {
+		if err := u.user.LockRole(ctx, dto.RoleAdmin); err != nil {
+			u.logger.Warnf("can't lock admin role: %s", err)
+
+			return err
+		}
+
+		admins, err := u.user.CountByRole(ctx, dto.RoleAdmin)
+		if err != nil {
+			u.logger.Warnf("can't count admins: %s", err)
+
+			return err
+		}
+
+		if admins > 0 {
+			u.logger.Warn("admin already exists")
+
+			return errors.ErrAlreadyExists.New("admin already exists")
+		}
+
+		id, err := u.user.Create(ctx, credentials)
+		if err != nil {
+			u.logger.Warnf("can't create admin: %s", err)
+
+			return err
+		}
+
+		adminId = id
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return adminId, nil
 }
 
 func (u UseCase) createTokenPair(
 	ctx context.Context,
 	user dto.User,
+	device dto.Device,
 ) (dto.TokenPair, error) {
 
-	refreshTokenId, refreshToken, err := u.refreshToken.Create(ctx, user)
+	refreshTokenId, refreshToken, err := u.refreshToken.Create(ctx, user, device)
 	if err != nil {
 		u.logger.Warnf("can't create refresh token: %s", err)
 
 		return dto.TokenPair{}, err
 	}
 
+	return u.tokenPair(ctx, user, refreshTokenId, refreshToken)
+}
+
+func (u UseCase) tokenPair(
+	ctx context.Context,
+	user dto.User,
+	refreshTokenId int,
+	refreshToken string,
+) (dto.TokenPair, error) {
+
 	access := dto.AccessToken{
+		UserId:         user.ID,
 		Username:       user.Username,
 		RefreshTokenId: refreshTokenId,
+		Role:           user.Role,
 	}
 
 	accessToken, err := u.accessToken.Create(ctx, access)
@@ -140,24 +355,3 @@ func (u UseCase) createTokenPair(
 		RefreshToken: refreshToken,
 	}, nil
 }
-
-func (u UseCase) updateTokenPair(
-	ctx context.Context,
-	username string,
-) (dto.TokenPair, error) {
-
-	user, err := u.user.GetByUsername(ctx, username)
-	if err != nil {
-		u.logger.Warnf("can't get user: %s", err)
-
-		return dto.TokenPair{}, err
-	}
-
-	if err := u.refreshToken.DeleteByUserId(ctx, user.ID); err != nil {
-		u.logger.Warnf("can't delete old refresh token: %s", err)
-
-		return dto.TokenPair{}, err
-	}
-
-	return u.createTokenPair(ctx, user)
-}
@@ -2,34 +2,52 @@ package category
 
 import (
 	"context"
+	"github.com/jackvonhouse/product-catalog/internal/audit"
 	"github.com/jackvonhouse/product-catalog/internal/dto"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"time"
 )
 
 type categoryService interface {
 	Create(context.Context, dto.CreateCategory) (int, error)
 
-	Get(context.Context, dto.GetCategory) ([]dto.Category, error)
+	Get(context.Context, dto.GetCategory) (dto.CategoryPage, error)
+	GetById(context.Context, int) (dto.Category, error)
+	GetChildren(context.Context, dto.Category) ([]dto.Category, error)
+	GetAncestors(context.Context, dto.Category) ([]dto.Category, error)
+	GetTree(context.Context) ([]dto.CategoryTree, error)
 
 	Update(context.Context, dto.UpdateCategory) (int, error)
 
-	Delete(context.Context, int) (int, error)
+	Delete(context.Context, int, int) (int, error)
+	Restore(context.Context, int) (int, error)
+	Purge(context.Context, time.Time) (int64, error)
+}
+
+type transactor interface {
+	Do(context.Context, func(context.Context) error) error
 }
 
 type UseCase struct {
-	category categoryService
+	category    categoryService
+	transaction transactor
+	audit       audit.Recorder
 
 	logger log.Logger
 }
 
 func New(
 	category categoryService,
+	transaction transactor,
+	audit audit.Recorder,
 	logger log.Logger,
 ) UseCase {
 
 	return UseCase{
-		category: category,
-		logger:   logger.WithField("unit", "category"),
+		category:    category,
+		transaction: transaction,
+		audit:       audit,
+		logger:      logger.WithField("unit", "category"),
 	}
 }
 
@@ -38,29 +56,117 @@ func (u UseCase) Create(
 	data dto.CreateCategory,
 ) (int, error) {
 
+	ctx = u.audit.Record(ctx, audit.ActionCreate, audit.EntityCategory)
+
 	return u.category.Create(ctx, data)
 }
 
 func (u UseCase) Get(
 	ctx context.Context,
 	data dto.GetCategory,
-) ([]dto.Category, error) {
+) (dto.CategoryPage, error) {
 
 	return u.category.Get(ctx, data)
 }
 
+func (u UseCase) GetById(
+	ctx context.Context,
+	id int,
+) (dto.Category, error) {
+
+	return u.category.GetById(ctx, id)
+}
+
+func (u UseCase) GetChildren(
+	ctx context.Context,
+	id int,
+) ([]dto.Category, error) {
+
+	category, err := u.category.GetById(ctx, id)
+	if err != nil {
+		u.logger.Warnf("category not found: %s", err)
+
+		return []dto.Category{}, err
+	}
+
+	return u.category.GetChildren(ctx, category)
+}
+
+func (u UseCase) GetAncestors(
+	ctx context.Context,
+	id int,
+) ([]dto.Category, error) {
+
+	category, err := u.category.GetById(ctx, id)
+	if err != nil {
+		u.logger.Warnf("category not found: %s", err)
+
+		return []dto.Category{}, err
+	}
+
+	return u.category.GetAncestors(ctx, category)
+}
+
+func (u UseCase) GetTree(
+	ctx context.Context,
+) ([]dto.CategoryTree, error) {
+
+	return u.category.GetTree(ctx)
+}
+
 func (u UseCase) Update(
 	ctx context.Context,
 	data dto.UpdateCategory,
 ) (int, error) {
 
-	return u.category.Update(ctx, data)
+	ctx = u.audit.Record(ctx, audit.ActionUpdate, audit.EntityCategory)
+
+	var categoryId int
+
+	// Проверка на цикл и смена родителя выполняются в одной транзакции
+	err := u.transaction.Do(ctx, func(ctx context.Context) error {
+		id, err := u.category.Update(ctx, data)
+		if err != nil {
+			return err
+		}
+
+		categoryId = id
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return categoryId, nil
 }
 
 func (u UseCase) Delete(
 	ctx context.Context,
 	id int,
+	version int,
 ) (int, error) {
 
-	return u.category.Delete(ctx, id)
+	ctx = u.audit.Record(ctx, audit.ActionDelete, audit.EntityCategory)
+
+	return u.category.Delete(ctx, id, version)
+}
+
+func (u UseCase) Restore(
+	ctx context.Context,
+	id int,
+) (int, error) {
+
+	ctx = u.audit.Record(ctx, audit.ActionRestore, audit.EntityCategory)
+
+	return u.category.Restore(ctx, id)
+}
+
+func (u UseCase) Purge(
+	ctx context.Context,
+	deletedBefore time.Time,
+) (int64, error) {
+
+	return u.category.Purge(ctx, deletedBefore)
 }
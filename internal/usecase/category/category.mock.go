@@ -12,6 +12,7 @@ package category
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	dto "github.com/jackvonhouse/product-catalog/internal/dto"
 	gomock "go.uber.org/mock/gomock"
@@ -56,25 +57,25 @@ func (mr *MockcategoryServiceMockRecorder) Create(arg0, arg1 any) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockcategoryService) Delete(arg0 context.Context, arg1 int) (int, error) {
+func (m *MockcategoryService) Delete(arg0 context.Context, arg1, arg2 int) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockcategoryServiceMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+func (mr *MockcategoryServiceMockRecorder) Delete(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockcategoryService)(nil).Delete), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockcategoryService)(nil).Delete), arg0, arg1, arg2)
 }
 
 // Get mocks base method.
-func (m *MockcategoryService) Get(arg0 context.Context, arg1 dto.GetCategory) ([]dto.Category, error) {
+func (m *MockcategoryService) Get(arg0 context.Context, arg1 dto.GetCategory) (dto.CategoryPage, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Get", arg0, arg1)
-	ret0, _ := ret[0].([]dto.Category)
+	ret0, _ := ret[0].(dto.CategoryPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -85,6 +86,96 @@ func (mr *MockcategoryServiceMockRecorder) Get(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockcategoryService)(nil).Get), arg0, arg1)
 }
 
+// GetAncestors mocks base method.
+func (m *MockcategoryService) GetAncestors(arg0 context.Context, arg1 dto.Category) ([]dto.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAncestors", arg0, arg1)
+	ret0, _ := ret[0].([]dto.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAncestors indicates an expected call of GetAncestors.
+func (mr *MockcategoryServiceMockRecorder) GetAncestors(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAncestors", reflect.TypeOf((*MockcategoryService)(nil).GetAncestors), arg0, arg1)
+}
+
+// GetById mocks base method.
+func (m *MockcategoryService) GetById(arg0 context.Context, arg1 int) (dto.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", arg0, arg1)
+	ret0, _ := ret[0].(dto.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockcategoryServiceMockRecorder) GetById(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockcategoryService)(nil).GetById), arg0, arg1)
+}
+
+// GetChildren mocks base method.
+func (m *MockcategoryService) GetChildren(arg0 context.Context, arg1 dto.Category) ([]dto.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChildren", arg0, arg1)
+	ret0, _ := ret[0].([]dto.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChildren indicates an expected call of GetChildren.
+func (mr *MockcategoryServiceMockRecorder) GetChildren(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChildren", reflect.TypeOf((*MockcategoryService)(nil).GetChildren), arg0, arg1)
+}
+
+// GetTree mocks base method.
+func (m *MockcategoryService) GetTree(arg0 context.Context) ([]dto.CategoryTree, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTree", arg0)
+	ret0, _ := ret[0].([]dto.CategoryTree)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTree indicates an expected call of GetTree.
+func (mr *MockcategoryServiceMockRecorder) GetTree(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTree", reflect.TypeOf((*MockcategoryService)(nil).GetTree), arg0)
+}
+
+// Purge mocks base method.
+func (m *MockcategoryService) Purge(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockcategoryServiceMockRecorder) Purge(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockcategoryService)(nil).Purge), arg0, arg1)
+}
+
+// Restore mocks base method.
+func (m *MockcategoryService) Restore(arg0 context.Context, arg1 int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockcategoryServiceMockRecorder) Restore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockcategoryService)(nil).Restore), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockcategoryService) Update(arg0 context.Context, arg1 dto.UpdateCategory) (int, error) {
 	m.ctrl.T.Helper()
@@ -99,3 +190,40 @@ func (mr *MockcategoryServiceMockRecorder) Update(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockcategoryService)(nil).Update), arg0, arg1)
 }
+
+// Mocktransactor is a mock of transactor interface.
+type Mocktransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactorMockRecorder
+}
+
+// MocktransactorMockRecorder is the mock recorder for Mocktransactor.
+type MocktransactorMockRecorder struct {
+	mock *Mocktransactor
+}
+
+// NewMocktransactor creates a new mock instance.
+func NewMocktransactor(ctrl *gomock.Controller) *Mocktransactor {
+	mock := &Mocktransactor{ctrl: ctrl}
+	mock.recorder = &MocktransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocktransactor) EXPECT() *MocktransactorMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *Mocktransactor) Do(arg0 context.Context, arg1 func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Do indicates an expected call of Do.
+func (mr *MocktransactorMockRecorder) Do(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*Mocktransactor)(nil).Do), arg0, arg1)
+}
@@ -2,7 +2,9 @@ package category
 
 import (
 	"context"
+	"github.com/jackvonhouse/product-catalog/internal/audit"
 	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/errors"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
@@ -27,7 +29,8 @@ type CategoryTestSuite struct {
 	categories []dto.Category
 
 	// Служебные параметры
-	categoryMock *MockcategoryService
+	categoryMock    *MockcategoryService
+	transactionMock *Mocktransactor
 }
 
 func TestSuiteCreate(t *testing.T) {
@@ -57,12 +60,13 @@ func (s *CategoryTestSuite) setupMock(
 ) *CategoryTestSuite {
 
 	s.categoryMock = NewMockcategoryService(controller)
+	s.transactionMock = NewMocktransactor(controller)
 
 	return s
 }
 
 func (s *CategoryTestSuite) setupUseCase() *CategoryTestSuite {
-	s.useCase = New(s.categoryMock, s.logger)
+	s.useCase = New(s.categoryMock, s.transactionMock, audit.New(s.logger), s.logger)
 
 	return s
 }
@@ -132,7 +136,7 @@ func (s *CategoryTestSuite) setupCategory(
 func (s *CategoryTestSuite) TestCreateSuccessful() {
 	s.categoryMock.
 		EXPECT().
-		Create(s.ctx, s.create).
+		Create(gomock.Any(), s.create).
 		Return(s.category.ID, nil).
 		Times(1)
 
@@ -146,19 +150,25 @@ func (s *CategoryTestSuite) TestGetSuccessful() {
 	s.categoryMock.
 		EXPECT().
 		Get(s.ctx, s.get).
-		Return(s.categories, nil).
+		Return(dto.CategoryPage{Items: s.categories, Total: 1}, nil).
 		Times(1)
 
 	categories, err := s.useCase.Get(s.ctx, s.get)
 
 	s.NoError(err)
-	s.Equal(s.categories, categories)
+	s.Equal(dto.CategoryPage{Items: s.categories, Total: 1}, categories)
 }
 
 func (s *CategoryTestSuite) TestUpdateSuccessful() {
+	s.transactionMock.
+		EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(s.runTransaction).
+		Times(1)
+
 	s.categoryMock.
 		EXPECT().
-		Update(s.ctx, s.update).
+		Update(gomock.Any(), s.update).
 		Return(s.category.ID, nil).
 		Times(1)
 
@@ -168,15 +178,106 @@ func (s *CategoryTestSuite) TestUpdateSuccessful() {
 	s.Equal(1, categoryId)
 }
 
+func (s *CategoryTestSuite) TestUpdateFailed() {
+	const (
+		expectedErrorMsg = "category can't be descendant of itself"
+	)
+
+	s.transactionMock.
+		EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(s.runTransaction).
+		Times(1)
+
+	s.categoryMock.
+		EXPECT().
+		Update(gomock.Any(), s.update).
+		Return(0, errors.ErrInvalid.New(expectedErrorMsg)).
+		Times(1)
+
+	categoryId, err := s.useCase.Update(s.ctx, s.update)
+
+	s.EqualError(err, expectedErrorMsg)
+	s.Equal(0, categoryId)
+}
+
 func (s *CategoryTestSuite) TestDeleteSuccessful() {
 	s.categoryMock.
 		EXPECT().
-		Delete(s.ctx, s.category.ID).
+		Delete(gomock.Any(), s.category.ID, 0).
 		Return(s.category.ID, nil).
 		Times(1)
 
-	categoryId, err := s.useCase.Delete(s.ctx, s.category.ID)
+	categoryId, err := s.useCase.Delete(s.ctx, s.category.ID, 0)
 
 	s.NoError(err)
 	s.Equal(1, categoryId)
 }
+
+func (s *CategoryTestSuite) TestGetChildrenSuccessful() {
+	s.categoryMock.
+		EXPECT().
+		GetById(s.ctx, s.category.ID).
+		Return(s.category, nil).
+		Times(1)
+
+	s.categoryMock.
+		EXPECT().
+		GetChildren(s.ctx, s.category).
+		Return(s.categories, nil).
+		Times(1)
+
+	categories, err := s.useCase.GetChildren(s.ctx, s.category.ID)
+
+	s.NoError(err)
+	s.Equal(s.categories, categories)
+}
+
+func (s *CategoryTestSuite) TestGetAncestorsSuccessful() {
+	s.categoryMock.
+		EXPECT().
+		GetById(s.ctx, s.category.ID).
+		Return(s.category, nil).
+		Times(1)
+
+	s.categoryMock.
+		EXPECT().
+		GetAncestors(s.ctx, s.category).
+		Return(s.categories, nil).
+		Times(1)
+
+	categories, err := s.useCase.GetAncestors(s.ctx, s.category.ID)
+
+	s.NoError(err)
+	s.Equal(s.categories, categories)
+}
+
+func (s *CategoryTestSuite) TestGetAncestorsFailure() {
+	const (
+		expectedNotFoundErrorMsg = "category not found"
+	)
+
+	var (
+		expectedError = errors.ErrNotFound.New(expectedNotFoundErrorMsg)
+	)
+
+	s.categoryMock.
+		EXPECT().
+		GetById(s.ctx, s.category.ID).
+		Return(dto.Category{}, expectedError).
+		Times(1)
+
+	categories, err := s.useCase.GetAncestors(s.ctx, s.category.ID)
+
+	s.NotNil(err)
+	s.Equal(expectedError.Error(), err.Error())
+	s.Equal([]dto.Category{}, categories)
+}
+
+func (s *CategoryTestSuite) runTransaction(
+	ctx context.Context,
+	fn func(context.Context) error,
+) error {
+
+	return fn(ctx)
+}
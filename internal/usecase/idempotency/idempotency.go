@@ -0,0 +1,65 @@
+package idempotency
+
+import (
+	"context"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"time"
+)
+
+type idempotencyService interface {
+	Begin(context.Context, dto.IdempotencyKey) (dto.IdempotencyKey, bool, error)
+	Complete(context.Context, dto.IdempotencyKey) error
+	Release(context.Context, dto.IdempotencyKey) error
+
+	Purge(context.Context, time.Time) (int64, error)
+}
+
+type UseCase struct {
+	idempotency idempotencyService
+
+	logger log.Logger
+}
+
+func New(
+	idempotency idempotencyService,
+	logger log.Logger,
+) UseCase {
+
+	return UseCase{
+		idempotency: idempotency,
+		logger:      logger.WithField("unit", "idempotency_key"),
+	}
+}
+
+func (u UseCase) Begin(
+	ctx context.Context,
+	data dto.IdempotencyKey,
+) (dto.IdempotencyKey, bool, error) {
+
+	return u.idempotency.Begin(ctx, data)
+}
+
+func (u UseCase) Complete(
+	ctx context.Context,
+	data dto.IdempotencyKey,
+) error {
+
+	return u.idempotency.Complete(ctx, data)
+}
+
+func (u UseCase) Release(
+	ctx context.Context,
+	data dto.IdempotencyKey,
+) error {
+
+	return u.idempotency.Release(ctx, data)
+}
+
+func (u UseCase) Purge(
+	ctx context.Context,
+	expiredBefore time.Time,
+) (int64, error) {
+
+	return u.idempotency.Purge(ctx, expiredBefore)
+}
@@ -0,0 +1,143 @@
+package job
+
+import (
+	"context"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/errors"
+	"github.com/jackvonhouse/product-catalog/internal/principal"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"time"
+)
+
+type jobService interface {
+	GetById(context.Context, int) (dto.Job, error)
+	GetFile(context.Context, int) (dto.JobFile, error)
+
+	Dequeue(context.Context, time.Time, int) (dto.Job, error)
+	Heartbeat(context.Context, int, int, int) (bool, error)
+	Finish(context.Context, dto.FinishJob) error
+	Requeue(context.Context, int, int) error
+	FailExhausted(context.Context, time.Time, int) (int64, error)
+
+	Cancel(context.Context, int) (dto.Job, error)
+	Purge(context.Context, time.Time) (int64, error)
+}
+
+type UseCase struct {
+	job jobService
+
+	logger log.Logger
+}
+
+func New(
+	job jobService,
+	logger log.Logger,
+) UseCase {
+
+	return UseCase{
+		job:    job,
+		logger: logger.WithField("unit", "job"),
+	}
+}
+
+// GetById отдаёт задачу её автору или администратору,
+// для остальных задача не существует
+func (u UseCase) GetById(
+	ctx context.Context,
+	id int,
+) (dto.Job, error) {
+
+	job, err := u.job.GetById(ctx, id)
+	if err != nil {
+		return dto.Job{}, err
+	}
+
+	if principal.HasRole(ctx, dto.RoleAdmin) {
+		return job, nil
+	}
+
+	if job.ActorId == nil || *job.ActorId != principal.UserId(ctx) {
+		u.logger.Warnf("access to job %d of another user", id)
+
+		return dto.Job{}, errors.ErrNotFound.New("job not found")
+	}
+
+	return job, nil
+}
+
+func (u UseCase) GetFile(
+	ctx context.Context,
+	id int,
+) (dto.JobFile, error) {
+
+	if _, err := u.GetById(ctx, id); err != nil {
+		return dto.JobFile{}, err
+	}
+
+	return u.job.GetFile(ctx, id)
+}
+
+func (u UseCase) Cancel(
+	ctx context.Context,
+	id int,
+) (dto.Job, error) {
+
+	if _, err := u.GetById(ctx, id); err != nil {
+		return dto.Job{}, err
+	}
+
+	return u.job.Cancel(ctx, id)
+}
+
+func (u UseCase) Dequeue(
+	ctx context.Context,
+	staleBefore time.Time,
+	maxAttempts int,
+) (dto.Job, error) {
+
+	return u.job.Dequeue(ctx, staleBefore, maxAttempts)
+}
+
+func (u UseCase) Heartbeat(
+	ctx context.Context,
+	id int,
+	attempt int,
+	progress int,
+) (bool, error) {
+
+	return u.job.Heartbeat(ctx, id, attempt, progress)
+}
+
+func (u UseCase) Finish(
+	ctx context.Context,
+	data dto.FinishJob,
+) error {
+
+	return u.job.Finish(ctx, data)
+}
+
+func (u UseCase) Requeue(
+	ctx context.Context,
+	id int,
+	attempt int,
+) error {
+
+	return u.job.Requeue(ctx, id, attempt)
+}
+
+func (u UseCase) FailExhausted(
+	ctx context.Context,
+	staleBefore time.Time,
+	maxAttempts int,
+) (int64, error) {
+
+	return u.job.FailExhausted(ctx, staleBefore, maxAttempts)
+}
+
+func (u UseCase) Purge(
+	ctx context.Context,
+	finishedBefore time.Time,
+) (int64, error) {
+
+	return u.job.Purge(ctx, finishedBefore)
+}
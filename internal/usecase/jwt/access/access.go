@@ -2,33 +2,108 @@ package access
 
 import (
 	"context"
+	"github.com/jackvonhouse/product-catalog/config"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/errors"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"time"
 )
 
 type serviceAccessToken interface {
-	Verify(string) error
+	Parse(string) (dto.AccessToken, error)
+}
+
+type serviceRefreshToken interface {
+	Exists(context.Context, int) (bool, error)
 }
 
 type UseCase struct {
-	accessToken serviceAccessToken
-	logger      log.Logger
+	accessToken  serviceAccessToken
+	refreshToken serviceRefreshToken
+	revocation   *revocation
+
+	logger log.Logger
 }
 
 func New(
 	accessToken serviceAccessToken,
+	refreshToken serviceRefreshToken,
+	config config.JWT,
 	logger log.Logger,
 ) UseCase {
 
-	return UseCase{
-		accessToken: accessToken,
-		logger:      logger.WithField("unit", "access_token"),
+	u := UseCase{
+		accessToken:  accessToken,
+		refreshToken: refreshToken,
+		logger:       logger.WithField("unit", "access_token"),
+	}
+
+	if config.Strict {
+		u.revocation = newRevocation(time.Duration(config.RevocationTTL) * time.Second)
 	}
+
+	return u
 }
 
 func (u UseCase) Verify(
-	_ context.Context,
+	ctx context.Context,
 	token string,
 ) error {
 
-	return u.accessToken.Verify(token)
+	_, err := u.Parse(ctx, token)
+
+	return err
+}
+
+// Parse в строгом режиме дополнительно проверяет, что refresh-токен,
+// с которым выдан access-токен, не отозван выходом или ротацией семейства
+func (u UseCase) Parse(
+	ctx context.Context,
+	token string,
+) (dto.AccessToken, error) {
+
+	accessToken, err := u.accessToken.Parse(token)
+	if err != nil {
+		return dto.AccessToken{}, err
+	}
+
+	if u.revocation == nil {
+		return accessToken, nil
+	}
+
+	if err := u.checkRevoked(ctx, accessToken.RefreshTokenId); err != nil {
+		return dto.AccessToken{}, err
+	}
+
+	return accessToken, nil
+}
+
+func (u UseCase) checkRevoked(
+	ctx context.Context,
+	refreshTokenId int,
+) error {
+
+	exists, ok := u.revocation.get(refreshTokenId)
+	if !ok {
+		var err error
+
+		exists, err = u.refreshToken.Exists(ctx, refreshTokenId)
+		if err != nil {
+			u.logger.Warnf("can't check refresh token: %s", err)
+
+			return err
+		}
+
+		u.revocation.set(refreshTokenId, exists)
+	}
+
+	if !exists {
+		u.logger.Warnf("access token of revoked refresh token %d", refreshTokenId)
+
+		return errors.
+			ErrRevoked.
+			New("access token has been revoked")
+	}
+
+	return nil
 }
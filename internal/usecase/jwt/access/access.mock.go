@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/jwt/access/access.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/jwt/access/access.go -destination=internal/usecase/jwt/access/access.mock.go -package=access
+//
+
+// Package access is a generated GoMock package.
+package access
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/jackvonhouse/product-catalog/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockserviceAccessToken is a mock of serviceAccessToken interface.
+type MockserviceAccessToken struct {
+	ctrl     *gomock.Controller
+	recorder *MockserviceAccessTokenMockRecorder
+}
+
+// MockserviceAccessTokenMockRecorder is the mock recorder for MockserviceAccessToken.
+type MockserviceAccessTokenMockRecorder struct {
+	mock *MockserviceAccessToken
+}
+
+// NewMockserviceAccessToken creates a new mock instance.
+func NewMockserviceAccessToken(ctrl *gomock.Controller) *MockserviceAccessToken {
+	mock := &MockserviceAccessToken{ctrl: ctrl}
+	mock.recorder = &MockserviceAccessTokenMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockserviceAccessToken) EXPECT() *MockserviceAccessTokenMockRecorder {
+	return m.recorder
+}
+
+// Parse mocks base method.
+func (m *MockserviceAccessToken) Parse(arg0 string) (dto.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Parse", arg0)
+	ret0, _ := ret[0].(dto.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Parse indicates an expected call of Parse.
+func (mr *MockserviceAccessTokenMockRecorder) Parse(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockserviceAccessToken)(nil).Parse), arg0)
+}
+
+// MockserviceRefreshToken is a mock of serviceRefreshToken interface.
+type MockserviceRefreshToken struct {
+	ctrl     *gomock.Controller
+	recorder *MockserviceRefreshTokenMockRecorder
+}
+
+// MockserviceRefreshTokenMockRecorder is the mock recorder for MockserviceRefreshToken.
+type MockserviceRefreshTokenMockRecorder struct {
+	mock *MockserviceRefreshToken
+}
+
+// NewMockserviceRefreshToken creates a new mock instance.
+func NewMockserviceRefreshToken(ctrl *gomock.Controller) *MockserviceRefreshToken {
+	mock := &MockserviceRefreshToken{ctrl: ctrl}
+	mock.recorder = &MockserviceRefreshTokenMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockserviceRefreshToken) EXPECT() *MockserviceRefreshTokenMockRecorder {
+	return m.recorder
+}
+
+// Exists mocks base method.
+func (m *MockserviceRefreshToken) Exists(arg0 context.Context, arg1 int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockserviceRefreshTokenMockRecorder) Exists(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockserviceRefreshToken)(nil).Exists), arg0, arg1)
+}
@@ -0,0 +1,193 @@
+package access
+
+import (
+	"context"
+	"github.com/jackvonhouse/product-catalog/config"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/errors"
+	errpkg "github.com/jackvonhouse/product-catalog/pkg/errors"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+type AccessTestSuite struct {
+	suite.Suite
+
+	// Вспомогательные параметры
+	ctx     context.Context
+	logger  log.Logger
+	config  config.JWT
+	useCase UseCase
+
+	// Входные параметры
+	token       string
+	accessToken dto.AccessToken
+
+	// Служебные параметры
+	accessTokenMock  *MockserviceAccessToken
+	refreshTokenMock *MockserviceRefreshToken
+}
+
+func TestSuiteAccess(t *testing.T) {
+	suite.Run(t, &AccessTestSuite{})
+}
+
+func (s *AccessTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.logger = log.NewNullLogger()
+	s.config = config.JWT{
+		Strict:        true,
+		RevocationTTL: 60,
+	}
+}
+
+func (s *AccessTestSuite) BeforeTest(_, _ string) {
+	controller := gomock.NewController(s.T())
+
+	s.setupMock(controller).setupUseCase()
+
+	// Входные значения по-умолчанию
+	s.setupAccessToken("token", 1)
+}
+
+func (s *AccessTestSuite) setupMock(
+	controller *gomock.Controller,
+) *AccessTestSuite {
+
+	s.accessTokenMock = NewMockserviceAccessToken(controller)
+	s.refreshTokenMock = NewMockserviceRefreshToken(controller)
+
+	return s
+}
+
+func (s *AccessTestSuite) setupUseCase() *AccessTestSuite {
+	s.useCase = New(s.accessTokenMock, s.refreshTokenMock, s.config, s.logger)
+
+	return s
+}
+
+func (s *AccessTestSuite) setupAccessToken(
+	token string,
+	refreshTokenId int,
+) {
+
+	s.token = token
+	s.accessToken = dto.AccessToken{
+		UserId:         1,
+		Username:       "user",
+		RefreshTokenId: refreshTokenId,
+		Role:           dto.RoleViewer,
+	}
+}
+
+func (s *AccessTestSuite) TestParseNotStrict() {
+	s.config.Strict = false
+	s.setupUseCase()
+
+	s.accessTokenMock.
+		EXPECT().
+		Parse(s.token).
+		Return(s.accessToken, nil).
+		Times(1)
+
+	accessToken, err := s.useCase.Parse(s.ctx, s.token)
+
+	s.NoError(err)
+	s.Equal(s.accessToken, accessToken)
+}
+
+func (s *AccessTestSuite) TestParseStrictCached() {
+	s.accessTokenMock.
+		EXPECT().
+		Parse(s.token).
+		Return(s.accessToken, nil).
+		Times(2)
+
+	s.refreshTokenMock.
+		EXPECT().
+		Exists(s.ctx, s.accessToken.RefreshTokenId).
+		Return(true, nil).
+		Times(1)
+
+	for range 2 {
+		accessToken, err := s.useCase.Parse(s.ctx, s.token)
+
+		s.NoError(err)
+		s.Equal(s.accessToken, accessToken)
+	}
+}
+
+func (s *AccessTestSuite) TestParseStrictWithoutCache() {
+	s.config.RevocationTTL = 0
+	s.setupUseCase()
+
+	s.accessTokenMock.
+		EXPECT().
+		Parse(s.token).
+		Return(s.accessToken, nil).
+		Times(2)
+
+	s.refreshTokenMock.
+		EXPECT().
+		Exists(s.ctx, s.accessToken.RefreshTokenId).
+		Return(true, nil).
+		Times(2)
+
+	for range 2 {
+		_, err := s.useCase.Parse(s.ctx, s.token)
+
+		s.NoError(err)
+	}
+}
+
+func (s *AccessTestSuite) TestParseStrictRevoked() {
+	const (
+		expectedErrorMsg = "access token has been revoked"
+	)
+
+	s.accessTokenMock.
+		EXPECT().
+		Parse(s.token).
+		Return(s.accessToken, nil).
+		Times(2)
+
+	s.refreshTokenMock.
+		EXPECT().
+		Exists(s.ctx, s.accessToken.RefreshTokenId).
+		Return(false, nil).
+		Times(1)
+
+	for range 2 {
+		_, err := s.useCase.Parse(s.ctx, s.token)
+
+		s.EqualError(err, expectedErrorMsg)
+		s.True(errpkg.Has(err, errors.ErrRevoked))
+	}
+}
+
+func (s *AccessTestSuite) TestParseStrictFailed() {
+	const (
+		expectedErrorMsg = "unknown error on getting refresh token"
+	)
+
+	s.accessTokenMock.
+		EXPECT().
+		Parse(s.token).
+		Return(s.accessToken, nil).
+		Times(2)
+
+	// Ошибка проверки не кэшируется
+	s.refreshTokenMock.
+		EXPECT().
+		Exists(s.ctx, s.accessToken.RefreshTokenId).
+		Return(false, errors.ErrInternal.New(expectedErrorMsg)).
+		Times(2)
+
+	for range 2 {
+		_, err := s.useCase.Parse(s.ctx, s.token)
+
+		s.EqualError(err, expectedErrorMsg)
+	}
+}
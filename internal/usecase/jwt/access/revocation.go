@@ -0,0 +1,58 @@
+package access
+
+import (
+	"github.com/patrickmn/go-cache"
+	"strconv"
+	"time"
+)
+
+// revocation хранит в памяти результаты проверки refresh-токенов, чтобы
+// не обращаться к базе на каждый запрос. Отзыв токена становится виден
+// не позже чем через ttl. Нулевой ttl отключает кэш: база проверяется
+// на каждый запрос
+type revocation struct {
+	db *cache.Cache
+}
+
+func newRevocation(
+	ttl time.Duration,
+) *revocation {
+
+	if ttl <= 0 {
+		return &revocation{}
+	}
+
+	return &revocation{
+		db: cache.New(ttl, 2*ttl),
+	}
+}
+
+func (r *revocation) get(
+	refreshTokenId int,
+) (bool, bool) {
+
+	if r.db == nil {
+		return false, false
+	}
+
+	value, ok := r.db.Get(strconv.Itoa(refreshTokenId))
+	if !ok {
+		return false, false
+	}
+
+	exists, ok := value.(bool)
+
+	return exists, ok
+}
+
+func (r *revocation) set(
+	refreshTokenId int,
+	exists bool,
+) {
+
+	if r.db == nil {
+		return
+	}
+
+	r.db.SetDefault(strconv.Itoa(refreshTokenId), exists)
+}
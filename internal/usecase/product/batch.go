@@ -0,0 +1,148 @@
+package product
+
+import (
+	"context"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/errors"
+	errpkg "github.com/jackvonhouse/product-catalog/pkg/errors"
+)
+
+// Batch выполняет операции над товарами по порядку. В атомарном режиме все
+// операции выполняются одной единицей работы и отменяются при первой ошибке,
+// иначе каждая операция фиксируется отдельно и ошибка не прерывает остальные
+func (u UseCase) Batch(
+	ctx context.Context,
+	data dto.BatchProducts,
+) (dto.BatchResult, error) {
+
+	result := dto.BatchResult{
+		Atomic:  data.Atomic,
+		Results: make([]dto.BatchOperationResult, len(data.Operations)),
+	}
+
+	for i, operation := range data.Operations {
+		result.Results[i] = dto.BatchOperationResult{
+			Index:  i,
+			Type:   operation.Type,
+			Status: dto.BatchSkipped,
+		}
+	}
+
+	if !data.Atomic {
+		for i, operation := range data.Operations {
+			productId, err := u.batchOperation(ctx, operation)
+
+			u.batchOperationResult(&result, i, productId, err)
+		}
+
+		result.Committed = result.Succeeded > 0
+
+		return result, nil
+	}
+
+	var (
+		productIds   = make([]int, len(data.Operations))
+		failed       = -1
+		operationErr error
+	)
+
+	err := u.transaction.Do(ctx, func(ctx context.Context) error {
+		for i, operation := range data.Operations {
+			productId, err := u.batchOperation(ctx, operation)
+			if err != nil {
+				failed, operationErr = i, err
+
+				return err
+			}
+
+			productIds[i] = productId
+		}
+
+		return nil
+	})
+
+	// Ошибка не связана с операциями: транзакцию не удалось открыть,
+	// зафиксировать или отменить
+	if err != nil && (failed < 0 || !errpkg.Is(err, operationErr)) {
+		return dto.BatchResult{}, err
+	}
+
+	if failed < 0 {
+		for i, productId := range productIds {
+			u.batchOperationResult(&result, i, productId, nil)
+		}
+
+		result.Committed = true
+
+		return result, nil
+	}
+
+	for i := 0; i < failed; i++ {
+		result.Results[i].Status = dto.BatchRolledBack
+	}
+
+	u.batchOperationResult(&result, failed, 0, operationErr)
+
+	return result, nil
+}
+
+func (u UseCase) batchOperation(
+	ctx context.Context,
+	operation dto.BatchOperation,
+) (int, error) {
+
+	switch operation.Type {
+
+	case dto.BatchCreate:
+		if operation.Create == nil {
+			return 0, errors.ErrInvalid.New("product data can't be empty")
+		}
+
+		return u.Create(ctx, *operation.Create)
+
+	case dto.BatchUpdate:
+		if operation.Update == nil {
+			return 0, errors.ErrInvalid.New("product data can't be empty")
+		}
+
+		data := *operation.Update
+		data.ID = operation.ID
+		data.Version = operation.Version
+
+		return u.Update(ctx, data)
+
+	case dto.BatchDelete:
+		return u.Delete(ctx, operation.ID, operation.Version)
+
+	case dto.BatchAttach:
+		return operation.ID, u.AttachToCategory(ctx, operation.ID, operation.CategoryId)
+
+	case dto.BatchDetach:
+		return operation.ID, u.DetachFromCategory(ctx, operation.ID, operation.CategoryId)
+
+	default:
+		return 0, errors.ErrInvalid.New("unknown operation type")
+	}
+}
+
+func (u UseCase) batchOperationResult(
+	result *dto.BatchResult,
+	index int,
+	productId int,
+	err error,
+) {
+
+	if err != nil {
+		u.logger.Warnf("batch operation %d failed: %s", index, err)
+
+		result.Results[index].Status = dto.BatchFailed
+		result.Results[index].Error = err.Error()
+		result.Failed++
+
+		return
+	}
+
+	result.Results[index].Status = dto.BatchDone
+	result.Results[index].ID = productId
+	result.Succeeded++
+}
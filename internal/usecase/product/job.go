@@ -0,0 +1,167 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/errors"
+	"github.com/jackvonhouse/product-catalog/internal/export"
+	"github.com/jackvonhouse/product-catalog/internal/principal"
+	"github.com/jackvonhouse/product-catalog/internal/requestid"
+	"time"
+)
+
+func (u UseCase) ImportAsync(
+	ctx context.Context,
+	data dto.ImportProducts,
+) (int, error) {
+
+	return u.enqueue(ctx, dto.JobProductImport, data)
+}
+
+func (u UseCase) ExportAsync(
+	ctx context.Context,
+	data dto.ExportProducts,
+) (int, error) {
+
+	if _, err := export.NewWriter(nil, data.Format); err != nil {
+		return 0, err
+	}
+
+	return u.enqueue(ctx, dto.JobProductExport, data)
+}
+
+// RunImport выполняет задачу импорта, отчёт сохраняется результатом задачи
+func (u UseCase) RunImport(
+	ctx context.Context,
+	job dto.Job,
+	progress func(int),
+) (dto.JobOutput, error) {
+
+	data := dto.ImportProducts{}
+
+	if err := u.decodePayload(job, &data); err != nil {
+		return dto.JobOutput{}, err
+	}
+
+	result, err := u.Import(ctx, data)
+	if err != nil {
+		return dto.JobOutput{}, err
+	}
+
+	progress(result.Total)
+
+	return u.jobOutput(result, nil)
+}
+
+// RunExport выполняет задачу выгрузки, файл сохраняется вместе с задачей
+func (u UseCase) RunExport(
+	ctx context.Context,
+	job dto.Job,
+	progress func(int),
+) (dto.JobOutput, error) {
+
+	data := dto.ExportProducts{}
+
+	if err := u.decodePayload(job, &data); err != nil {
+		return dto.JobOutput{}, err
+	}
+
+	buffer := bytes.Buffer{}
+
+	writer, err := export.NewWriter(&buffer, data.Format)
+	if err != nil {
+		return dto.JobOutput{}, err
+	}
+
+	exported := 0
+
+	err = u.product.Export(ctx, data.Data, func(product dto.ExportProduct) error {
+		if err := writer.Write(product); err != nil {
+			return err
+		}
+
+		exported++
+		progress(exported)
+
+		return nil
+	})
+
+	if err != nil {
+		return dto.JobOutput{}, err
+	}
+
+	if err := writer.Flush(); err != nil {
+		u.logger.Warnf("can't write export file: %s", err)
+
+		return dto.JobOutput{}, errors.ErrInternal.New("can't write export file")
+	}
+
+	file := dto.JobFile{
+		Name:        export.FileName(data.Format, time.Now()),
+		ContentType: export.ContentType(data.Format),
+		Data:        buffer.Bytes(),
+	}
+
+	return u.jobOutput(map[string]int{"exported": exported}, &file)
+}
+
+// enqueue запоминает автора и запрос, чтобы обработчик
+// выполнил задачу от их имени
+func (u UseCase) enqueue(
+	ctx context.Context,
+	jobType string,
+	payload any,
+) (int, error) {
+
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		u.logger.Warnf("can't encode job payload: %s", err)
+
+		return 0, errors.ErrInternal.New("can't create job")
+	}
+
+	job := dto.CreateJob{
+		Type:      jobType,
+		Payload:   encoded,
+		RequestId: requestid.FromContext(ctx),
+	}
+
+	if p, ok := principal.FromContext(ctx); ok {
+		job.ActorId = &p.UserId
+		job.Actor = p.Username
+		job.ActorRole = p.Role
+	}
+
+	return u.job.Create(ctx, job)
+}
+
+func (u UseCase) decodePayload(
+	job dto.Job,
+	payload any,
+) error {
+
+	if err := json.Unmarshal(job.Payload, payload); err != nil {
+		u.logger.Warnf("can't decode payload of job %d: %s", job.ID, err)
+
+		return errors.ErrInvalid.New("invalid job payload")
+	}
+
+	return nil
+}
+
+func (u UseCase) jobOutput(
+	result any,
+	file *dto.JobFile,
+) (dto.JobOutput, error) {
+
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		u.logger.Warnf("can't encode job result: %s", err)
+
+		return dto.JobOutput{}, errors.ErrInternal.New("can't encode job result")
+	}
+
+	return dto.JobOutput{Result: encoded, File: file}, nil
+}
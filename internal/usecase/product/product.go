@@ -2,29 +2,50 @@ package product
 
 import (
 	"context"
+	"github.com/jackvonhouse/product-catalog/internal/audit"
 	"github.com/jackvonhouse/product-catalog/internal/dto"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"slices"
+	"time"
 )
 
 type productService interface {
-	Create(context.Context, dto.CreateProduct, dto.Category) (int, error)
+	Create(context.Context, dto.CreateProduct, []dto.Category) (int, error)
+	AttachToCategory(context.Context, dto.Product, dto.Category) error
+	Import(context.Context, []dto.ImportProduct, bool) (dto.ImportResult, error)
 
-	Get(context.Context, dto.GetProduct) ([]dto.Product, error)
+	Get(context.Context, dto.GetProduct) (dto.ProductPage, error)
 	GetById(context.Context, int) (dto.Product, error)
-	GetByCategoryId(context.Context, dto.GetProduct, dto.Category) ([]dto.Product, error)
+	Search(context.Context, dto.SearchProduct) ([]dto.FoundProduct, error)
+	Export(context.Context, dto.GetProduct, func(dto.ExportProduct) error) error
 
 	Update(context.Context, dto.UpdateProduct, dto.Category) (int, error)
 
-	Delete(context.Context, int) (int, error)
+	DetachFromCategory(context.Context, dto.Product, dto.Category) error
+	Delete(context.Context, int, int) (int, error)
+	Restore(context.Context, int) (int, error)
+	Purge(context.Context, time.Time) (int64, error)
 }
 
 type categoryService interface {
 	GetById(context.Context, int) (dto.Category, error)
+	GetByProductId(context.Context, dto.Product) ([]dto.Category, error)
+}
+
+type jobService interface {
+	Create(context.Context, dto.CreateJob) (int, error)
+}
+
+type transactor interface {
+	Do(context.Context, func(context.Context) error) error
 }
 
 type UseCase struct {
-	product  productService
-	category categoryService
+	product     productService
+	category    categoryService
+	job         jobService
+	transaction transactor
+	audit       audit.Recorder
 
 	logger log.Logger
 }
@@ -32,13 +53,19 @@ type UseCase struct {
 func New(
 	service productService,
 	category categoryService,
+	job jobService,
+	transaction transactor,
+	audit audit.Recorder,
 	logger log.Logger,
 ) UseCase {
 
 	return UseCase{
-		product:  service,
-		category: category,
-		logger:   logger.WithField("unit", "product"),
+		product:     service,
+		category:    category,
+		job:         job,
+		transaction: transaction,
+		audit:       audit,
+		logger:      logger.WithField("unit", "product"),
 	}
 }
 
@@ -47,38 +74,117 @@ func (u UseCase) Create(
 	data dto.CreateProduct,
 ) (int, error) {
 
-	category, err := u.category.GetById(ctx, data.CategoryId)
+	categories, err := u.getCategories(ctx, data.CategoryIds)
 	if err != nil {
-		u.logger.Warnf("category not found: %s", err)
-
 		return 0, err
 	}
 
-	return u.product.Create(ctx, data, category)
+	ctx = u.audit.Record(ctx, audit.ActionCreate, audit.EntityProduct)
+
+	return u.product.Create(ctx, data, categories)
+}
+
+func (u UseCase) AttachToCategory(
+	ctx context.Context,
+	productId, categoryId int,
+) error {
+
+	product, category, err := u.getProductAndCategory(ctx, productId, categoryId)
+	if err != nil {
+		return err
+	}
+
+	return u.product.AttachToCategory(ctx, product, category)
+}
+
+// Import сохраняет корректные строки и объединяет отчёт с ошибками
+// разбора файла, упорядочивая его по номеру строки
+func (u UseCase) Import(
+	ctx context.Context,
+	data dto.ImportProducts,
+) (dto.ImportResult, error) {
+
+	ctx = u.audit.Record(ctx, audit.ActionCreate, audit.EntityProduct)
+
+	result, err := u.product.Import(ctx, data.Rows, data.DryRun)
+	if err != nil {
+		return dto.ImportResult{}, err
+	}
+
+	result.Errors = append(slices.Clone(data.Errors), result.Errors...)
+	result.Total = len(data.Rows) + len(data.Errors)
+	result.Failed = len(result.Errors)
+
+	slices.SortStableFunc(result.Errors, func(a, b dto.ImportError) int {
+		return a.Row - b.Row
+	})
+
+	return result, nil
 }
 
 func (u UseCase) Get(
 	ctx context.Context,
 	data dto.GetProduct,
-) ([]dto.Product, error) {
+) (dto.ProductPage, error) {
 
 	return u.product.Get(ctx, data)
 }
 
-func (u UseCase) GetByCategoryId(
+func (u UseCase) Export(
 	ctx context.Context,
 	data dto.GetProduct,
-	categoryId int,
-) ([]dto.Product, error) {
+	write func(dto.ExportProduct) error,
+) error {
 
-	category, err := u.category.GetById(ctx, categoryId)
+	return u.product.Export(ctx, data, write)
+}
+
+func (u UseCase) GetById(
+	ctx context.Context,
+	id int,
+) (dto.ProductWithCategories, error) {
+
+	product, err := u.product.GetById(ctx, id)
 	if err != nil {
-		u.logger.Warnf("category not found: %s", err)
+		u.logger.Warnf("product not found: %s", err)
+
+		return dto.ProductWithCategories{}, err
+	}
+
+	categories, err := u.category.GetByProductId(ctx, product)
+	if err != nil {
+		u.logger.Warnf("can't get categories of product: %s", err)
 
-		return []dto.Product{}, err
+		return dto.ProductWithCategories{}, err
 	}
 
-	return u.product.GetByCategoryId(ctx, data, category)
+	return dto.ProductWithCategories{
+		Product:    product,
+		Categories: categories,
+	}, nil
+}
+
+func (u UseCase) GetCategories(
+	ctx context.Context,
+	productId int,
+) ([]dto.Category, error) {
+
+	product, err := u.product.GetById(ctx, productId)
+	if err != nil {
+		u.logger.Warnf("product not found: %s", err)
+
+		return []dto.Category{}, err
+	}
+
+	return u.category.GetByProductId(ctx, product)
+}
+
+func (u UseCase) Search(
+	ctx context.Context,
+	data dto.SearchProduct,
+) ([]dto.FoundProduct, error) {
+
+	return u.product.Search(ctx, data)
 }
 
 func (u UseCase) Update(
@@ -93,13 +199,99 @@ func (u UseCase) Update(
 		return 0, err
 	}
 
+	ctx = u.audit.Record(ctx, audit.ActionUpdate, audit.EntityProduct)
+
 	return u.product.Update(ctx, data, category)
 }
 
+func (u UseCase) DetachFromCategory(
+	ctx context.Context,
+	productId, categoryId int,
+) error {
+
+	product, category, err := u.getProductAndCategory(ctx, productId, categoryId)
+	if err != nil {
+		return err
+	}
+
+	return u.product.DetachFromCategory(ctx, product, category)
+}
+
 func (u UseCase) Delete(
 	ctx context.Context,
 	id int,
+	version int,
 ) (int, error) {
 
-	return u.product.Delete(ctx, id)
+	ctx = u.audit.Record(ctx, audit.ActionDelete, audit.EntityProduct)
+
+	return u.product.Delete(ctx, id, version)
+}
+
+func (u UseCase) Restore(
+	ctx context.Context,
+	id int,
+) (int, error) {
+
+	ctx = u.audit.Record(ctx, audit.ActionRestore, audit.EntityProduct)
+
+	return u.product.Restore(ctx, id)
+}
+
+func (u UseCase) Purge(
+	ctx context.Context,
+	deletedBefore time.Time,
+) (int64, error) {
+
+	return u.product.Purge(ctx, deletedBefore)
+}
+
+func (u UseCase) getCategories(
+	ctx context.Context,
+	categoryIds []int,
+) ([]dto.Category, error) {
+
+	categories := make([]dto.Category, 0, len(categoryIds))
+	seen := make(map[int]struct{}, len(categoryIds))
+
+	for _, categoryId := range categoryIds {
+		if _, ok := seen[categoryId]; ok {
+			continue
+		}
+
+		seen[categoryId] = struct{}{}
+
+		category, err := u.category.GetById(ctx, categoryId)
+		if err != nil {
+			u.logger.Warnf("category not found: %s", err)
+
+			return []dto.Category{}, err
+		}
+
+		categories = append(categories, category)
+	}
+
+	return categories, nil
+}
+
+func (u UseCase) getProductAndCategory(
+	ctx context.Context,
+	productId, categoryId int,
+) (dto.Product, dto.Category, error) {
+
+	product, err := u.product.GetById(ctx, productId)
+	if err != nil {
+		u.logger.Warnf("product not found: %s", err)
+
+		return dto.Product{}, dto.Category{}, err
+	}
+
+	category, err := u.category.GetById(ctx, categoryId)
+	if err != nil {
+		u.logger.Warnf("category not found: %s", err)
+
+		return dto.Product{}, dto.Category{}, err
+	}
+
+	return product, category, nil
 }
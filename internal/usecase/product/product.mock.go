@@ -12,6 +12,7 @@ package product
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	dto "github.com/jackvonhouse/product-catalog/internal/dto"
 	gomock "go.uber.org/mock/gomock"
@@ -40,8 +41,22 @@ func (m *MockproductService) EXPECT() *MockproductServiceMockRecorder {
 	return m.recorder
 }
 
+// AttachToCategory mocks base method.
+func (m *MockproductService) AttachToCategory(arg0 context.Context, arg1 dto.Product, arg2 dto.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachToCategory", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachToCategory indicates an expected call of AttachToCategory.
+func (mr *MockproductServiceMockRecorder) AttachToCategory(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachToCategory", reflect.TypeOf((*MockproductService)(nil).AttachToCategory), arg0, arg1, arg2)
+}
+
 // Create mocks base method.
-func (m *MockproductService) Create(arg0 context.Context, arg1 dto.CreateProduct, arg2 dto.Category) (int, error) {
+func (m *MockproductService) Create(arg0 context.Context, arg1 dto.CreateProduct, arg2 []dto.Category) (int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
 	ret0, _ := ret[0].(int)
@@ -56,48 +71,61 @@ func (mr *MockproductServiceMockRecorder) Create(arg0, arg1, arg2 any) *gomock.C
 }
 
 // Delete mocks base method.
-func (m *MockproductService) Delete(arg0 context.Context, arg1 int) (int, error) {
+func (m *MockproductService) Delete(arg0 context.Context, arg1, arg2 int) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockproductServiceMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+func (mr *MockproductServiceMockRecorder) Delete(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockproductService)(nil).Delete), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockproductService)(nil).Delete), arg0, arg1, arg2)
 }
 
-// Get mocks base method.
-func (m *MockproductService) Get(arg0 context.Context, arg1 dto.GetProduct) ([]dto.Product, error) {
+// DetachFromCategory mocks base method.
+func (m *MockproductService) DetachFromCategory(arg0 context.Context, arg1 dto.Product, arg2 dto.Category) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", arg0, arg1)
-	ret0, _ := ret[0].([]dto.Product)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "DetachFromCategory", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// Get indicates an expected call of Get.
-func (mr *MockproductServiceMockRecorder) Get(arg0, arg1 any) *gomock.Call {
+// DetachFromCategory indicates an expected call of DetachFromCategory.
+func (mr *MockproductServiceMockRecorder) DetachFromCategory(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockproductService)(nil).Get), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachFromCategory", reflect.TypeOf((*MockproductService)(nil).DetachFromCategory), arg0, arg1, arg2)
 }
 
-// GetByCategoryId mocks base method.
-func (m *MockproductService) GetByCategoryId(arg0 context.Context, arg1 dto.GetProduct, arg2 dto.Category) ([]dto.Product, error) {
+// Export mocks base method.
+func (m *MockproductService) Export(arg0 context.Context, arg1 dto.GetProduct, arg2 func(dto.ExportProduct) error) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByCategoryId", arg0, arg1, arg2)
-	ret0, _ := ret[0].([]dto.Product)
+	ret := m.ctrl.Call(m, "Export", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockproductServiceMockRecorder) Export(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockproductService)(nil).Export), arg0, arg1, arg2)
+}
+
+// Get mocks base method.
+func (m *MockproductService) Get(arg0 context.Context, arg1 dto.GetProduct) (dto.ProductPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(dto.ProductPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByCategoryId indicates an expected call of GetByCategoryId.
-func (mr *MockproductServiceMockRecorder) GetByCategoryId(arg0, arg1, arg2 any) *gomock.Call {
+// Get indicates an expected call of Get.
+func (mr *MockproductServiceMockRecorder) Get(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCategoryId", reflect.TypeOf((*MockproductService)(nil).GetByCategoryId), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockproductService)(nil).Get), arg0, arg1)
 }
 
 // GetById mocks base method.
@@ -115,6 +143,66 @@ func (mr *MockproductServiceMockRecorder) GetById(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockproductService)(nil).GetById), arg0, arg1)
 }
 
+// Import mocks base method.
+func (m *MockproductService) Import(arg0 context.Context, arg1 []dto.ImportProduct, arg2 bool) (dto.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", arg0, arg1, arg2)
+	ret0, _ := ret[0].(dto.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockproductServiceMockRecorder) Import(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockproductService)(nil).Import), arg0, arg1, arg2)
+}
+
+// Purge mocks base method.
+func (m *MockproductService) Purge(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockproductServiceMockRecorder) Purge(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockproductService)(nil).Purge), arg0, arg1)
+}
+
+// Restore mocks base method.
+func (m *MockproductService) Restore(arg0 context.Context, arg1 int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockproductServiceMockRecorder) Restore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockproductService)(nil).Restore), arg0, arg1)
+}
+
+// Search mocks base method.
+func (m *MockproductService) Search(arg0 context.Context, arg1 dto.SearchProduct) ([]dto.FoundProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0, arg1)
+	ret0, _ := ret[0].([]dto.FoundProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockproductServiceMockRecorder) Search(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockproductService)(nil).Search), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockproductService) Update(arg0 context.Context, arg1 dto.UpdateProduct, arg2 dto.Category) (int, error) {
 	m.ctrl.T.Helper()
@@ -167,3 +255,93 @@ func (mr *MockcategoryServiceMockRecorder) GetById(arg0, arg1 any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockcategoryService)(nil).GetById), arg0, arg1)
 }
+
+// GetByProductId mocks base method.
+func (m *MockcategoryService) GetByProductId(arg0 context.Context, arg1 dto.Product) ([]dto.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProductId", arg0, arg1)
+	ret0, _ := ret[0].([]dto.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProductId indicates an expected call of GetByProductId.
+func (mr *MockcategoryServiceMockRecorder) GetByProductId(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProductId", reflect.TypeOf((*MockcategoryService)(nil).GetByProductId), arg0, arg1)
+}
+
+// MockjobService is a mock of jobService interface.
+type MockjobService struct {
+	ctrl     *gomock.Controller
+	recorder *MockjobServiceMockRecorder
+}
+
+// MockjobServiceMockRecorder is the mock recorder for MockjobService.
+type MockjobServiceMockRecorder struct {
+	mock *MockjobService
+}
+
+// NewMockjobService creates a new mock instance.
+func NewMockjobService(ctrl *gomock.Controller) *MockjobService {
+	mock := &MockjobService{ctrl: ctrl}
+	mock.recorder = &MockjobServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockjobService) EXPECT() *MockjobServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockjobService) Create(arg0 context.Context, arg1 dto.CreateJob) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockjobServiceMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockjobService)(nil).Create), arg0, arg1)
+}
+
+// Mocktransactor is a mock of transactor interface.
+type Mocktransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactorMockRecorder
+}
+
+// MocktransactorMockRecorder is the mock recorder for Mocktransactor.
+type MocktransactorMockRecorder struct {
+	mock *Mocktransactor
+}
+
+// NewMocktransactor creates a new mock instance.
+func NewMocktransactor(ctrl *gomock.Controller) *Mocktransactor {
+	mock := &Mocktransactor{ctrl: ctrl}
+	mock.recorder = &MocktransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocktransactor) EXPECT() *MocktransactorMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *Mocktransactor) Do(arg0 context.Context, arg1 func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Do indicates an expected call of Do.
+func (mr *MocktransactorMockRecorder) Do(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*Mocktransactor)(nil).Do), arg0, arg1)
+}
@@ -2,11 +2,16 @@ package product
 
 import (
 	"context"
+	"encoding/json"
+	"github.com/jackvonhouse/product-catalog/internal/audit"
 	"github.com/jackvonhouse/product-catalog/internal/dto"
 	"github.com/jackvonhouse/product-catalog/internal/errors"
+	"github.com/jackvonhouse/product-catalog/internal/principal"
+	"github.com/jackvonhouse/product-catalog/internal/requestid"
 	"github.com/jackvonhouse/product-catalog/pkg/log"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
+	"strings"
 	"testing"
 )
 
@@ -28,8 +33,10 @@ type ProductTestSuite struct {
 	products []dto.Product
 
 	// Служебные параметры
-	productMock  *MockproductService
-	categoryMock *MockcategoryService
+	productMock     *MockproductService
+	categoryMock    *MockcategoryService
+	jobMock         *MockjobService
+	transactionMock *Mocktransactor
 }
 
 func TestSuiteCreate(t *testing.T) {
@@ -60,12 +67,14 @@ func (s *ProductTestSuite) setupMock(
 
 	s.productMock = NewMockproductService(controller)
 	s.categoryMock = NewMockcategoryService(controller)
+	s.jobMock = NewMockjobService(controller)
+	s.transactionMock = NewMocktransactor(controller)
 
 	return s
 }
 
 func (s *ProductTestSuite) setupUseCase() *ProductTestSuite {
-	s.useCase = New(s.productMock, s.categoryMock, s.logger)
+	s.useCase = New(s.productMock, s.categoryMock, s.jobMock, s.transactionMock, audit.New(s.logger), s.logger)
 
 	return s
 }
@@ -76,8 +85,8 @@ func (s *ProductTestSuite) setupCreateProduct(
 ) *ProductTestSuite {
 
 	s.create = dto.CreateProduct{
-		Name:       name,
-		CategoryId: categoryId,
+		Name:        name,
+		CategoryIds: []int{categoryId},
 	}
 
 	return s
@@ -140,13 +149,13 @@ func (s *ProductTestSuite) setupCategory(
 func (s *ProductTestSuite) TestCreateSuccessful() {
 	s.categoryMock.
 		EXPECT().
-		GetById(s.ctx, s.create.CategoryId).
+		GetById(s.ctx, s.create.CategoryIds[0]).
 		Return(s.category, nil).
 		Times(1)
 
 	s.productMock.
 		EXPECT().
-		Create(s.ctx, s.create, s.category).
+		Create(gomock.Any(), s.create, []dto.Category{s.category}).
 		Return(1, nil)
 
 	productId, err := s.useCase.Create(s.ctx, s.create)
@@ -166,7 +175,7 @@ func (s *ProductTestSuite) TestCreateFailed() {
 
 	s.categoryMock.
 		EXPECT().
-		GetById(s.ctx, s.create.CategoryId).
+		GetById(s.ctx, s.create.CategoryIds[0]).
 		Return(dto.Category{}, expectedError).
 		Times(1)
 
@@ -177,38 +186,96 @@ func (s *ProductTestSuite) TestCreateFailed() {
 	s.Equal(0, productId)
 }
 
-func (s *ProductTestSuite) TestGetSuccessful() {
+func (s *ProductTestSuite) TestCreateWithDuplicateCategoriesSuccessful() {
+	s.create.CategoryIds = []int{s.category.ID, s.category.ID}
+
+	s.categoryMock.
+		EXPECT().
+		GetById(s.ctx, s.category.ID).
+		Return(s.category, nil).
+		Times(1)
+
 	s.productMock.
 		EXPECT().
-		Get(s.ctx, s.get).
-		Return(s.products, nil)
+		Create(gomock.Any(), s.create, []dto.Category{s.category}).
+		Return(1, nil)
 
-	products, err := s.useCase.Get(s.ctx, s.get)
+	productId, err := s.useCase.Create(s.ctx, s.create)
 
 	s.NoError(err)
-	s.Equal(s.products, products)
+	s.Equal(1, productId)
 }
 
-func (s *ProductTestSuite) TestGetByCategoryIdSuccessful() {
+func (s *ProductTestSuite) TestAttachToCategorySuccessful() {
+	s.productMock.
+		EXPECT().
+		GetById(s.ctx, s.product.ID).
+		Return(s.product, nil).
+		Times(1)
+
 	s.categoryMock.
 		EXPECT().
-		GetById(s.ctx, s.create.CategoryId).
+		GetById(s.ctx, s.category.ID).
 		Return(s.category, nil).
 		Times(1)
 
 	s.productMock.
 		EXPECT().
-		GetByCategoryId(s.ctx, s.get, s.category).
-		Return(s.products, nil).
+		AttachToCategory(s.ctx, s.product, s.category).
+		Return(nil).
 		Times(1)
 
-	products, err := s.useCase.GetByCategoryId(s.ctx, s.get, s.create.CategoryId)
+	err := s.useCase.AttachToCategory(s.ctx, s.product.ID, s.category.ID)
 
 	s.NoError(err)
-	s.Equal(s.products, products)
 }
 
-func (s *ProductTestSuite) TestGetByCategoryIdFailed() {
+func (s *ProductTestSuite) TestAttachToCategoryFailed() {
+	const (
+		expectedNotFoundErrorMsg = "product not found"
+	)
+
+	var (
+		expectedError = errors.ErrNotFound.New(expectedNotFoundErrorMsg)
+	)
+
+	s.productMock.
+		EXPECT().
+		GetById(s.ctx, s.product.ID).
+		Return(dto.Product{}, expectedError).
+		Times(1)
+
+	err := s.useCase.AttachToCategory(s.ctx, s.product.ID, s.category.ID)
+
+	s.NotNil(err)
+	s.Equal(expectedError.Error(), err.Error())
+}
+
+func (s *ProductTestSuite) TestDetachFromCategorySuccessful() {
+	s.productMock.
+		EXPECT().
+		GetById(s.ctx, s.product.ID).
+		Return(s.product, nil).
+		Times(1)
+
+	s.categoryMock.
+		EXPECT().
+		GetById(s.ctx, s.category.ID).
+		Return(s.category, nil).
+		Times(1)
+
+	s.productMock.
+		EXPECT().
+		DetachFromCategory(s.ctx, s.product, s.category).
+		Return(nil).
+		Times(1)
+
+	err := s.useCase.DetachFromCategory(s.ctx, s.product.ID, s.category.ID)
+
+	s.NoError(err)
+}
+
+func (s *ProductTestSuite) TestDetachFromCategoryFailed() {
 	const (
 		expectedNotFoundErrorMsg = "category not found"
 	)
@@ -217,17 +284,144 @@ func (s *ProductTestSuite) TestGetByCategoryIdFailed() {
 		expectedError = errors.ErrNotFound.New(expectedNotFoundErrorMsg)
 	)
 
+	s.productMock.
+		EXPECT().
+		GetById(s.ctx, s.product.ID).
+		Return(s.product, nil).
+		Times(1)
+
 	s.categoryMock.
 		EXPECT().
-		GetById(s.ctx, s.create.CategoryId).
+		GetById(s.ctx, s.category.ID).
 		Return(dto.Category{}, expectedError).
 		Times(1)
 
-	products, err := s.useCase.GetByCategoryId(s.ctx, s.get, s.create.CategoryId)
+	err := s.useCase.DetachFromCategory(s.ctx, s.product.ID, s.category.ID)
+
+	s.NotNil(err)
+	s.Equal(expectedError.Error(), err.Error())
+}
+
+func (s *ProductTestSuite) TestGetCategoriesSuccessful() {
+	categories := []dto.Category{s.category}
+
+	s.productMock.
+		EXPECT().
+		GetById(s.ctx, s.product.ID).
+		Return(s.product, nil).
+		Times(1)
+
+	s.categoryMock.
+		EXPECT().
+		GetByProductId(s.ctx, s.product).
+		Return(categories, nil).
+		Times(1)
+
+	result, err := s.useCase.GetCategories(s.ctx, s.product.ID)
+
+	s.NoError(err)
+	s.Equal(categories, result)
+}
+
+func (s *ProductTestSuite) TestGetSuccessful() {
+	s.productMock.
+		EXPECT().
+		Get(s.ctx, s.get).
+		Return(dto.ProductPage{Items: s.products, Total: 1}, nil)
+
+	products, err := s.useCase.Get(s.ctx, s.get)
+
+	s.NoError(err)
+	s.Equal(dto.ProductPage{Items: s.products, Total: 1}, products)
+}
+
+func (s *ProductTestSuite) TestSearchSuccessful() {
+	search := dto.SearchProduct{Query: "корм", Limit: s.get.Limit, Offset: s.get.Offset}
+	found := []dto.FoundProduct{{Product: s.product, Rank: 0.5, Snippet: "<b>корм</b>"}}
+
+	s.productMock.
+		EXPECT().
+		Search(s.ctx, search).
+		Return(found, nil)
+
+	products, err := s.useCase.Search(s.ctx, search)
+
+	s.NoError(err)
+	s.Equal(found, products)
+}
+
+func (s *ProductTestSuite) TestGetByIdSuccessful() {
+	categories := []dto.Category{s.category}
+
+	s.productMock.
+		EXPECT().
+		GetById(s.ctx, s.product.ID).
+		Return(s.product, nil).
+		Times(1)
+
+	s.categoryMock.
+		EXPECT().
+		GetByProductId(s.ctx, s.product).
+		Return(categories, nil).
+		Times(1)
+
+	product, err := s.useCase.GetById(s.ctx, s.product.ID)
+
+	s.NoError(err)
+	s.Equal(dto.ProductWithCategories{
+		Product:    s.product,
+		Categories: categories,
+	}, product)
+}
+
+func (s *ProductTestSuite) TestGetByIdFailed() {
+	const (
+		expectedNotFoundErrorMsg = "product not found"
+	)
+
+	var (
+		expectedError = errors.ErrNotFound.New(expectedNotFoundErrorMsg)
+	)
+
+	s.productMock.
+		EXPECT().
+		GetById(s.ctx, s.product.ID).
+		Return(dto.Product{}, expectedError).
+		Times(1)
+
+	product, err := s.useCase.GetById(s.ctx, s.product.ID)
 
 	s.NotNil(err)
 	s.Equal(expectedError.Error(), err.Error())
-	s.Equal([]dto.Product{}, products)
+	s.Equal(dto.ProductWithCategories{}, product)
+}
+
+func (s *ProductTestSuite) TestGetByIdCategoriesFailed() {
+	const (
+		expectedInternalErrorMsg = "unknown error on getting categories"
+	)
+
+	var (
+		expectedError = errors.ErrInternal.New(expectedInternalErrorMsg)
+	)
+
+	s.productMock.
+		EXPECT().
+		GetById(s.ctx, s.product.ID).
+		Return(s.product, nil).
+		Times(1)
+
+	s.categoryMock.
+		EXPECT().
+		GetByProductId(s.ctx, s.product).
+		Return([]dto.Category{}, expectedError).
+		Times(1)
+
+	product, err := s.useCase.GetById(s.ctx, s.product.ID)
+
+	s.NotNil(err)
+	s.Equal(expectedError.Error(), err.Error())
+	s.Equal(dto.ProductWithCategories{}, product)
 }
 
 func (s *ProductTestSuite) TestUpdateSuccessful() {
@@ -239,7 +433,7 @@ func (s *ProductTestSuite) TestUpdateSuccessful() {
 
 	s.productMock.
 		EXPECT().
-		Update(s.ctx, s.update, s.category).
+		Update(gomock.Any(), s.update, s.category).
 		Return(s.product.ID, nil).
 		Times(1)
 
@@ -274,12 +468,295 @@ func (s *ProductTestSuite) TestUpdateCategoryFailed() {
 func (s *ProductTestSuite) TestDeleteSuccessful() {
 	s.productMock.
 		EXPECT().
-		Delete(s.ctx, s.product.ID).
+		Delete(gomock.Any(), s.product.ID, 0).
 		Return(s.product.ID, nil).
 		Times(1)
 
-	productId, err := s.useCase.Delete(s.ctx, s.product.ID)
+	productId, err := s.useCase.Delete(s.ctx, s.product.ID, 0)
 
 	s.NoError(err)
 	s.Equal(1, productId)
 }
+
+func (s *ProductTestSuite) TestImportSuccessful() {
+	rows := []dto.ImportProduct{{Row: 2, Name: "Продукт"}}
+
+	data := dto.ImportProducts{
+		Rows: rows,
+		Errors: []dto.ImportError{
+			{Row: 4, Reason: "invalid price"},
+		},
+	}
+
+	s.productMock.
+		EXPECT().
+		Import(gomock.Any(), rows, false).
+		Return(dto.ImportResult{
+			Created: 0,
+			Errors:  []dto.ImportError{{Row: 2, Reason: "product already exists"}},
+		}, nil).
+		Times(1)
+
+	result, err := s.useCase.Import(s.ctx, data)
+
+	s.NoError(err)
+	s.Equal(dto.ImportResult{
+		Total:  2,
+		Failed: 2,
+		Errors: []dto.ImportError{
+			{Row: 2, Reason: "product already exists"},
+			{Row: 4, Reason: "invalid price"},
+		},
+	}, result)
+}
+
+func (s *ProductTestSuite) TestExportAsyncSuccessful() {
+	ctx := principal.WithContext(s.ctx, principal.Principal{
+		UserId:   1,
+		Username: "admin",
+		Role:     dto.RoleAdmin,
+	})
+	ctx = requestid.WithContext(ctx, "request")
+
+	actorId := 1
+	data := dto.ExportProducts{Format: "csv", Data: s.get}
+
+	payload, err := json.Marshal(data)
+	s.NoError(err)
+
+	s.jobMock.
+		EXPECT().
+		Create(ctx, dto.CreateJob{
+			Type:      dto.JobProductExport,
+			Payload:   payload,
+			ActorId:   &actorId,
+			Actor:     "admin",
+			ActorRole: dto.RoleAdmin,
+			RequestId: "request",
+		}).
+		Return(1, nil).
+		Times(1)
+
+	jobId, err := s.useCase.ExportAsync(ctx, data)
+
+	s.NoError(err)
+	s.Equal(1, jobId)
+}
+
+func (s *ProductTestSuite) TestExportAsyncFailed() {
+	const (
+		expectedErrorMsg = "unsupported export format"
+	)
+
+	jobId, err := s.useCase.ExportAsync(s.ctx, dto.ExportProducts{Format: "xlsx", Data: s.get})
+
+	s.EqualError(err, expectedErrorMsg)
+	s.Equal(0, jobId)
+}
+
+func (s *ProductTestSuite) TestRunExportSuccessful() {
+	const (
+		expectedFile = "id,name,description,price,currency,sku,stock,categories,created_at,updated_at\n" +
+			"1,Продукт,,0,,,0,Категория,0001-01-01T00:00:00Z,0001-01-01T00:00:00Z\n"
+		expectedResult = `{"exported":1}`
+	)
+
+	job := dto.Job{ID: 1, Payload: []byte(`{"format":"csv","data":{}}`)}
+
+	s.productMock.
+		EXPECT().
+		Export(gomock.Any(), dto.GetProduct{}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ dto.GetProduct, write func(dto.ExportProduct) error) error {
+			return write(dto.ExportProduct{Product: s.product, Categories: []string{s.category.Name}})
+		}).
+		Times(1)
+
+	progress := 0
+
+	output, err := s.useCase.RunExport(s.ctx, job, func(n int) { progress = n })
+
+	s.NoError(err)
+	s.Equal(1, progress)
+	s.Equal(expectedResult, string(output.Result))
+	s.Equal(expectedFile, string(output.File.Data))
+	s.Equal("text/csv; charset=utf-8", output.File.ContentType)
+	s.True(strings.HasSuffix(output.File.Name, ".csv"))
+}
+
+func (s *ProductTestSuite) batchOperations() []dto.BatchOperation {
+	update := s.update
+
+	return []dto.BatchOperation{
+		{Type: dto.BatchCreate, Create: &s.create},
+		{Type: dto.BatchUpdate, ID: s.update.ID, Update: &update},
+		{Type: dto.BatchDelete, ID: s.product.ID},
+	}
+}
+
+func (s *ProductTestSuite) TestBatchSuccessful() {
+	s.transactionMock.
+		EXPECT().
+		Do(s.ctx, gomock.Any()).
+		DoAndReturn(s.runTransaction).
+		Times(1)
+
+	s.categoryMock.
+		EXPECT().
+		GetById(gomock.Any(), gomock.Any()).
+		Return(s.category, nil).
+		Times(2)
+
+	s.productMock.
+		EXPECT().
+		Create(gomock.Any(), s.create, []dto.Category{s.category}).
+		Return(2, nil).
+		Times(1)
+
+	s.productMock.
+		EXPECT().
+		Update(gomock.Any(), s.update, s.category).
+		Return(s.update.ID, nil).
+		Times(1)
+
+	s.productMock.
+		EXPECT().
+		Delete(gomock.Any(), s.product.ID, 0).
+		Return(s.product.ID, nil).
+		Times(1)
+
+	result, err := s.useCase.Batch(s.ctx, dto.BatchProducts{
+		Operations: s.batchOperations(),
+		Atomic:     true,
+	})
+
+	s.NoError(err)
+	s.Equal(dto.BatchResult{
+		Atomic:    true,
+		Committed: true,
+		Succeeded: 3,
+		Results: []dto.BatchOperationResult{
+			{Index: 0, Type: dto.BatchCreate, Status: dto.BatchDone, ID: 2},
+			{Index: 1, Type: dto.BatchUpdate, Status: dto.BatchDone, ID: 1},
+			{Index: 2, Type: dto.BatchDelete, Status: dto.BatchDone, ID: 1},
+		},
+	}, result)
+}
+
+func (s *ProductTestSuite) TestBatchRolledBack() {
+	const (
+		expectedErrorMsg = "product has been modified"
+	)
+
+	s.transactionMock.
+		EXPECT().
+		Do(s.ctx, gomock.Any()).
+		DoAndReturn(s.runTransaction).
+		Times(1)
+
+	s.categoryMock.
+		EXPECT().
+		GetById(gomock.Any(), gomock.Any()).
+		Return(s.category, nil).
+		Times(2)
+
+	s.productMock.
+		EXPECT().
+		Create(gomock.Any(), s.create, []dto.Category{s.category}).
+		Return(2, nil).
+		Times(1)
+
+	s.productMock.
+		EXPECT().
+		Update(gomock.Any(), s.update, s.category).
+		Return(0, errors.ErrConflict.New(expectedErrorMsg)).
+		Times(1)
+
+	result, err := s.useCase.Batch(s.ctx, dto.BatchProducts{
+		Operations: s.batchOperations(),
+		Atomic:     true,
+	})
+
+	s.NoError(err)
+	s.Equal(dto.BatchResult{
+		Atomic: true,
+		Failed: 1,
+		Results: []dto.BatchOperationResult{
+			{Index: 0, Type: dto.BatchCreate, Status: dto.BatchRolledBack},
+			{Index: 1, Type: dto.BatchUpdate, Status: dto.BatchFailed, Error: expectedErrorMsg},
+			{Index: 2, Type: dto.BatchDelete, Status: dto.BatchSkipped},
+		},
+	}, result)
+}
+
+func (s *ProductTestSuite) TestBatchTransactionFailed() {
+	const (
+		expectedErrorMsg = "unknown error on executing transaction"
+	)
+
+	s.transactionMock.
+		EXPECT().
+		Do(s.ctx, gomock.Any()).
+		Return(errors.ErrInternal.New(expectedErrorMsg)).
+		Times(1)
+
+	_, err := s.useCase.Batch(s.ctx, dto.BatchProducts{
+		Operations: s.batchOperations(),
+		Atomic:     true,
+	})
+
+	s.EqualError(err, expectedErrorMsg)
+}
+
+func (s *ProductTestSuite) TestBatchNonAtomicSuccessful() {
+	const (
+		expectedErrorMsg = "product not found"
+	)
+
+	s.categoryMock.
+		EXPECT().
+		GetById(s.ctx, gomock.Any()).
+		Return(s.category, nil).
+		Times(2)
+
+	s.productMock.
+		EXPECT().
+		Create(gomock.Any(), s.create, []dto.Category{s.category}).
+		Return(2, nil).
+		Times(1)
+
+	s.productMock.
+		EXPECT().
+		Update(gomock.Any(), s.update, s.category).
+		Return(0, errors.ErrNotFound.New(expectedErrorMsg)).
+		Times(1)
+
+	s.productMock.
+		EXPECT().
+		Delete(gomock.Any(), s.product.ID, 0).
+		Return(s.product.ID, nil).
+		Times(1)
+
+	result, err := s.useCase.Batch(s.ctx, dto.BatchProducts{
+		Operations: s.batchOperations(),
+	})
+
+	s.NoError(err)
+	s.Equal(dto.BatchResult{
+		Committed: true,
+		Succeeded: 2,
+		Failed:    1,
+		Results: []dto.BatchOperationResult{
+			{Index: 0, Type: dto.BatchCreate, Status: dto.BatchDone, ID: 2},
+			{Index: 1, Type: dto.BatchUpdate, Status: dto.BatchFailed, Error: expectedErrorMsg},
+			{Index: 2, Type: dto.BatchDelete, Status: dto.BatchDone, ID: 1},
+		},
+	}, result)
+}
+
+func (s *ProductTestSuite) runTransaction(
+	ctx context.Context,
+	fn func(context.Context) error,
+) error {
+
+	return fn(ctx)
+}
@@ -0,0 +1,429 @@
+package job
+
+import (
+	"context"
+	"github.com/jackvonhouse/product-catalog/config"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/errors"
+	"github.com/jackvonhouse/product-catalog/internal/principal"
+	"github.com/jackvonhouse/product-catalog/internal/requestid"
+	errpkg "github.com/jackvonhouse/product-catalog/pkg/errors"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	purgeInterval = time.Hour
+	queryTimeout  = 5 * time.Second
+)
+
+var (
+	errCancelled = errors.ErrConflict.New("job cancelled")
+	errShutdown  = errors.ErrInternal.New("worker shutdown")
+)
+
+type useCase interface {
+	Dequeue(context.Context, time.Time, int) (dto.Job, error)
+	Heartbeat(context.Context, int, int, int) (bool, error)
+	Finish(context.Context, dto.FinishJob) error
+	Requeue(context.Context, int, int) error
+	FailExhausted(context.Context, time.Time, int) (int64, error)
+	Purge(context.Context, time.Time) (int64, error)
+}
+
+// Handler выполняет задачу. progress сообщает число обработанных строк,
+// оно сохраняется вместе с heartbeat
+type Handler func(context.Context, dto.Job, func(int)) (dto.JobOutput, error)
+
+type Worker struct {
+	jobs     useCase
+	handlers map[string]Handler
+
+	workers      int
+	pollInterval time.Duration
+	staleAfter   time.Duration
+	maxAttempts  int
+	retention    time.Duration
+	drainTimeout time.Duration
+
+	done    chan struct{}
+	stopped chan struct{}
+
+	abort       context.Context
+	abortCancel context.CancelFunc
+
+	logger log.Logger
+}
+
+func New(
+	jobs useCase,
+	handlers map[string]Handler,
+	config config.Jobs,
+	logger log.Logger,
+) Worker {
+
+	abort, abortCancel := context.WithCancel(context.Background())
+
+	return Worker{
+		jobs:         jobs,
+		handlers:     handlers,
+		workers:      config.Workers,
+		pollInterval: time.Duration(config.PollInterval) * time.Second,
+		staleAfter:   time.Duration(config.StaleAfter) * time.Second,
+		maxAttempts:  config.MaxAttempts,
+		retention:    time.Duration(config.Retention) * time.Hour,
+		drainTimeout: time.Duration(config.DrainTimeout) * time.Second,
+		done:         make(chan struct{}),
+		stopped:      make(chan struct{}),
+		abort:        abort,
+		abortCancel:  abortCancel,
+		logger:       logger.WithField("unit", "job"),
+	}
+}
+
+func (w Worker) Run() {
+	defer close(w.stopped)
+
+	if w.workers <= 0 || w.pollInterval <= 0 || w.staleAfter <= 0 {
+		w.logger.Info("job workers are disabled")
+
+		return
+	}
+
+	w.logger.WithFields(map[string]any{
+		"workers":       w.workers,
+		"poll_interval": w.pollInterval,
+		"stale_after":   w.staleAfter,
+		"max_attempts":  w.maxAttempts,
+	}).Info("job workers started")
+
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < w.workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			w.poll()
+		}()
+	}
+
+	if w.maxAttempts > 0 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			w.failExhausted()
+		}()
+	}
+
+	if w.retention > 0 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			w.purgeFinished()
+		}()
+	}
+
+	wg.Wait()
+}
+
+// Shutdown перестаёт брать новые задачи и ждёт выполняющиеся. Если они не
+// успели за drainTimeout, они прерываются и возвращаются в очередь
+func (w Worker) Shutdown(
+	ctx context.Context,
+) error {
+
+	close(w.done)
+	defer w.abortCancel()
+
+	timer := time.NewTimer(w.drainTimeout)
+	defer timer.Stop()
+
+	select {
+
+	case <-w.stopped:
+		return nil
+
+	case <-timer.C:
+		w.logger.Warn("running jobs didn't finish in time, returning them to queue")
+
+	case <-ctx.Done():
+	}
+
+	w.abortCancel()
+
+	select {
+
+	case <-w.stopped:
+		return nil
+
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w Worker) poll() {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for w.next() {
+		}
+
+		select {
+
+		case <-w.done:
+			return
+
+		case <-ticker.C:
+		}
+	}
+}
+
+// next выполняет одну задачу из очереди. Возвращает false,
+// если очередь пуста или обработчик останавливается
+func (w Worker) next() bool {
+	select {
+
+	case <-w.done:
+		return false
+
+	default:
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	job, err := w.jobs.Dequeue(ctx, time.Now().Add(-w.staleAfter), w.maxAttempts)
+	if err != nil {
+		if !errpkg.Has(err, errors.ErrNotFound) {
+			w.logger.Warnf("can't dequeue job: %s", err)
+		}
+
+		return false
+	}
+
+	w.process(job)
+
+	return true
+}
+
+func (w Worker) process(
+	job dto.Job,
+) {
+
+	logger := w.logger.WithFields(map[string]any{
+		"job_id":     job.ID,
+		"attempt":    job.Attempt,
+		"type":       job.Type,
+		"request_id": job.RequestId,
+	})
+
+	finish := dto.FinishJob{ID: job.ID, Attempt: job.Attempt}
+
+	handler, ok := w.handlers[job.Type]
+	if !ok {
+		logger.Warn("unknown job type")
+
+		finish.Status = dto.JobFailed
+		finish.Error = "unknown job type"
+
+		w.finish(logger, finish)
+
+		return
+	}
+
+	ctx, cancel := context.WithCancelCause(w.jobContext(job))
+	defer cancel(nil)
+
+	stopAbort := context.AfterFunc(w.abort, func() { cancel(errShutdown) })
+	defer stopAbort()
+
+	var progress atomic.Int64
+
+	stopHeartbeat := make(chan struct{})
+	heartbeatStopped := make(chan struct{})
+
+	go func() {
+		defer close(heartbeatStopped)
+
+		w.heartbeat(job, &progress, cancel, stopHeartbeat)
+	}()
+
+	logger.Info("job started")
+
+	output, err := handler(ctx, job, func(n int) { progress.Store(int64(n)) })
+
+	close(stopHeartbeat)
+	<-heartbeatStopped
+
+	finish.Progress = int(progress.Load())
+
+	switch cause := context.Cause(ctx); {
+
+	case errpkg.Is(cause, errShutdown):
+		logger.Info("job interrupted by shutdown")
+
+		ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+		defer cancel()
+
+		if err := w.jobs.Requeue(ctx, job.ID, job.Attempt); err != nil {
+			logger.Warnf("can't requeue job: %s", err)
+		}
+
+		return
+
+	case errpkg.Is(cause, errCancelled):
+		logger.Info("job cancelled")
+
+		finish.Status = dto.JobCancelled
+		finish.Error = errCancelled.Error()
+
+	case err != nil:
+		logger.Warnf("job failed: %s", err)
+
+		finish.Status = dto.JobFailed
+		finish.Error = err.Error()
+
+	default:
+		logger.Info("job done")
+
+		finish.Status = dto.JobDone
+		finish.Result = output.Result
+		finish.File = output.File
+	}
+
+	w.finish(logger, finish)
+}
+
+// jobContext восстанавливает автора и запрос, создавшие задачу,
+// чтобы журнал изменений указывал на них
+func (w Worker) jobContext(
+	job dto.Job,
+) context.Context {
+
+	ctx := requestid.WithContext(context.Background(), job.RequestId)
+
+	if job.ActorId == nil {
+		return ctx
+	}
+
+	return principal.WithContext(ctx, principal.Principal{
+		UserId:   *job.ActorId,
+		Username: job.Actor,
+		Role:     job.ActorRole,
+	})
+}
+
+func (w Worker) heartbeat(
+	job dto.Job,
+	progress *atomic.Int64,
+	cancel context.CancelCauseFunc,
+	stop <-chan struct{},
+) {
+
+	ticker := time.NewTicker(w.staleAfter / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+
+		case <-stop:
+			return
+
+		case <-ticker.C:
+		}
+
+		ctx, cancelQuery := context.WithTimeout(context.Background(), queryTimeout)
+
+		cancelRequested, err := w.jobs.Heartbeat(ctx, job.ID, job.Attempt, int(progress.Load()))
+
+		cancelQuery()
+
+		if err != nil {
+			w.logger.Warnf("can't update heartbeat of job %d: %s", job.ID, err)
+
+			continue
+		}
+
+		if cancelRequested {
+			cancel(errCancelled)
+
+			return
+		}
+	}
+}
+
+func (w Worker) finish(
+	logger log.Logger,
+	data dto.FinishJob,
+) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	if err := w.jobs.Finish(ctx, data); err != nil {
+		logger.Warnf("can't finish job: %s", err)
+	}
+}
+
+// failExhausted раз в staleAfter завершает с ошибкой брошенные задачи,
+// у которых не осталось попыток
+func (w Worker) failExhausted() {
+	ticker := time.NewTicker(w.staleAfter)
+	defer ticker.Stop()
+
+	for {
+		select {
+
+		case <-w.done:
+			return
+
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+
+		failed, err := w.jobs.FailExhausted(ctx, time.Now().Add(-w.staleAfter), w.maxAttempts)
+		if err != nil {
+			w.logger.Warnf("can't fail exhausted jobs: %s", err)
+		} else if failed > 0 {
+			w.logger.Warnf("%d jobs failed after %d attempts", failed, w.maxAttempts)
+		}
+
+		cancel()
+	}
+}
+
+func (w Worker) purgeFinished() {
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+
+		purged, err := w.jobs.Purge(ctx, time.Now().Add(-w.retention))
+		if err != nil {
+			w.logger.Warnf("can't purge finished jobs: %s", err)
+		} else if purged > 0 {
+			w.logger.Infof("%d finished jobs purged", purged)
+		}
+
+		cancel()
+
+		select {
+
+		case <-w.done:
+			return
+
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/worker/job/job.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/worker/job/job.go -destination=internal/worker/job/job.mock.go -package=job
+//
+
+// Package job is a generated GoMock package.
+package job
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	dto "github.com/jackvonhouse/product-catalog/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockuseCase is a mock of useCase interface.
+type MockuseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockuseCaseMockRecorder
+}
+
+// MockuseCaseMockRecorder is the mock recorder for MockuseCase.
+type MockuseCaseMockRecorder struct {
+	mock *MockuseCase
+}
+
+// NewMockuseCase creates a new mock instance.
+func NewMockuseCase(ctrl *gomock.Controller) *MockuseCase {
+	mock := &MockuseCase{ctrl: ctrl}
+	mock.recorder = &MockuseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuseCase) EXPECT() *MockuseCaseMockRecorder {
+	return m.recorder
+}
+
+// Dequeue mocks base method.
+func (m *MockuseCase) Dequeue(arg0 context.Context, arg1 time.Time, arg2 int) (dto.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dequeue", arg0, arg1, arg2)
+	ret0, _ := ret[0].(dto.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Dequeue indicates an expected call of Dequeue.
+func (mr *MockuseCaseMockRecorder) Dequeue(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dequeue", reflect.TypeOf((*MockuseCase)(nil).Dequeue), arg0, arg1, arg2)
+}
+
+// FailExhausted mocks base method.
+func (m *MockuseCase) FailExhausted(arg0 context.Context, arg1 time.Time, arg2 int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailExhausted", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailExhausted indicates an expected call of FailExhausted.
+func (mr *MockuseCaseMockRecorder) FailExhausted(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailExhausted", reflect.TypeOf((*MockuseCase)(nil).FailExhausted), arg0, arg1, arg2)
+}
+
+// Finish mocks base method.
+func (m *MockuseCase) Finish(arg0 context.Context, arg1 dto.FinishJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockuseCaseMockRecorder) Finish(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockuseCase)(nil).Finish), arg0, arg1)
+}
+
+// Heartbeat mocks base method.
+func (m *MockuseCase) Heartbeat(arg0 context.Context, arg1, arg2, arg3 int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Heartbeat", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Heartbeat indicates an expected call of Heartbeat.
+func (mr *MockuseCaseMockRecorder) Heartbeat(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Heartbeat", reflect.TypeOf((*MockuseCase)(nil).Heartbeat), arg0, arg1, arg2, arg3)
+}
+
+// Purge mocks base method.
+func (m *MockuseCase) Purge(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockuseCaseMockRecorder) Purge(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockuseCase)(nil).Purge), arg0, arg1)
+}
+
+// Requeue mocks base method.
+func (m *MockuseCase) Requeue(arg0 context.Context, arg1, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Requeue", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Requeue indicates an expected call of Requeue.
+func (mr *MockuseCaseMockRecorder) Requeue(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Requeue", reflect.TypeOf((*MockuseCase)(nil).Requeue), arg0, arg1, arg2)
+}
@@ -0,0 +1,296 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jackvonhouse/product-catalog/config"
+	"github.com/jackvonhouse/product-catalog/internal/dto"
+	"github.com/jackvonhouse/product-catalog/internal/errors"
+	"github.com/jackvonhouse/product-catalog/internal/principal"
+	"github.com/jackvonhouse/product-catalog/internal/requestid"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+type JobTestSuite struct {
+	suite.Suite
+
+	// Вспомогательные параметры
+	ctx    context.Context
+	logger log.Logger
+	worker Worker
+
+	// Входные параметры
+	config  config.Jobs
+	job     dto.Job
+	handler Handler
+
+	// Служебные параметры
+	useCaseMock *MockuseCase
+}
+
+func TestSuiteJob(t *testing.T) {
+	suite.Run(t, &JobTestSuite{})
+}
+
+func (s *JobTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.logger = log.NewNullLogger()
+}
+
+func (s *JobTestSuite) BeforeTest(_, _ string) {
+	controller := gomock.NewController(s.T())
+
+	// Входные значения по-умолчанию
+	s.setupMock(controller).
+		setupConfig(1, 60, 5).
+		setupJob(1, "admin")
+}
+
+func (s *JobTestSuite) setupMock(
+	controller *gomock.Controller,
+) *JobTestSuite {
+
+	s.useCaseMock = NewMockuseCase(controller)
+
+	return s
+}
+
+func (s *JobTestSuite) setupConfig(
+	workers int,
+	staleAfter int,
+	drainTimeout int,
+) *JobTestSuite {
+
+	s.config = config.Jobs{
+		Workers:      workers,
+		PollInterval: 60,
+		StaleAfter:   staleAfter,
+		DrainTimeout: drainTimeout,
+	}
+
+	return s
+}
+
+func (s *JobTestSuite) setupJob(
+	actorId int,
+	actor string,
+) {
+
+	s.job = dto.Job{
+		ID:        1,
+		Type:      dto.JobProductExport,
+		Status:    dto.JobRunning,
+		Attempt:   2,
+		ActorId:   &actorId,
+		Actor:     actor,
+		ActorRole: dto.RoleAdmin,
+		RequestId: "request",
+	}
+}
+
+func (s *JobTestSuite) setupWorker() {
+	s.worker = New(s.useCaseMock, map[string]Handler{
+		dto.JobProductExport: s.handler,
+	}, s.config, s.logger)
+}
+
+// expectDequeue отдаёт задачу один раз, после чего очередь пуста
+func (s *JobTestSuite) expectDequeue() {
+	s.useCaseMock.
+		EXPECT().
+		Dequeue(gomock.Any(), gomock.Any(), 0).
+		Return(s.job, nil).
+		Times(1)
+
+	s.useCaseMock.
+		EXPECT().
+		Dequeue(gomock.Any(), gomock.Any(), 0).
+		Return(dto.Job{}, errors.ErrNotFound.New("job not found")).
+		AnyTimes()
+}
+
+func (s *JobTestSuite) expectFinish(
+	expected dto.FinishJob,
+) <-chan struct{} {
+
+	finished := make(chan struct{})
+
+	s.useCaseMock.
+		EXPECT().
+		Finish(gomock.Any(), expected).
+		DoAndReturn(func(context.Context, dto.FinishJob) error {
+			close(finished)
+
+			return nil
+		}).
+		Times(1)
+
+	return finished
+}
+
+func (s *JobTestSuite) TestRunSuccessful() {
+	result := json.RawMessage(`{"exported":2}`)
+	file := &dto.JobFile{Name: "products.csv", ContentType: "text/csv", Data: []byte("id\n")}
+
+	s.handler = func(ctx context.Context, job dto.Job, progress func(int)) (dto.JobOutput, error) {
+		s.Equal(1, principal.UserId(ctx))
+		s.Equal("admin", principal.Username(ctx))
+		s.True(principal.HasRole(ctx, dto.RoleAdmin))
+		s.Equal("request", requestid.FromContext(ctx))
+
+		progress(2)
+
+		return dto.JobOutput{Result: result, File: file}, nil
+	}
+
+	s.setupWorker()
+	s.expectDequeue()
+
+	finished := s.expectFinish(dto.FinishJob{
+		ID:       1,
+		Attempt:  2,
+		Status:   dto.JobDone,
+		Result:   result,
+		Progress: 2,
+		File:     file,
+	})
+
+	go s.worker.Run()
+
+	<-finished
+
+	s.NoError(s.worker.Shutdown(s.ctx))
+}
+
+func (s *JobTestSuite) TestRunFailed() {
+	s.handler = func(context.Context, dto.Job, func(int)) (dto.JobOutput, error) {
+		return dto.JobOutput{}, errors.ErrInvalid.New("unsupported export format")
+	}
+
+	s.setupWorker()
+	s.expectDequeue()
+
+	finished := s.expectFinish(dto.FinishJob{
+		ID:      1,
+		Attempt: 2,
+		Status:  dto.JobFailed,
+		Error:   "unsupported export format",
+	})
+
+	go s.worker.Run()
+
+	<-finished
+
+	s.NoError(s.worker.Shutdown(s.ctx))
+}
+
+func (s *JobTestSuite) TestRunCancelled() {
+	s.setupConfig(1, 1, 5)
+
+	s.handler = func(ctx context.Context, _ dto.Job, progress func(int)) (dto.JobOutput, error) {
+		progress(1)
+
+		<-ctx.Done()
+
+		return dto.JobOutput{}, ctx.Err()
+	}
+
+	s.setupWorker()
+	s.expectDequeue()
+
+	s.useCaseMock.
+		EXPECT().
+		Heartbeat(gomock.Any(), 1, 2, 1).
+		Return(true, nil).
+		Times(1)
+
+	finished := s.expectFinish(dto.FinishJob{
+		ID:       1,
+		Attempt:  2,
+		Status:   dto.JobCancelled,
+		Error:    "job cancelled",
+		Progress: 1,
+	})
+
+	go s.worker.Run()
+
+	<-finished
+
+	s.NoError(s.worker.Shutdown(s.ctx))
+}
+
+func (s *JobTestSuite) TestShutdownRequeueSuccessful() {
+	s.setupConfig(1, 60, 0)
+
+	started := make(chan struct{})
+
+	s.handler = func(ctx context.Context, _ dto.Job, _ func(int)) (dto.JobOutput, error) {
+		close(started)
+
+		<-ctx.Done()
+
+		return dto.JobOutput{}, ctx.Err()
+	}
+
+	s.setupWorker()
+	s.expectDequeue()
+
+	s.useCaseMock.
+		EXPECT().
+		Requeue(gomock.Any(), 1, 2).
+		Return(nil).
+		Times(1)
+
+	go s.worker.Run()
+
+	<-started
+
+	s.NoError(s.worker.Shutdown(s.ctx))
+}
+
+func (s *JobTestSuite) TestFailExhaustedSuccessful() {
+	const (
+		maxAttempts = 3
+	)
+
+	s.setupConfig(1, 1, 5)
+	s.config.MaxAttempts = maxAttempts
+
+	s.setupWorker()
+
+	s.useCaseMock.
+		EXPECT().
+		Dequeue(gomock.Any(), gomock.Any(), maxAttempts).
+		Return(dto.Job{}, errors.ErrNotFound.New("job not found")).
+		AnyTimes()
+
+	failed := make(chan struct{})
+
+	s.useCaseMock.
+		EXPECT().
+		FailExhausted(gomock.Any(), gomock.Any(), maxAttempts).
+		DoAndReturn(func(context.Context, time.Time, int) (int64, error) {
+			close(failed)
+
+			return 1, nil
+		}).
+		Times(1)
+
+	go s.worker.Run()
+
+	<-failed
+
+	s.NoError(s.worker.Shutdown(s.ctx))
+}
+
+func (s *JobTestSuite) TestRunDisabled() {
+	s.setupConfig(0, 60, 5).setupWorker()
+
+	go s.worker.Run()
+
+	s.NoError(s.worker.Shutdown(s.ctx))
+}
@@ -0,0 +1,164 @@
+package purge
+
+import (
+	"context"
+	"github.com/jackvonhouse/product-catalog/config"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"sync"
+	"time"
+)
+
+type useCase interface {
+	Purge(context.Context, time.Time) (int64, error)
+}
+
+type Worker struct {
+	product     useCase
+	category    useCase
+	idempotency useCase
+
+	retention time.Duration
+	interval  time.Duration
+
+	done    chan struct{}
+	stopped chan struct{}
+
+	logger log.Logger
+}
+
+func New(
+	product useCase,
+	category useCase,
+	idempotency useCase,
+	config config.Purge,
+	logger log.Logger,
+) Worker {
+
+	return Worker{
+		product:     product,
+		category:    category,
+		idempotency: idempotency,
+		retention:   time.Duration(config.Retention) * time.Hour,
+		interval:    time.Duration(config.Interval) * time.Minute,
+		done:        make(chan struct{}),
+		stopped:     make(chan struct{}),
+		logger:      logger.WithField("unit", "purge"),
+	}
+}
+
+// Run запускает очистку удалённых записей и истёкших ключей идемпотентности.
+// Ключи удаляются и при отключённом хранении удалённых записей: иначе таблица
+// ключей растёт без ограничений
+func (w Worker) Run() {
+	defer close(w.stopped)
+
+	if w.interval <= 0 {
+		w.logger.Info("purge is disabled")
+
+		return
+	}
+
+	wg := sync.WaitGroup{}
+
+	if w.retention > 0 {
+		w.logger.WithFields(map[string]any{
+			"retention": w.retention,
+			"interval":  w.interval,
+		}).Info("purge of deleted rows started")
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			w.every(w.purgeDeleted)
+		}()
+	} else {
+		w.logger.Info("purge of deleted rows is disabled")
+	}
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		w.every(w.purgeIdempotencyKeys)
+	}()
+
+	wg.Wait()
+}
+
+func (w Worker) Shutdown(
+	ctx context.Context,
+) error {
+
+	close(w.done)
+
+	select {
+
+	case <-w.stopped:
+		return nil
+
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w Worker) every(
+	purge func(),
+) {
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		purge()
+
+		select {
+
+		case <-w.done:
+			return
+
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w Worker) purgeDeleted() {
+	ctx, cancel := context.WithTimeout(context.Background(), w.interval)
+	defer cancel()
+
+	deletedBefore := time.Now().Add(-w.retention)
+
+	products, err := w.product.Purge(ctx, deletedBefore)
+	if err != nil {
+		w.logger.Warnf("can't purge deleted products: %s", err)
+	}
+
+	categories, err := w.category.Purge(ctx, deletedBefore)
+	if err != nil {
+		w.logger.Warnf("can't purge deleted categories: %s", err)
+	}
+
+	if products > 0 || categories > 0 {
+		w.logger.WithFields(map[string]any{
+			"products":       products,
+			"categories":     categories,
+			"deleted_before": deletedBefore,
+		}).Info("deleted rows purged")
+	}
+}
+
+func (w Worker) purgeIdempotencyKeys() {
+	ctx, cancel := context.WithTimeout(context.Background(), w.interval)
+	defer cancel()
+
+	keys, err := w.idempotency.Purge(ctx, time.Now())
+	if err != nil {
+		w.logger.Warnf("can't purge expired idempotency keys: %s", err)
+	}
+
+	if keys > 0 {
+		w.logger.Infof("%d expired idempotency keys purged", keys)
+	}
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/worker/purge/purge.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/worker/purge/purge.go -destination=internal/worker/purge/purge.mock.go -package=purge
+//
+
+// Package purge is a generated GoMock package.
+package purge
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockuseCase is a mock of useCase interface.
+type MockuseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockuseCaseMockRecorder
+}
+
+// MockuseCaseMockRecorder is the mock recorder for MockuseCase.
+type MockuseCaseMockRecorder struct {
+	mock *MockuseCase
+}
+
+// NewMockuseCase creates a new mock instance.
+func NewMockuseCase(ctrl *gomock.Controller) *MockuseCase {
+	mock := &MockuseCase{ctrl: ctrl}
+	mock.recorder = &MockuseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuseCase) EXPECT() *MockuseCaseMockRecorder {
+	return m.recorder
+}
+
+// Purge mocks base method.
+func (m *MockuseCase) Purge(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockuseCaseMockRecorder) Purge(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockuseCase)(nil).Purge), arg0, arg1)
+}
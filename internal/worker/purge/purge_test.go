@@ -0,0 +1,175 @@
+package purge
+
+import (
+	"context"
+	"errors"
+	"github.com/jackvonhouse/product-catalog/config"
+	"github.com/jackvonhouse/product-catalog/pkg/log"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+type PurgeTestSuite struct {
+	suite.Suite
+
+	// Вспомогательные параметры
+	ctx    context.Context
+	logger log.Logger
+	worker Worker
+
+	// Входные параметры
+	config config.Purge
+
+	// Служебные параметры
+	productMock     *MockuseCase
+	categoryMock    *MockuseCase
+	idempotencyMock *MockuseCase
+}
+
+func TestSuitePurge(t *testing.T) {
+	suite.Run(t, &PurgeTestSuite{})
+}
+
+func (s *PurgeTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.logger = log.NewNullLogger()
+}
+
+func (s *PurgeTestSuite) BeforeTest(_, _ string) {
+	controller := gomock.NewController(s.T())
+
+	// Входные значения по-умолчанию
+	s.setupMock(controller).setupConfig(24, 60)
+}
+
+func (s *PurgeTestSuite) setupMock(
+	controller *gomock.Controller,
+) *PurgeTestSuite {
+
+	s.productMock = NewMockuseCase(controller)
+	s.categoryMock = NewMockuseCase(controller)
+	s.idempotencyMock = NewMockuseCase(controller)
+
+	return s
+}
+
+func (s *PurgeTestSuite) setupConfig(
+	retention int,
+	interval int,
+) *PurgeTestSuite {
+
+	s.config = config.Purge{
+		Retention: retention,
+		Interval:  interval,
+	}
+
+	return s
+}
+
+func (s *PurgeTestSuite) setupWorker() {
+	s.worker = New(s.productMock, s.categoryMock, s.idempotencyMock, s.config, s.logger)
+}
+
+func (s *PurgeTestSuite) retentionMatcher() gomock.Matcher {
+	retention := time.Duration(s.config.Retention) * time.Hour
+
+	return gomock.Cond(func(x any) bool {
+		deletedBefore, ok := x.(time.Time)
+
+		return ok && time.Since(deletedBefore)-retention < time.Minute
+	})
+}
+
+func (s *PurgeTestSuite) expiredMatcher() gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		expiredBefore, ok := x.(time.Time)
+
+		return ok && time.Since(expiredBefore) < time.Minute
+	})
+}
+
+// expectPurge закрывает канал после вызова, чтобы тест дождался
+// очистки, выполняемой в отдельной горутине
+func (s *PurgeTestSuite) expectPurge(
+	mock *MockuseCase,
+	matcher gomock.Matcher,
+	purged int64,
+	err error,
+) <-chan struct{} {
+
+	done := make(chan struct{})
+
+	mock.
+		EXPECT().
+		Purge(gomock.Any(), matcher).
+		DoAndReturn(func(context.Context, time.Time) (int64, error) {
+			close(done)
+
+			return purged, err
+		}).
+		Times(1)
+
+	return done
+}
+
+func (s *PurgeTestSuite) TestRunSuccessful() {
+	s.setupWorker()
+
+	s.productMock.
+		EXPECT().
+		Purge(gomock.Any(), s.retentionMatcher()).
+		Return(int64(1), nil).
+		Times(1)
+
+	categories := s.expectPurge(s.categoryMock, s.retentionMatcher(), 0, nil)
+	keys := s.expectPurge(s.idempotencyMock, s.expiredMatcher(), 2, nil)
+
+	go s.worker.Run()
+
+	<-categories
+	<-keys
+
+	s.NoError(s.worker.Shutdown(s.ctx))
+}
+
+func (s *PurgeTestSuite) TestRunFailed() {
+	s.setupWorker()
+
+	s.productMock.
+		EXPECT().
+		Purge(gomock.Any(), gomock.Any()).
+		Return(int64(0), errors.New("unknown error")).
+		Times(1)
+
+	categories := s.expectPurge(s.categoryMock, gomock.Any(), 0, errors.New("unknown error"))
+	keys := s.expectPurge(s.idempotencyMock, gomock.Any(), 0, errors.New("unknown error"))
+
+	go s.worker.Run()
+
+	<-categories
+	<-keys
+
+	s.NoError(s.worker.Shutdown(s.ctx))
+}
+
+func (s *PurgeTestSuite) TestRunIdempotencyKeysOnly() {
+	s.setupConfig(0, 60).setupWorker()
+
+	keys := s.expectPurge(s.idempotencyMock, s.expiredMatcher(), 2, nil)
+
+	go s.worker.Run()
+
+	<-keys
+
+	s.NoError(s.worker.Shutdown(s.ctx))
+}
+
+func (s *PurgeTestSuite) TestRunDisabled() {
+	s.setupConfig(24, 0).setupWorker()
+
+	go s.worker.Run()
+
+	s.NoError(s.worker.Shutdown(s.ctx))
+}
@@ -0,0 +1,13 @@
+BEGIN;
+
+ALTER TABLE product
+    DROP CONSTRAINT IF EXISTS product_stock_non_negative,
+    DROP CONSTRAINT IF EXISTS product_price_non_negative,
+    DROP CONSTRAINT IF EXISTS product_sku_unique,
+    DROP COLUMN IF EXISTS stock,
+    DROP COLUMN IF EXISTS sku,
+    DROP COLUMN IF EXISTS currency,
+    DROP COLUMN IF EXISTS price,
+    DROP COLUMN IF EXISTS description;
+
+COMMIT;
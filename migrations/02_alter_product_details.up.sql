@@ -0,0 +1,18 @@
+BEGIN;
+
+ALTER TABLE product
+    ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS price BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'RUB',
+    ADD COLUMN IF NOT EXISTS sku TEXT,
+    ADD COLUMN IF NOT EXISTS stock INTEGER NOT NULL DEFAULT 0;
+
+UPDATE product SET sku = 'SKU-' || id WHERE sku IS NULL;
+
+ALTER TABLE product
+    ALTER COLUMN sku SET NOT NULL,
+    ADD CONSTRAINT product_sku_unique UNIQUE (sku),
+    ADD CONSTRAINT product_price_non_negative CHECK (price >= 0),
+    ADD CONSTRAINT product_stock_non_negative CHECK (stock >= 0);
+
+COMMIT;
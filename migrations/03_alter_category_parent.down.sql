@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP INDEX IF EXISTS category_parent_id_idx;
+
+ALTER TABLE category
+    DROP CONSTRAINT IF EXISTS category_not_self_parent,
+    DROP COLUMN IF EXISTS parent_id;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE category
+    ADD COLUMN IF NOT EXISTS parent_id INTEGER REFERENCES category(id) ON DELETE SET NULL,
+    ADD CONSTRAINT category_not_self_parent CHECK (parent_id <> id);
+
+CREATE INDEX IF NOT EXISTS category_parent_id_idx ON category (parent_id);
+
+COMMIT;
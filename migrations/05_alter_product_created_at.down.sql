@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP INDEX IF EXISTS product_name_pattern_idx;
+DROP INDEX IF EXISTS product_created_at_idx;
+
+ALTER TABLE product
+    DROP COLUMN IF EXISTS created_at;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE product
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE INDEX IF NOT EXISTS product_created_at_idx ON product (created_at);
+CREATE INDEX IF NOT EXISTS product_name_pattern_idx ON product (name text_pattern_ops);
+
+COMMIT;
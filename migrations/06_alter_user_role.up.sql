@@ -0,0 +1,12 @@
+BEGIN;
+
+ALTER TABLE "user"
+    ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'viewer';
+
+ALTER TABLE "user"
+    DROP CONSTRAINT IF EXISTS user_role_check;
+
+ALTER TABLE "user"
+    ADD CONSTRAINT user_role_check CHECK (role IN ('admin', 'editor', 'viewer'));
+
+COMMIT;
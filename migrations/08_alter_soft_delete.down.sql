@@ -0,0 +1,26 @@
+BEGIN;
+
+DELETE FROM product WHERE deleted_at IS NOT NULL;
+DELETE FROM category WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS category_deleted_at_idx;
+DROP INDEX IF EXISTS product_deleted_at_idx;
+
+DROP INDEX IF EXISTS category_unique;
+DROP INDEX IF EXISTS product_sku_unique;
+DROP INDEX IF EXISTS product_unique;
+
+ALTER TABLE category
+    ADD CONSTRAINT category_unique UNIQUE (name);
+
+ALTER TABLE product
+    ADD CONSTRAINT product_unique UNIQUE (name),
+    ADD CONSTRAINT product_sku_unique UNIQUE (sku);
+
+ALTER TABLE category
+    DROP COLUMN IF EXISTS deleted_at;
+
+ALTER TABLE product
+    DROP COLUMN IF EXISTS deleted_at;
+
+COMMIT;
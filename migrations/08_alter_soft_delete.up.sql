@@ -0,0 +1,23 @@
+BEGIN;
+
+ALTER TABLE product
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+ALTER TABLE category
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+ALTER TABLE product
+    DROP CONSTRAINT IF EXISTS product_unique,
+    DROP CONSTRAINT IF EXISTS product_sku_unique;
+
+ALTER TABLE category
+    DROP CONSTRAINT IF EXISTS category_unique;
+
+CREATE UNIQUE INDEX IF NOT EXISTS product_unique ON product (name) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS product_sku_unique ON product (sku) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS category_unique ON category (name) WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS product_deleted_at_idx ON product (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS category_deleted_at_idx ON category (deleted_at) WHERE deleted_at IS NOT NULL;
+
+COMMIT;
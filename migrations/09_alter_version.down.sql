@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE category
+    DROP COLUMN IF EXISTS version;
+
+ALTER TABLE product
+    DROP COLUMN IF EXISTS version;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE category
+    DROP COLUMN IF EXISTS updated_at;
+
+ALTER TABLE product
+    DROP COLUMN IF EXISTS updated_at;
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+DROP INDEX IF EXISTS refresh_family_id_idx;
+
+ALTER TABLE refresh
+    DROP COLUMN IF EXISTS used_at,
+    DROP COLUMN IF EXISTS parent_id,
+    DROP COLUMN IF EXISTS family_id;
+
+COMMIT;
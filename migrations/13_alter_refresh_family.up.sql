@@ -0,0 +1,15 @@
+BEGIN;
+
+ALTER TABLE refresh
+    ADD COLUMN IF NOT EXISTS family_id TEXT,
+    ADD COLUMN IF NOT EXISTS parent_id INTEGER REFERENCES refresh(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS used_at BIGINT;
+
+UPDATE refresh SET family_id = id::TEXT WHERE family_id IS NULL;
+
+ALTER TABLE refresh
+    ALTER COLUMN family_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS refresh_family_id_idx ON refresh (family_id);
+
+COMMIT;
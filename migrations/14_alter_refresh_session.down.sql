@@ -0,0 +1,11 @@
+BEGIN;
+
+DROP INDEX IF EXISTS refresh_user_id_idx;
+
+ALTER TABLE refresh
+    DROP COLUMN IF EXISTS last_used_at,
+    DROP COLUMN IF EXISTS ip,
+    DROP COLUMN IF EXISTS user_agent,
+    DROP COLUMN IF EXISTS device_name;
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE refresh
+    ADD COLUMN IF NOT EXISTS device_name TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS user_agent TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS ip TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS last_used_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE INDEX IF NOT EXISTS refresh_user_id_idx ON refresh (user_id);
+
+COMMIT;
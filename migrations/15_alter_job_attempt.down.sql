@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE job
+    DROP COLUMN IF EXISTS attempt;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE job
+    ADD COLUMN IF NOT EXISTS attempt INTEGER NOT NULL DEFAULT 0;
+
+COMMIT;
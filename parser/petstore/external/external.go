@@ -149,8 +149,8 @@ func (s PetStore) createProduct(
 ) (int, error) {
 
 	url := fmt.Sprintf("%s/api/v1/product", config.Source)
-	body := fmt.Sprintf(`{"name": "%s", "category_id": %d}`,
-		pet.Name, pet.Category.ID,
+	body := fmt.Sprintf(`{"name": "%s", "sku": "PET-%d", "category_ids": [%d]}`,
+		pet.Name, pet.ID, pet.Category.ID,
 	)
 
 	req, err := http.NewRequestWithContext(